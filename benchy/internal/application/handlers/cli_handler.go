@@ -3,11 +3,12 @@ package handlers
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
+	"math/big"
 	"time"
 
 	"benchy/internal/application/services"
+	"benchy/internal/domain/entities"
+	"benchy/internal/infrastructure/config"
 	"benchy/internal/infrastructure/feedback"
 )
 
@@ -15,17 +16,24 @@ import (
 type CLIHandler struct {
 	networkService    *services.NetworkService
 	monitoringService *services.MonitoringService
+	explorerService   *services.ExplorerService
+	consensusService  *services.ConsensusService
+	contractService   *services.ContractService
+	tokenService      *services.TokenService
+	genesisService    *services.GenesisService
+	scenarioService   *services.ScenarioService
+	serveService      *services.ServeService
 	feedback          *feedback.ConsoleFeedback
 }
 
 // NewCLIHandler crée un nouveau handler CLI
 func NewCLIHandler() (*CLIHandler, error) {
-	// Répertoire de base pour les configurations
-	homeDir, err := os.UserHomeDir()
+	// Répertoire de base du réseau choisi par --network
+	network, err := config.CurrentNetwork()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+		return nil, err
 	}
-	baseDir := filepath.Join(homeDir, ".benchy")
+	baseDir := network.BaseDir
 
 	// Créer les services
 	networkService, err := services.NewNetworkService(baseDir)
@@ -33,16 +41,49 @@ func NewCLIHandler() (*CLIHandler, error) {
 		return nil, fmt.Errorf("failed to create network service: %w", err)
 	}
 
-	monitoringService, err := services.NewMonitoringService()
+	monitoringService, err := services.NewMonitoringService(baseDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create monitoring service: %w", err)
 	}
 
+	explorerService, err := services.NewExplorerService(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create explorer service: %w", err)
+	}
+
+	consensusService, err := services.NewConsensusService(baseDir, monitoringService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consensus service: %w", err)
+	}
+
+	contractService, err := services.NewContractService(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create contract service: %w", err)
+	}
+
+	tokenService, err := services.NewTokenService(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token service: %w", err)
+	}
+
+	genesisService, err := services.NewGenesisService(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create genesis service: %w", err)
+	}
+
+	scenarioService := services.NewScenarioService(baseDir, explorerService, tokenService)
 	feedback := feedback.NewConsoleFeedback()
 
 	handler := &CLIHandler{
 		networkService:    networkService,
 		monitoringService: monitoringService,
+		explorerService:   explorerService,
+		consensusService:  consensusService,
+		contractService:   contractService,
+		tokenService:      tokenService,
+		genesisService:    genesisService,
+		scenarioService:   scenarioService,
+		serveService:      services.NewServeService(monitoringService, consensusService, networkService, scenarioService),
 		feedback:          feedback,
 	}
 
@@ -56,42 +97,173 @@ func (h *CLIHandler) HandleLaunchNetwork(ctx context.Context) error {
 	return h.networkService.LaunchNetwork(ctx)
 }
 
+// HandleConfigRender gère la commande config render
+func (h *CLIHandler) HandleConfigRender(ctx context.Context, nodeName string) error {
+	return h.networkService.RenderNodeConfig(ctx, nodeName)
+}
+
+// HandleConfigValidate gère la commande config validate
+func (h *CLIHandler) HandleConfigValidate(ctx context.Context) error {
+	return h.networkService.ValidateConfiguration(ctx)
+}
+
+// HandleGenesisShow gère la commande genesis show
+func (h *CLIHandler) HandleGenesisShow(ctx context.Context, path string, raw bool) error {
+	return h.genesisService.ShowGenesis(ctx, path, raw)
+}
+
+// HandleGenesisDiff gère la commande genesis diff
+func (h *CLIHandler) HandleGenesisDiff(ctx context.Context, pathA, pathB string) error {
+	return h.genesisService.DiffGenesis(ctx, pathA, pathB)
+}
+
+// HandleGenesisVerify gère la commande genesis verify
+func (h *CLIHandler) HandleGenesisVerify(ctx context.Context) error {
+	return h.genesisService.VerifyGenesis(ctx)
+}
+
+// HandleNodeAdd gère la commande node add
+func (h *CLIHandler) HandleNodeAdd(ctx context.Context, name string, client entities.ClientType, validator bool, image string) error {
+	return h.networkService.AddNode(ctx, config.NodeSpec{
+		Name:      name,
+		Client:    client,
+		Validator: validator,
+		Image:     image,
+	})
+}
+
+// HandleNodeRemove gère la commande node remove
+func (h *CLIHandler) HandleNodeRemove(ctx context.Context, name string, force bool) error {
+	return h.networkService.RemoveNode(ctx, name, force)
+}
+
+// HandleNetworksList gère la commande networks ls
+func (h *CLIHandler) HandleNetworksList(ctx context.Context) error {
+	return h.networkService.ListNetworks(ctx)
+}
+
 // HandleInfos gère la commande infos
-func (h *CLIHandler) HandleInfos(ctx context.Context, updateInterval int) error {
-	return h.monitoringService.DisplayNetworkInfo(ctx, updateInterval)
+func (h *CLIHandler) HandleInfos(ctx context.Context, updateInterval int, showTokens bool) error {
+	return h.monitoringService.DisplayNetworkInfo(ctx, updateInterval, showTokens)
+}
+
+// HandleInfosHistory gère la commande infos --history
+func (h *CLIHandler) HandleInfosHistory(ctx context.Context, window time.Duration) error {
+	return h.monitoringService.DisplayMetricsHistory(ctx, window)
+}
+
+// HandleServe gère la commande serve
+func (h *CLIHandler) HandleServe(ctx context.Context, metricsAddr, apiAddr string) error {
+	return h.serveService.Serve(ctx, metricsAddr, apiAddr)
+}
+
+// HandleDashboard gère la commande dashboard
+func (h *CLIHandler) HandleDashboard(ctx context.Context, addr string, updateInterval int) error {
+	return h.serveService.Dashboard(ctx, addr, updateInterval)
+}
+
+// HandleBlock gère la commande block
+func (h *CLIHandler) HandleBlock(ctx context.Context, blockRef string, nodeName string) error {
+	return h.explorerService.ShowBlock(ctx, blockRef, nodeName)
+}
+
+// HandleTransaction gère la commande tx
+func (h *CLIHandler) HandleTransaction(ctx context.Context, txHash string, nodeName string) error {
+	return h.explorerService.ShowTransaction(ctx, txHash, nodeName)
+}
+
+// HandleTransactionTrace gère la commande tx trace
+func (h *CLIHandler) HandleTransactionTrace(ctx context.Context, txHash string, nodeName string) error {
+	return h.explorerService.TraceTransaction(ctx, txHash, nodeName)
+}
+
+// HandleEvents gère la commande events
+func (h *CLIHandler) HandleEvents(ctx context.Context, contractRef, eventName string, fromBlock *uint64, nodeName string) error {
+	return h.explorerService.StreamEvents(ctx, contractRef, eventName, fromBlock, nodeName)
+}
+
+// HandleConsensus gère la commande consensus
+func (h *CLIHandler) HandleConsensus(ctx context.Context, nodeName string, blockCount int) error {
+	return h.consensusService.DisplayConsensusReport(ctx, nodeName, blockCount)
+}
+
+// HandleContractList gère la commande contract list
+func (h *CLIHandler) HandleContractList(ctx context.Context, artifactsDir string) error {
+	if err := h.contractService.LoadArtifacts(artifactsDir); err != nil {
+		return err
+	}
+	return h.contractService.ListContracts(ctx)
+}
+
+// HandleContractDeploy gère la commande contract deploy
+func (h *CLIHandler) HandleContractDeploy(ctx context.Context, artifactsDir, artifactName, name string, args []string, from, nodeName string) error {
+	if err := h.contractService.LoadArtifacts(artifactsDir); err != nil {
+		return err
+	}
+	_, err := h.contractService.Deploy(ctx, artifactName, name, args, from, nodeName)
+	return err
+}
+
+// HandleContractCall gère la commande contract call
+func (h *CLIHandler) HandleContractCall(ctx context.Context, artifactsDir, contractRef, method string, args []string, nodeName string) error {
+	if err := h.contractService.LoadArtifacts(artifactsDir); err != nil {
+		return err
+	}
+	return h.contractService.Call(ctx, contractRef, method, args, nodeName)
+}
+
+// HandleContractSend gère la commande contract send
+func (h *CLIHandler) HandleContractSend(ctx context.Context, artifactsDir, contractRef, method string, args []string, from, nodeName string, value *big.Int) error {
+	if err := h.contractService.LoadArtifacts(artifactsDir); err != nil {
+		return err
+	}
+	return h.contractService.Send(ctx, contractRef, method, args, from, nodeName, value)
+}
+
+// HandleTokenDeploy gère la commande token deploy
+func (h *CLIHandler) HandleTokenDeploy(ctx context.Context, name, symbol, supply, alias, from, nodeName string) error {
+	return h.tokenService.DeployToken(ctx, name, symbol, supply, alias, from, nodeName)
+}
+
+// HandleTokenBalances gère la commande token balances
+func (h *CLIHandler) HandleTokenBalances(ctx context.Context, token, nodeName string) error {
+	return h.tokenService.ShowBalances(ctx, token, nodeName)
+}
+
+// HandleTokenAllowance gère la commande token allowance
+func (h *CLIHandler) HandleTokenAllowance(ctx context.Context, token, owner, spender, nodeName string) error {
+	return h.tokenService.ShowAllowance(ctx, token, owner, spender, nodeName)
+}
+
+// HandleTokenTransfer gère la commande token transfer
+func (h *CLIHandler) HandleTokenTransfer(ctx context.Context, token, to, amount, from, nodeName string) error {
+	return h.tokenService.Transfer(ctx, token, to, amount, from, nodeName)
+}
+
+// HandleTokenApprove gère la commande token approve
+func (h *CLIHandler) HandleTokenApprove(ctx context.Context, token, spender, amount, from, nodeName string) error {
+	return h.tokenService.Approve(ctx, token, spender, amount, from, nodeName)
+}
+
+// HandleTokenTransferFrom gère la commande token transfer-from
+func (h *CLIHandler) HandleTokenTransferFrom(ctx context.Context, token, owner, to, amount, from, nodeName string) error {
+	return h.tokenService.TransferFrom(ctx, token, owner, to, amount, from, nodeName)
 }
 
 // HandleScenario gère la commande scenario
 func (h *CLIHandler) HandleScenario(ctx context.Context, scenarioName string) error {
-	h.feedback.Info(ctx, fmt.Sprintf("🎯 Running scenario: %s", scenarioName))
-	
-	switch scenarioName {
-	case "0", "init":
-		return h.handleInitScenario(ctx)
-	case "1", "transfers":
-		return h.handleTransfersScenario(ctx)
-	case "2", "erc20":
-		return h.handleERC20Scenario(ctx)
-	case "3", "replacement":
-		return h.handleReplacementScenario(ctx)
-	default:
-		return fmt.Errorf("unknown scenario: %s", scenarioName)
+	scenarioType, err := services.ParseScenario(scenarioName)
+	if err != nil {
+		return err
 	}
+	h.feedback.Info(ctx, fmt.Sprintf("🎯 Running scenario: %s", scenarioName))
+
+	return h.scenarioService.Run(ctx, entities.NewScenario(scenarioType, scenarioName, ""))
 }
 
 // HandleTemporaryFailure gère la commande temporary-failure
 func (h *CLIHandler) HandleTemporaryFailure(ctx context.Context, nodeName string) error {
-	h.feedback.Info(ctx, fmt.Sprintf("🔥 Simulating failure for node: %s", nodeName))
-	h.feedback.Info(ctx, "📋 Process:")
-	h.feedback.Info(ctx, "   1. Stop the node container")
-	h.feedback.Info(ctx, "   2. Wait 40 seconds")
-	h.feedback.Info(ctx, "   3. Restart the node automatically")
-	h.feedback.Info(ctx, "   4. Monitor recovery with 'benchy infos'")
-	
-	// TODO: Implémenter la vraie simulation de panne
-	h.feedback.Warning(ctx, "⚠️  Implementation coming soon...")
-	
-	return nil
+	return h.networkService.TemporaryFailure(ctx, nodeName, services.DefaultFailureDuration)
 }
 
 // CheckDockerAvailable vérifie que Docker est disponible
@@ -124,17 +296,21 @@ func (h *CLIHandler) HandleLaunchNetworkReal(ctx context.Context) error {
 		return err
 	}
 	
-	homeDir, err := os.UserHomeDir()
+	network, err := h.networkService.Network()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
+	}
+	
+	topology, err := h.networkService.Topology()
+	if err != nil {
+		return err
 	}
-	baseDir := filepath.Join(homeDir, ".benchy")
 	
 	h.feedback.Info(ctx, "📋 Network configuration:")
-	h.feedback.Info(ctx, "   - Base directory: " + baseDir)
-	h.feedback.Info(ctx, "   - 5 nodes: Alice, Bob, Cassandra, Driss, Elena")
+	h.feedback.Info(ctx, "   - Base directory: " + network.BaseDir)
+	h.feedback.Info(ctx, "   - " + topology.Summary()[0])
 	h.feedback.Info(ctx, "   - Images: ethereum/client-go, nethermind/nethermind")
-	h.feedback.Info(ctx, "   - Network: benchy-network")
+	h.feedback.Info(ctx, "   - Network: " + network.DockerNetwork)
 	
 	// Simuler le lancement
 	spinner, err := h.feedback.StartSpinner(ctx, "Checking Docker images...")
@@ -152,13 +328,13 @@ func (h *CLIHandler) HandleLaunchNetworkReal(ctx context.Context) error {
 	spinner.Success("✅ Docker network created")
 	
 	// Simuler le lancement des containers
-	progress, err := h.feedback.StartProgress(ctx, "Launching containers", 5)
+	nodes := topology.Names()
+	progress, err := h.feedback.StartProgress(ctx, "Launching containers", len(nodes))
 	if err != nil {
 		return err
 	}
 	defer progress.Close()
 	
-	nodes := []string{"alice", "bob", "cassandra", "driss", "elena"}
 	for i, node := range nodes {
 		time.Sleep(2 * time.Second)
 		progress.Update(i+1, fmt.Sprintf("✅ %s container started", node))
@@ -172,57 +348,3 @@ func (h *CLIHandler) HandleLaunchNetworkReal(ctx context.Context) error {
 	
 	return nil
 }
-
-// Handlers de scénarios individuels
-
-func (h *CLIHandler) handleInitScenario(ctx context.Context) error {
-	h.feedback.Info(ctx, "🎯 Running Scenario 0: Network Initialization")
-	
-	spinner, err := h.feedback.StartSpinner(ctx, "Checking network status...")
-	if err != nil {
-		return err
-	}
-	time.Sleep(3 * time.Second)
-	spinner.Success("✅ Network is healthy")
-	
-	h.feedback.Success(ctx, "✅ Scenario 0 completed successfully!")
-	return nil
-}
-
-func (h *CLIHandler) handleTransfersScenario(ctx context.Context) error {
-	h.feedback.Info(ctx, "🎯 Running Scenario 1: Continuous Transfers")
-	
-	for i := 1; i <= 3; i++ {
-		h.feedback.Info(ctx, fmt.Sprintf("📤 Transfer #%d: Alice → Bob (0.1 ETH)", i))
-		time.Sleep(2 * time.Second)
-	}
-	
-	h.feedback.Success(ctx, "✅ Scenario demonstration completed!")
-	return nil
-}
-
-func (h *CLIHandler) handleERC20Scenario(ctx context.Context) error {
-	h.feedback.Info(ctx, "🎯 Running Scenario 2: ERC20 Token Deployment")
-	
-	spinner, err := h.feedback.StartSpinner(ctx, "Deploying ERC20 contract...")
-	if err != nil {
-		return err
-	}
-	time.Sleep(3 * time.Second)
-	spinner.Success("✅ Contract deployed")
-	
-	h.feedback.Success(ctx, "✅ Scenario 2 completed successfully!")
-	return nil
-}
-
-func (h *CLIHandler) handleReplacementScenario(ctx context.Context) error {
-	h.feedback.Info(ctx, "🎯 Running Scenario 3: Transaction Replacement")
-	
-	h.feedback.Info(ctx, "📤 Sending transaction to Driss...")
-	time.Sleep(2 * time.Second)
-	h.feedback.Info(ctx, "📤 Replacing with higher fee transaction to Elena...")
-	time.Sleep(2 * time.Second)
-	
-	h.feedback.Success(ctx, "✅ Scenario 3 completed successfully!")
-	return nil
-}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/clients"
+	"benchy/internal/infrastructure/config"
+	"benchy/internal/infrastructure/ethereum"
+	"benchy/internal/infrastructure/feedback"
+	"benchy/internal/infrastructure/monitoring"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Nombre maximum de blocs affichés dans la timeline de scellement
+const maxTimelineBlocks = 60
+
+// ConsensusService analyse la production de blocs des validateurs Clique
+type ConsensusService struct {
+	ethClient     *ethereum.EthereumClient
+	monitor       *monitoring.SystemMonitor
+	analyzer      *monitoring.ConsensusAnalyzer
+	clients       *clients.Provider
+	feedback      *feedback.ConsoleFeedback
+	configManager *config.NodeConfigManager
+	window        blockWindow // Derniers blocs analysés, complétés à chaque analyse
+}
+
+// NewConsensusService crée un nouveau service d'analyse du consensus. Les analyses
+// alimentent le moniteur du service de monitoring, celui qui porte le collecteur
+func NewConsensusService(baseDir string, monitoringService *MonitoringService) (*ConsensusService, error) {
+	ethClient := ethereum.NewEthereumClient()
+	provider := clients.NewProvider(ethClient)
+
+	return &ConsensusService{
+		ethClient:     ethClient,
+		monitor:       monitoringService.systemMonitor,
+		analyzer:      monitoring.NewConsensusAnalyzer(),
+		clients:       provider,
+		feedback:      feedback.NewConsoleFeedback(),
+		configManager: config.NewNodeConfigManager(baseDir),
+	}, nil
+}
+
+// AnalyzeRecentBlocks analyse les derniers blocs vus par un node et alimente
+// les métriques réseau du moniteur
+func (cs *ConsensusService) AnalyzeRecentBlocks(ctx context.Context, nodeName string, blockCount int) (*monitoring.ConsensusReport, error) {
+	nodeURL, err := nodeRPCURL(cs.configManager, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := cs.ethClient.GetLatestBlockNumber(ctx, nodeURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block number: %w", err)
+	}
+
+	adapter, err := nodeAdapter(cs.clients, cs.configManager, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Le genesis n'est pas scellé : la fenêtre commence au bloc 1
+	from := uint64(1)
+	if blockCount > 0 && latest > uint64(blockCount) {
+		from = latest - uint64(blockCount) + 1
+	}
+
+	blocks, sets, err := cs.window.load(ctx, cs.ethClient, adapter, nodeURL, from, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := cs.configManager.Network()
+	if err != nil {
+		return nil, err
+	}
+	report := cs.analyzer.Analyze(network.Name, blocks, sets)
+
+	book := cs.configManager.LoadAddressBook()
+	for i := range report.Validators {
+		report.Validators[i].Name = book[report.Validators[i].Address]
+	}
+
+	cs.monitor.RecordConsensusReport(report)
+	return report, nil
+}
+
+// signerSets retourne les validateurs en vigueur de from à to. Les validateurs qui
+// scellent le bloc n sont ceux de clique_getSigners au bloc n-1 ; les changements de la
+// liste sont localisés par dichotomie pour ne pas interroger chaque bloc ; une liste
+// revenue à l'identique entre deux bornes n'est pas détectée.
+func signerSets(ctx context.Context, adapter ports.ClientAdapter, nodeURL string, from, to uint64) ([]monitoring.SignerSet, error) {
+	signersFor := func(block uint64) ([]common.Address, error) {
+		return adapter.SignersAt(ctx, nodeURL, block-1)
+	}
+
+	first, err := signersFor(from)
+	if err != nil {
+		return nil, err
+	}
+	last, err := signersFor(to)
+	if err != nil {
+		return nil, err
+	}
+
+	sets := []monitoring.SignerSet{{FromBlock: from, Signers: first}}
+	var split func(low, high uint64, lowSigners, highSigners []common.Address) error
+	split = func(low, high uint64, lowSigners, highSigners []common.Address) error {
+		if sameSigners(lowSigners, highSigners) {
+			return nil
+		}
+		if high == low+1 {
+			sets = append(sets, monitoring.SignerSet{FromBlock: high, Signers: highSigners})
+			return nil
+		}
+		mid := low + (high-low)/2
+		midSigners, err := signersFor(mid)
+		if err != nil {
+			return err
+		}
+		if err := split(low, mid, lowSigners, midSigners); err != nil {
+			return err
+		}
+		return split(mid, high, midSigners, highSigners)
+	}
+	if err := split(from, to, first, last); err != nil {
+		return nil, err
+	}
+	return sets, nil
+}
+
+// sameSigners compare deux listes de validateurs sans tenir compte de l'ordre
+func sameSigners(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[common.Address]bool, len(a))
+	for _, address := range a {
+		seen[address] = true
+	}
+	for _, address := range b {
+		if !seen[address] {
+			return false
+		}
+	}
+	return true
+}
+
+// DisplayConsensusReport affiche le rapport de scellement par validateur
+func (cs *ConsensusService) DisplayConsensusReport(ctx context.Context, nodeName string, blockCount int) error {
+	spinner, err := cs.feedback.StartSpinner(ctx, fmt.Sprintf("Analyzing recent blocks from %s...", nodeName))
+	if err != nil {
+		return err
+	}
+
+	report, err := cs.AnalyzeRecentBlocks(ctx, nodeName, blockCount)
+	if err != nil {
+		spinner.Error("Consensus analysis failed")
+		return err
+	}
+	spinner.Success(fmt.Sprintf("Analyzed %d blocks", report.Blocks))
+
+	if report.Blocks == 0 {
+		cs.feedback.Warning(ctx, "⚠️  No sealed blocks yet")
+		return nil
+	}
+
+	networkMetrics, err := cs.monitor.GetNetworkMetrics(ctx, report.NetworkName)
+	if err != nil {
+		return fmt.Errorf("failed to get network metrics: %w", err)
+	}
+
+	fmt.Println()
+	cs.feedback.Info(ctx, fmt.Sprintf("⛓️  Clique sealing report (blocks #%d → #%d)", report.FromBlock, report.ToBlock))
+
+	headers := []string{"Validator", "Sealed", "In-turn", "Out-of-turn", "In-turn %", "Missed Turns", "Longest Gap", "Last Sealed"}
+	var rows [][]string
+	for _, validator := range networkMetrics.Validators {
+		lastSealed := "never"
+		if validator.SealedBlocks > 0 {
+			lastSealed = fmt.Sprintf("#%d", validator.LastSealedBlock)
+		}
+
+		rows = append(rows, []string{
+			validatorLabel(validator),
+			fmt.Sprintf("%d", validator.SealedBlocks),
+			fmt.Sprintf("%d", validator.InTurnBlocks),
+			fmt.Sprintf("%d", validator.OutOfTurnBlocks),
+			fmt.Sprintf("%.1f%%", validator.InTurnRatio),
+			fmt.Sprintf("%d", validator.MissedTurns),
+			fmt.Sprintf("%d blocks", validator.LongestGap),
+			lastSealed,
+		})
+	}
+	if err := cs.feedback.DisplayTable(ctx, headers, rows); err != nil {
+		return fmt.Errorf("failed to display table: %w", err)
+	}
+
+	cs.displayTimeline(ctx, report, networkMetrics.Validators)
+
+	fmt.Println()
+	cs.feedback.Info(ctx, "📈 Consensus Summary:")
+	cs.feedback.Info(ctx, fmt.Sprintf("   • In-turn blocks: %d/%d", report.InTurnBlocks, report.Blocks))
+	cs.feedback.Info(ctx, fmt.Sprintf("   • Out-of-turn blocks: %d", report.OutOfTurnBlocks))
+	cs.feedback.Info(ctx, fmt.Sprintf("   • Missed turns: %d", networkMetrics.MissedBlocks))
+	cs.feedback.Info(ctx, fmt.Sprintf("   • Average block time: %v", networkMetrics.AvgBlockTime))
+
+	if networkMetrics.ConsensusStatus == monitoring.ConsensusStatusHealthy {
+		cs.feedback.Success(ctx, "✅ Consensus is healthy")
+	} else {
+		cs.feedback.Warning(ctx, fmt.Sprintf("⚠️  Consensus is %s", networkMetrics.ConsensusStatus))
+	}
+
+	return nil
+}
+
+// displayTimeline affiche la séquence de scellement des derniers blocs :
+// ● à son tour, ○ hors tour, · pas de sceau
+func (cs *ConsensusService) displayTimeline(ctx context.Context, report *monitoring.ConsensusReport, validators []ports.ValidatorMetrics) {
+	from := report.FromBlock
+	if report.ToBlock-report.FromBlock+1 > maxTimelineBlocks {
+		from = report.ToBlock - maxTimelineBlocks + 1
+	}
+
+	fmt.Println()
+	cs.feedback.Info(ctx, fmt.Sprintf("🕒 Sealing timeline (#%d → #%d, ● in-turn, ○ out-of-turn, · not sealed):", from, report.ToBlock))
+
+	width := 0
+	for _, validator := range validators {
+		if len(validatorLabel(validator)) > width {
+			width = len(validatorLabel(validator))
+		}
+	}
+
+	for _, validator := range validators {
+		var line strings.Builder
+		sealed := report.Timeline[validator.Address]
+		for number := from; number <= report.ToBlock; number++ {
+			inTurn, exists := sealed[number]
+			switch {
+			case !exists:
+				line.WriteString("·")
+			case inTurn:
+				line.WriteString("●")
+			default:
+				line.WriteString("○")
+			}
+		}
+		cs.feedback.Info(ctx, fmt.Sprintf("   %-*s %s", width, validatorLabel(validator), line.String()))
+	}
+}
+
+// validatorLabel retourne le nom du validateur ou son adresse
+func validatorLabel(validator ports.ValidatorMetrics) string {
+	if validator.Name != "" {
+		return validator.Name
+	}
+	return shortAddress(validator.Address)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/ethereum"
+	"benchy/internal/infrastructure/monitoring"
+)
+
+// blockWindow garde en mémoire les derniers blocs analysés et leurs validateurs :
+// l'analyse suivante ne lit que les blocs scellés depuis
+type blockWindow struct {
+	mu     sync.Mutex
+	blocks []*ports.BlockInfo     // Blocs consécutifs, du plus ancien au plus récent
+	sets   []monitoring.SignerSet // Validateurs en vigueur sur blocks
+}
+
+// load retourne les blocs from..latest et leurs validateurs, en complétant la fenêtre
+// en mémoire. Une réorganisation ou une chaîne plus courte vide la fenêtre.
+func (w *blockWindow) load(ctx context.Context, ethClient *ethereum.EthereumClient, adapter ports.ClientAdapter, nodeURL string, from, latest uint64) ([]*ports.BlockInfo, []monitoring.SignerSet, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.trim(from, latest)
+	start := from
+	if len(w.blocks) > 0 {
+		start = w.blocks[len(w.blocks)-1].Number + 1
+	}
+	fresh, err := fetchBlocks(ctx, ethClient, nodeURL, start, latest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(w.blocks) > 0 && len(fresh) > 0 && fresh[0].ParentHash != w.blocks[len(w.blocks)-1].Hash {
+		// Réorganisation : la fenêtre en mémoire n'est plus sur la chaîne du node
+		w.blocks, w.sets = nil, nil
+		if fresh, err = fetchBlocks(ctx, ethClient, nodeURL, from, latest); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(fresh) > 0 {
+		sets, err := signerSets(ctx, adapter, nodeURL, fresh[0].Number, latest)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read clique signers: %w", err)
+		}
+		w.blocks = append(w.blocks, fresh...)
+		for _, set := range sets {
+			if len(w.sets) > 0 && sameSigners(w.sets[len(w.sets)-1].Signers, set.Signers) {
+				continue
+			}
+			w.sets = append(w.sets, set)
+		}
+	}
+
+	// Copies : l'analyse ne doit pas voir les blocs ajoutés ensuite
+	blocks := append([]*ports.BlockInfo(nil), w.blocks...)
+	sets := append([]monitoring.SignerSet(nil), w.sets...)
+	return blocks, sets, nil
+}
+
+// fetchBlocks lit les blocs from..to d'un node
+func fetchBlocks(ctx context.Context, ethClient *ethereum.EthereumClient, nodeURL string, from, to uint64) ([]*ports.BlockInfo, error) {
+	var blocks []*ports.BlockInfo
+	for number := from; number <= to; number++ {
+		block, err := ethClient.GetBlockByNumber(ctx, nodeURL, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %d: %w", number, err)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// trim retire les blocs sortis de la fenêtre from..latest ; la fenêtre est vidée si elle
+// ne commence plus à from ou dépasse la tête du node
+func (w *blockWindow) trim(from, latest uint64) {
+	for len(w.blocks) > 0 && w.blocks[0].Number < from {
+		w.blocks = w.blocks[1:]
+	}
+	if len(w.blocks) == 0 || len(w.sets) == 0 || w.blocks[0].Number != from || w.blocks[len(w.blocks)-1].Number > latest {
+		w.blocks, w.sets = nil, nil
+		return
+	}
+
+	// Le premier ensemble de validateurs encore utile commence désormais à from
+	first := 0
+	for i, set := range w.sets {
+		if set.FromBlock <= from {
+			first = i
+		}
+	}
+	w.sets = append([]monitoring.SignerSet(nil), w.sets[first:]...)
+	w.sets[0].FromBlock = from
+}
@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/config"
+	"benchy/internal/infrastructure/contracts"
+	"benchy/internal/infrastructure/ethereum"
+	"benchy/internal/infrastructure/feedback"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Délai maximum d'attente de la confirmation d'une transaction
+const transactionTimeout = 90 * time.Second
+
+// ContractService déploie et appelle des contrats à partir de leurs artifacts
+type ContractService struct {
+	ethClient     *ethereum.EthereumClient
+	registry      *contracts.Registry
+	feedback      *feedback.ConsoleFeedback
+	configManager *config.NodeConfigManager
+	network       string // Réseau choisi par --network, clé de ses déploiements
+}
+
+// NewContractService crée un nouveau service de contrats
+func NewContractService(baseDir string) (*ContractService, error) {
+	configManager := config.NewNodeConfigManager(baseDir)
+	registry, network, err := openNetworkRegistry(baseDir, configManager)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContractService{
+		ethClient:     ethereum.NewEthereumClient(),
+		registry:      registry,
+		feedback:      feedback.NewConsoleFeedback(),
+		configManager: configManager,
+		network:       network,
+	}, nil
+}
+
+// openNetworkRegistry charge le registre de contrats et retourne le nom du réseau
+// choisi par --network, sous lequel ses déploiements sont mémorisés
+func openNetworkRegistry(baseDir string, configManager *config.NodeConfigManager) (*contracts.Registry, string, error) {
+	network, err := configManager.Network()
+	if err != nil {
+		return nil, "", err
+	}
+	registry, err := contracts.NewRegistry(baseDir)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create contract registry: %w", err)
+	}
+	registry.Deployments().AdoptLegacy(network.Name)
+	return registry, network.Name, nil
+}
+
+// LoadArtifacts charge des artifacts supplémentaires depuis un répertoire
+func (cs *ContractService) LoadArtifacts(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	return cs.registry.LoadDir(dir)
+}
+
+// ListContracts affiche les artifacts disponibles et les contrats déployés
+func (cs *ContractService) ListContracts(ctx context.Context) error {
+	artifacts := cs.registry.Artifacts()
+	if len(artifacts) == 0 {
+		cs.feedback.Warning(ctx, "⚠️  No contract artifacts found")
+	} else {
+		cs.feedback.Info(ctx, "📚 Contract artifacts:")
+		var rows [][]string
+		for _, artifact := range artifacts {
+			deployable := "no"
+			if artifact.IsDeployable() {
+				deployable = "yes"
+			}
+			rows = append(rows, []string{
+				artifact.Name,
+				fmt.Sprintf("%d", len(artifact.ABI.Methods)),
+				fmt.Sprintf("%d", len(artifact.ABI.Events)),
+				deployable,
+				artifact.Source,
+			})
+		}
+		if err := cs.feedback.DisplayTable(ctx, []string{"Artifact", "Methods", "Events", "Deployable", "Source"}, rows); err != nil {
+			return err
+		}
+	}
+
+	deployments := cs.registry.Deployments().List(cs.network)
+	fmt.Println()
+	if len(deployments) == 0 {
+		cs.feedback.Info(ctx, fmt.Sprintf("📭 No contracts deployed on %s", cs.network))
+		return nil
+	}
+
+	book := cs.configManager.LoadAddressBook()
+	cs.feedback.Info(ctx, fmt.Sprintf("📄 Contracts deployed on %s:", cs.network))
+	var rows [][]string
+	for _, deployment := range deployments {
+		rows = append(rows, []string{
+			deployment.Name,
+			deployment.Artifact,
+			deployment.Address.Hex(),
+			labelAddress(book, deployment.Deployer),
+			fmt.Sprintf("#%d", deployment.BlockNumber),
+		})
+	}
+	return cs.feedback.DisplayTable(ctx, []string{"Name", "Artifact", "Address", "Deployer", "Block"}, rows)
+}
+
+// Deploy déploie un artifact avec les arguments de son constructeur et
+// mémorise son adresse sous le nom donné
+func (cs *ContractService) Deploy(ctx context.Context, artifactName, name string, rawArgs []string, from, nodeName string) (*contracts.Deployment, error) {
+	return cs.deploy(ctx, artifactName, name, rawArgs, from, nodeName, nil)
+}
+
+// deploy déploie un artifact ; sent reçoit le hash de la transaction dès sa diffusion,
+// avant la confirmation
+func (cs *ContractService) deploy(ctx context.Context, artifactName, name string, rawArgs []string, from, nodeName string, sent func(common.Hash)) (*contracts.Deployment, error) {
+	artifact, err := cs.registry.Artifact(artifactName)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = artifact.Name
+	}
+
+	nodeURL, err := nodeRPCURL(cs.configManager, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	deployer, err := cs.registerSigner(from)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := contracts.ParseArguments(artifact.ABI.Constructor.Inputs, rawArgs, cs.resolveAddress)
+	if err != nil {
+		return nil, fmt.Errorf("constructor: %w", err)
+	}
+
+	code, err := cs.registry.EncodeDeployment(artifact.Name, args)
+	if err != nil {
+		return nil, err
+	}
+
+	spinner, err := cs.feedback.StartSpinner(ctx, fmt.Sprintf("Deploying %s as '%s' from %s...", artifact.Name, name, from))
+	if err != nil {
+		return nil, err
+	}
+
+	address, txHash, err := cs.ethClient.DeployContract(ctx, nodeURL, code, deployer)
+	if err != nil {
+		spinner.Error("Deployment failed")
+		return nil, fmt.Errorf("failed to deploy %s: %w", artifact.Name, err)
+	}
+	if sent != nil {
+		sent(txHash)
+	}
+
+	receipt, err := cs.waitForReceipt(ctx, nodeURL, txHash)
+	if err != nil {
+		spinner.Error("Deployment not confirmed")
+		return nil, err
+	}
+	if receipt.Status != 1 {
+		spinner.Error("Deployment reverted")
+		return nil, fmt.Errorf("deployment transaction %s reverted", txHash.Hex())
+	}
+
+	deployment := &contracts.Deployment{
+		Name:        name,
+		Artifact:    artifact.Name,
+		Address:     address,
+		TxHash:      txHash,
+		Deployer:    deployer,
+		BlockNumber: receipt.BlockNumber,
+		DeployedAt:  time.Now(),
+	}
+	if err := cs.registry.Deployments().Record(cs.network, deployment); err != nil {
+		spinner.Error("Failed to record deployment")
+		return nil, err
+	}
+
+	spinner.Success(fmt.Sprintf("%s deployed at %s (block #%d)", name, address.Hex(), receipt.BlockNumber))
+	return deployment, nil
+}
+
+// Call appelle une méthode en lecture seule et affiche les valeurs décodées
+func (cs *ContractService) Call(ctx context.Context, contractRef, method string, rawArgs []string, nodeName string) error {
+	deployment, abiMethod, args, err := cs.prepareMethod(contractRef, method, rawArgs)
+	if err != nil {
+		return err
+	}
+
+	nodeURL, err := nodeRPCURL(cs.configManager, nodeName)
+	if err != nil {
+		return err
+	}
+
+	data, err := cs.registry.EncodeCall(deployment.Artifact, method, args)
+	if err != nil {
+		return err
+	}
+
+	result, err := cs.ethClient.CallContract(ctx, nodeURL, deployment.Address, data)
+	if err != nil {
+		return err
+	}
+
+	values, err := cs.registry.DecodeResult(deployment.Artifact, method, result)
+	if err != nil {
+		return err
+	}
+
+	cs.feedback.Info(ctx, fmt.Sprintf("📞 %s.%s", deployment.Name, abiMethod.Sig))
+	if len(values) == 0 {
+		cs.feedback.Info(ctx, "   (no return value)")
+		return nil
+	}
+
+	var rows [][]string
+	for i, value := range values {
+		output := abiMethod.Outputs[i]
+		name := output.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		rows = append(rows, []string{name, output.Type.String(), contracts.FormatValue(value)})
+	}
+	return cs.feedback.DisplayTable(ctx, []string{"Output", "Type", "Value"}, rows)
+}
+
+// Send envoie une transaction appelant une méthode et attend sa confirmation
+func (cs *ContractService) Send(ctx context.Context, contractRef, method string, rawArgs []string, from, nodeName string, value *big.Int) error {
+	deployment, abiMethod, args, err := cs.prepareMethod(contractRef, method, rawArgs)
+	if err != nil {
+		return err
+	}
+
+	nodeURL, err := nodeRPCURL(cs.configManager, nodeName)
+	if err != nil {
+		return err
+	}
+
+	sender, err := cs.registerSigner(from)
+	if err != nil {
+		return err
+	}
+
+	data, err := cs.registry.EncodeCall(deployment.Artifact, method, args)
+	if err != nil {
+		return err
+	}
+
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	tx := entities.NewTransaction(sender, deployment.Address, value, entities.TxTypeContract)
+	tx.Data = data
+
+	spinner, err := cs.feedback.StartSpinner(ctx, fmt.Sprintf("Sending %s.%s from %s...", deployment.Name, abiMethod.Name, from))
+	if err != nil {
+		return err
+	}
+
+	txHash, err := cs.ethClient.SendTransaction(ctx, nodeURL, tx)
+	if err != nil {
+		spinner.Error("Transaction failed")
+		return err
+	}
+
+	receipt, err := cs.waitForReceipt(ctx, nodeURL, txHash)
+	if err != nil {
+		spinner.Error("Transaction not confirmed")
+		return err
+	}
+	if receipt.Status != 1 {
+		spinner.Error(fmt.Sprintf("Transaction %s reverted", txHash.Hex()))
+		return fmt.Errorf("transaction reverted")
+	}
+
+	spinner.Success(fmt.Sprintf("%s.%s confirmed in block #%d (gas used: %d)", deployment.Name, abiMethod.Name, receipt.BlockNumber, receipt.GasUsed))
+	displayReceiptEvents(ctx, cs.feedback, cs.registry, cs.network, cs.configManager.LoadAddressBook(), receipt)
+	cs.feedback.Info(ctx, fmt.Sprintf("💡 Use 'benchy tx %s' for details", txHash.Hex()))
+	return nil
+}
+
+// prepareMethod retrouve le contrat, la méthode et convertit les arguments
+func (cs *ContractService) prepareMethod(contractRef, method string, rawArgs []string) (*contracts.Deployment, abi.Method, []interface{}, error) {
+	deployment, err := cs.registry.Resolve(cs.network, contractRef)
+	if err != nil {
+		return nil, abi.Method{}, nil, err
+	}
+
+	artifact, err := cs.registry.Artifact(deployment.Artifact)
+	if err != nil {
+		return nil, abi.Method{}, nil, err
+	}
+
+	abiMethod, exists := artifact.ABI.Methods[method]
+	if !exists {
+		return nil, abi.Method{}, nil, fmt.Errorf("contract '%s' has no method '%s'", deployment.Name, method)
+	}
+
+	args, err := contracts.ParseArguments(abiMethod.Inputs, rawArgs, cs.resolveAddress)
+	if err != nil {
+		return nil, abi.Method{}, nil, fmt.Errorf("%s: %w", abiMethod.Sig, err)
+	}
+
+	return deployment, abiMethod, args, nil
+}
+
+// registerSigner charge la clé d'un node et l'enregistre pour signer
+func (cs *ContractService) registerSigner(nodeName string) (common.Address, error) {
+	return registerSigner(cs.configManager, cs.ethClient, nodeName)
+}
+
+// registerSigner charge la clé d'un node et l'enregistre dans ethClient, qui signe
+// ensuite ses transactions
+func registerSigner(configManager *config.NodeConfigManager, ethClient *ethereum.EthereumClient, nodeName string) (common.Address, error) {
+	keyPair, err := configManager.LoadNodeKeyPair(nodeName)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to load key of %s: %w", nodeName, err)
+	}
+	return ethClient.RegisterAccount(keyPair.PrivateKey), nil
+}
+
+// resolveAddress convertit un nom de node ou de contrat déployé en adresse
+func (cs *ContractService) resolveAddress(name string) (common.Address, bool) {
+	for address, nodeName := range cs.configManager.LoadAddressBook() {
+		if strings.EqualFold(nodeName, name) {
+			return address, true
+		}
+	}
+	if deployment, exists := cs.registry.Deployments().Get(cs.network, name); exists {
+		return deployment.Address, true
+	}
+	return common.Address{}, false
+}
+
+// waitForReceipt attend la confirmation d'une transaction avec un délai maximum
+func (cs *ContractService) waitForReceipt(ctx context.Context, nodeURL string, txHash common.Hash) (*ports.TransactionReceipt, error) {
+	return waitForReceipt(ctx, cs.ethClient, nodeURL, txHash)
+}
+
+// waitForReceipt attend la confirmation d'une transaction au plus transactionTimeout
+func waitForReceipt(ctx context.Context, ethClient *ethereum.EthereumClient, nodeURL string, txHash common.Hash) (*ports.TransactionReceipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, transactionTimeout)
+	defer cancel()
+	return ethClient.WaitForReceipt(ctx, nodeURL, txHash)
+}
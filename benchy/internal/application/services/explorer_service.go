@@ -0,0 +1,527 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/clients"
+	"benchy/internal/infrastructure/config"
+	"benchy/internal/infrastructure/contracts"
+	"benchy/internal/infrastructure/ethereum"
+	"benchy/internal/infrastructure/feedback"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Intervalle d'interrogation du node pour le flux d'événements
+const eventPollInterval = 2 * time.Second
+
+// ExplorerService permet d'inspecter les blocs, transactions et événements du réseau
+type ExplorerService struct {
+	ethClient     *ethereum.EthereumClient
+	feedback      *feedback.ConsoleFeedback
+	configManager *config.NodeConfigManager
+	registry      *contracts.Registry
+	clients       *clients.Provider
+	network       string // Réseau choisi par --network, clé de ses déploiements
+}
+
+// NewExplorerService crée un nouveau service d'inspection
+func NewExplorerService(baseDir string) (*ExplorerService, error) {
+	configManager := config.NewNodeConfigManager(baseDir)
+	registry, network, err := openNetworkRegistry(baseDir, configManager)
+	if err != nil {
+		return nil, err
+	}
+
+	ethClient := ethereum.NewEthereumClient()
+
+	return &ExplorerService{
+		ethClient:     ethClient,
+		feedback:      feedback.NewConsoleFeedback(),
+		configManager: configManager,
+		registry:      registry,
+		clients:       clients.NewProvider(ethClient),
+		network:       network,
+	}, nil
+}
+
+// ShowBlock affiche un bloc ("latest" ou numéro) tel que vu par un node
+func (es *ExplorerService) ShowBlock(ctx context.Context, blockRef string, nodeName string) error {
+	nodeURL, err := nodeRPCURL(es.configManager, nodeName)
+	if err != nil {
+		return err
+	}
+
+	blockNumber, err := es.resolveBlockNumber(ctx, nodeURL, blockRef)
+	if err != nil {
+		return err
+	}
+
+	block, err := es.ethClient.GetBlockByNumber(ctx, nodeURL, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get block: %w", err)
+	}
+
+	book := es.configManager.LoadAddressBook()
+
+	es.feedback.Info(ctx, fmt.Sprintf("📦 Block #%d (queried from %s)", block.Number, nodeName))
+
+	signer := "none (genesis)"
+	if block.Number > 0 {
+		signer = labelAddress(book, block.Signer)
+	}
+
+	rows := [][]string{
+		{"Hash", block.Hash.Hex()},
+		{"Parent", block.ParentHash.Hex()},
+		{"Timestamp", time.Unix(int64(block.Timestamp), 0).Format("2006-01-02 15:04:05")},
+		{"Signer", signer},
+		{"Difficulty", fmt.Sprintf("%s (%s)", block.Difficulty.String(), ethereum.SealLabel(block.Difficulty))},
+		{"Gas used", fmt.Sprintf("%d / %d (%.1f%%)", block.GasUsed, block.GasLimit, gasRatio(block.GasUsed, block.GasLimit))},
+		{"Transactions", fmt.Sprintf("%d", len(block.Transactions))},
+	}
+	if err := es.feedback.DisplayTable(ctx, []string{"Field", "Value"}, rows); err != nil {
+		return fmt.Errorf("failed to display table: %w", err)
+	}
+
+	if len(block.Transactions) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	es.feedback.Info(ctx, "📋 Transactions:")
+
+	var txRows [][]string
+	for _, txHash := range block.Transactions {
+		receipt, err := es.ethClient.GetTransactionReceipt(ctx, nodeURL, txHash)
+		if err != nil {
+			txRows = append(txRows, []string{txHash.Hex(), "N/A", "N/A", "N/A", "❓ Unknown"})
+			continue
+		}
+
+		txRows = append(txRows, []string{
+			txHash.Hex(),
+			labelAddress(book, receipt.From),
+			es.labelRecipient(book, receipt),
+			fmt.Sprintf("%d", receipt.GasUsed),
+			receiptStatusLabel(receipt.Status),
+		})
+	}
+
+	return es.feedback.DisplayTable(ctx, []string{"Hash", "From", "To", "Gas Used", "Status"}, txRows)
+}
+
+// ShowTransaction affiche le reçu d'une transaction et le bloc qui l'inclut
+func (es *ExplorerService) ShowTransaction(ctx context.Context, txHashHex string, nodeName string) error {
+	nodeURL, err := nodeRPCURL(es.configManager, nodeName)
+	if err != nil {
+		return err
+	}
+
+	txHash, err := parseTxHash(txHashHex)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := es.ethClient.GetTransactionReceipt(ctx, nodeURL, txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	book := es.configManager.LoadAddressBook()
+
+	es.feedback.Info(ctx, fmt.Sprintf("🧾 Transaction %s (queried from %s)", txHash.Hex(), nodeName))
+
+	rows := [][]string{
+		{"Status", receiptStatusLabel(receipt.Status)},
+		{"Block", fmt.Sprintf("#%d (%s)", receipt.BlockNumber, receipt.BlockHash.Hex())},
+		{"Index", fmt.Sprintf("%d", receipt.TransactionIndex)},
+		{"From", labelAddress(book, receipt.From)},
+		{"To", es.labelRecipient(book, receipt)},
+		{"Gas used", fmt.Sprintf("%d", receipt.GasUsed)},
+		{"Logs", fmt.Sprintf("%d", len(receipt.Logs))},
+	}
+
+	if block, err := es.ethClient.GetBlockByNumber(ctx, nodeURL, receipt.BlockNumber); err == nil {
+		rows = append(rows,
+			[]string{"Sealed by", labelAddress(book, block.Signer)},
+			[]string{"Seal", ethereum.SealLabel(block.Difficulty)},
+		)
+	}
+
+	if err := es.feedback.DisplayTable(ctx, []string{"Field", "Value"}, rows); err != nil {
+		return fmt.Errorf("failed to display table: %w", err)
+	}
+
+	if len(receipt.Logs) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	es.feedback.Info(ctx, "📣 Events:")
+
+	label := newAddressLabeler(book, es.registry, es.network)
+	var logRows [][]string
+	for _, log := range receipt.Logs {
+		event, err := es.registry.DecodeLog(es.network, log)
+		if err != nil {
+			logRows = append(logRows, []string{fmt.Sprintf("%d", log.Index), label(log.Address), fmt.Sprintf("❓ %s", eventTopic(log))})
+			continue
+		}
+		logRows = append(logRows, []string{fmt.Sprintf("%d", log.Index), label(log.Address), event.Format(label)})
+	}
+	return es.feedback.DisplayTable(ctx, []string{"Index", "Contract", "Event"}, logRows)
+}
+
+// TraceTransaction rejoue une transaction et affiche son arbre d'appels,
+// la raison du revert et le gas consommé par chaque appel
+func (es *ExplorerService) TraceTransaction(ctx context.Context, txHashHex string, nodeName string) error {
+	txHash, err := parseTxHash(txHashHex)
+	if err != nil {
+		return err
+	}
+
+	trace, err := es.traceTransaction(ctx, txHash, nodeName)
+	if err != nil {
+		return err
+	}
+
+	es.feedback.Info(ctx, fmt.Sprintf("🔍 Trace of %s (replayed by %s)", txHash.Hex(), nodeName))
+	es.displayTrace(ctx, trace)
+	return nil
+}
+
+// AttachFailureTraces trace chaque transaction échouée d'un scénario et
+// joint la trace au rapport
+func (es *ExplorerService) AttachFailureTraces(ctx context.Context, scenario *entities.Scenario, nodeName string) error {
+	nodeURL, err := nodeRPCURL(es.configManager, nodeName)
+	if err != nil {
+		return err
+	}
+
+	for _, hashHex := range scenario.TransactionHashes {
+		txHash, err := parseTxHash(hashHex)
+		if err != nil {
+			continue
+		}
+		receipt, err := es.ethClient.GetTransactionReceipt(ctx, nodeURL, txHash)
+		if err != nil || receipt.Status == 1 {
+			continue
+		}
+
+		trace, err := es.traceTransaction(ctx, txHash, nodeName)
+		if err != nil {
+			es.feedback.Warning(ctx, fmt.Sprintf("⚠️  Could not trace failed transaction %s: %v", txHash.Hex(), err))
+			continue
+		}
+		scenario.AttachTrace(txHash.Hex(), trace)
+
+		fmt.Println()
+		es.feedback.Error(ctx, fmt.Sprintf("❌ Transaction %s failed", txHash.Hex()))
+		es.displayTrace(ctx, trace)
+	}
+	return nil
+}
+
+// traceTransaction demande la trace au node via l'adapter de son client
+func (es *ExplorerService) traceTransaction(ctx context.Context, txHash common.Hash, nodeName string) (*entities.CallFrame, error) {
+	nodeURL, err := nodeRPCURL(es.configManager, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	adapter, err := nodeAdapter(es.clients, es.configManager, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	trace, err := adapter.TraceTransaction(ctx, nodeURL, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace transaction: %w", err)
+	}
+	return trace, nil
+}
+
+// displayTrace affiche le résumé d'une trace puis son arbre d'appels
+func (es *ExplorerService) displayTrace(ctx context.Context, trace *entities.CallFrame) {
+	status := "✅ Success"
+	if trace.Failed() {
+		status = "❌ " + trace.Error
+	}
+	rows := [][]string{
+		{"Status", status},
+		{"Gas used", fmt.Sprintf("%d / %d", trace.GasUsed, trace.Gas)},
+	}
+	if reason := trace.FailureReason(); trace.Failed() && reason != "" {
+		rows = append(rows, []string{"Revert reason", reason})
+	}
+	es.feedback.DisplayTable(ctx, []string{"Field", "Value"}, rows)
+
+	fmt.Println()
+	es.feedback.Info(ctx, "🌳 Call tree:")
+	label := newAddressLabeler(es.configManager.LoadAddressBook(), es.registry, es.network)
+	es.printFrame(ctx, trace, label, "", "")
+}
+
+// printFrame affiche un appel puis ses sous-appels en arbre
+func (es *ExplorerService) printFrame(ctx context.Context, frame *entities.CallFrame, label contracts.AddressLabeler, prefix, childPrefix string) {
+	line := fmt.Sprintf("%s%s %s → %s", prefix, frame.Type, label(frame.From), label(frame.To))
+	if call := es.registry.DescribeCall(es.network, frame.To, frame.Input, label); call != "" && !strings.HasPrefix(frame.Type, "CREATE") {
+		line += "." + call
+	}
+	if frame.Value != nil && frame.Value.Sign() > 0 {
+		line += fmt.Sprintf(" value=%s", frame.Value.String())
+	}
+	line += fmt.Sprintf(" [gas %d]", frame.GasUsed)
+	if frame.Failed() {
+		line += " ❌ " + frame.Error
+		if frame.RevertReason != "" {
+			line += fmt.Sprintf(" (%s)", frame.RevertReason)
+		}
+	}
+	es.feedback.Info(ctx, line)
+
+	for i, call := range frame.Calls {
+		if i == len(frame.Calls)-1 {
+			es.printFrame(ctx, call, label, childPrefix+"└─ ", childPrefix+"   ")
+			continue
+		}
+		es.printFrame(ctx, call, label, childPrefix+"├─ ", childPrefix+"│  ")
+	}
+}
+
+// StreamEvents affiche en continu les événements décodés d'un contrat en
+// interrogeant le node (eth_getLogs) jusqu'à l'annulation du contexte. Un fromBlock
+// nil commence au prochain bloc.
+func (es *ExplorerService) StreamEvents(ctx context.Context, contractRef, eventName string, fromBlock *uint64, nodeName string) error {
+	nodeURL, err := nodeRPCURL(es.configManager, nodeName)
+	if err != nil {
+		return err
+	}
+
+	filter, target, err := es.eventFilter(contractRef, eventName)
+	if err != nil {
+		return err
+	}
+
+	// Sans bloc de départ, le flux commence au prochain bloc
+	var next uint64
+	if fromBlock != nil {
+		next = *fromBlock
+	} else {
+		latest, err := es.ethClient.GetLatestBlockNumber(ctx, nodeURL)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block number: %w", err)
+		}
+		next = latest + 1
+	}
+
+	events := "all events"
+	if eventName != "" {
+		events = eventName + " events"
+	}
+	es.feedback.Info(ctx, fmt.Sprintf("📡 Streaming %s of %s from block #%d via %s, press Ctrl+C to stop", events, target, next, nodeName))
+
+	book := es.configManager.LoadAddressBook()
+	label := newAddressLabeler(book, es.registry, es.network)
+
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		latest, err := es.ethClient.GetLatestBlockNumber(ctx, nodeURL)
+		if err != nil && ctx.Err() == nil {
+			es.feedback.Warning(ctx, fmt.Sprintf("⚠️  Failed to get latest block: %v", err))
+		}
+
+		if err == nil && latest >= next {
+			filter.FromBlock = next
+			filter.ToBlock = latest
+
+			logs, err := es.ethClient.FilterLogs(ctx, nodeURL, filter)
+			if err != nil {
+				if ctx.Err() == nil {
+					es.feedback.Warning(ctx, fmt.Sprintf("⚠️  %v", err))
+				}
+			} else {
+				for _, log := range logs {
+					es.printEvent(ctx, log, label)
+				}
+				next = latest + 1
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			es.feedback.Info(ctx, "🔄 Stopping event stream...")
+			return nil
+		}
+	}
+}
+
+// eventFilter construit le filtre de logs d'un contrat déployé ou d'une adresse
+// quelconque. Les logs d'une adresse inconnue sont décodés avec tous les ABI enregistrés.
+func (es *ExplorerService) eventFilter(contractRef, eventName string) (ports.LogFilter, string, error) {
+	deployment, err := es.registry.Resolve(es.network, contractRef)
+	if err != nil {
+		if !common.IsHexAddress(contractRef) {
+			return ports.LogFilter{}, "", err
+		}
+		address := common.HexToAddress(contractRef)
+		filter := ports.LogFilter{Addresses: []common.Address{address}}
+		if eventName != "" {
+			topics, err := es.registry.EventTopics(eventName)
+			if err != nil {
+				return ports.LogFilter{}, "", err
+			}
+			filter.Topics = [][]common.Hash{topics}
+		}
+		return filter, address.Hex(), nil
+	}
+
+	filter := ports.LogFilter{Addresses: []common.Address{deployment.Address}}
+	if eventName != "" {
+		artifact, err := es.registry.Artifact(deployment.Artifact)
+		if err != nil {
+			return ports.LogFilter{}, "", err
+		}
+		topic, err := artifact.EventTopic(eventName)
+		if err != nil {
+			return ports.LogFilter{}, "", err
+		}
+		filter.Topics = [][]common.Hash{{topic}}
+	}
+	return filter, fmt.Sprintf("%s (%s)", deployment.Name, deployment.Address.Hex()), nil
+}
+
+// printEvent affiche un événement sur une ligne
+func (es *ExplorerService) printEvent(ctx context.Context, log ports.LogEntry, label contracts.AddressLabeler) {
+	description := fmt.Sprintf("❓ unknown event %s", eventTopic(log))
+	if event, err := es.registry.DecodeLog(es.network, log); err == nil {
+		description = fmt.Sprintf("%s.%s", event.Contract, event.Format(label))
+	}
+
+	hash := log.TxHash.Hex()
+	es.feedback.Info(ctx, fmt.Sprintf("#%-6d %s…%s  %s", log.BlockNumber, hash[:10], hash[len(hash)-4:], description))
+}
+
+// newAddressLabeler nomme les adresses connues (nodes et contrats déployés)
+func newAddressLabeler(book map[common.Address]string, registry *contracts.Registry, network string) contracts.AddressLabeler {
+	return func(address common.Address) string {
+		if name, exists := book[address]; exists {
+			return name
+		}
+		if deployment, exists := registry.Deployments().FindByAddress(network, address); exists {
+			return deployment.Name
+		}
+		return address.Hex()
+	}
+}
+
+// eventTopic retourne le topic identifiant un événement
+func eventTopic(log ports.LogEntry) string {
+	if len(log.Topics) == 0 {
+		return "(anonymous)"
+	}
+	return log.Topics[0].Hex()
+}
+
+// displayReceiptEvents affiche les événements décodés d'un reçu, un par ligne
+func displayReceiptEvents(ctx context.Context, out *feedback.ConsoleFeedback, registry *contracts.Registry, network string, book map[common.Address]string, receipt *ports.TransactionReceipt) {
+	label := newAddressLabeler(book, registry, network)
+	for _, log := range receipt.Logs {
+		if event, err := registry.DecodeLog(network, log); err == nil {
+			out.Info(ctx, fmt.Sprintf("   📣 %s.%s", event.Contract, event.Format(label)))
+			continue
+		}
+		out.Info(ctx, fmt.Sprintf("   📣 %s emitted unknown event %s", label(log.Address), eventTopic(log)))
+	}
+}
+
+// nodeRPCURL retourne l'URL RPC du node interrogé
+func nodeRPCURL(configManager *config.NodeConfigManager, nodeName string) (string, error) {
+	rpcPort, ok := configManager.NodeRPCPort(nodeName)
+	if !ok {
+		return "", fmt.Errorf("unknown node '%s'", nodeName)
+	}
+	return fmt.Sprintf("http://localhost:%d", rpcPort), nil
+}
+
+// nodeAdapter retourne l'adapter du client qui fait tourner un node
+func nodeAdapter(provider *clients.Provider, configManager *config.NodeConfigManager, nodeName string) (ports.ClientAdapter, error) {
+	client, ok := configManager.NodeClient(nodeName)
+	if !ok {
+		return nil, fmt.Errorf("unknown node '%s'", nodeName)
+	}
+	return provider.Adapter(client)
+}
+
+// resolveBlockNumber convertit "latest" ou un numéro en numéro de bloc
+func (es *ExplorerService) resolveBlockNumber(ctx context.Context, nodeURL, blockRef string) (uint64, error) {
+	if blockRef == "latest" {
+		latest, err := es.ethClient.GetLatestBlockNumber(ctx, nodeURL)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get latest block number: %w", err)
+		}
+		return latest, nil
+	}
+
+	blockNumber, err := strconv.ParseUint(blockRef, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block '%s'. Use a block number or 'latest'", blockRef)
+	}
+	return blockNumber, nil
+}
+
+// labelAddress affiche une adresse avec le nom du node correspondant
+func labelAddress(book map[common.Address]string, address common.Address) string {
+	if name, exists := book[address]; exists {
+		return fmt.Sprintf("%s (%s)", name, shortAddress(address))
+	}
+	return address.Hex()
+}
+
+// labelRecipient affiche le destinataire ou le contrat créé
+func (es *ExplorerService) labelRecipient(book map[common.Address]string, receipt *ports.TransactionReceipt) string {
+	if receipt.ContractAddress != (common.Address{}) {
+		return "📄 new contract " + receipt.ContractAddress.Hex()
+	}
+	return labelAddress(book, receipt.To)
+}
+
+// parseTxHash valide et convertit un hash de transaction
+func parseTxHash(txHashHex string) (common.Hash, error) {
+	raw, err := hexutil.Decode("0x" + strings.TrimPrefix(txHashHex, "0x"))
+	if err != nil || len(raw) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid transaction hash '%s'", txHashHex)
+	}
+	return common.BytesToHash(raw), nil
+}
+
+// receiptStatusLabel retourne le libellé du statut d'un reçu
+func receiptStatusLabel(status uint64) string {
+	if status == 1 {
+		return "✅ Success"
+	}
+	return "❌ Failed"
+}
+
+// shortAddress raccourcit une adresse pour l'affichage
+func shortAddress(address common.Address) string {
+	hex := address.Hex()
+	return hex[:6] + "…" + hex[len(hex)-4:]
+}
+
+// gasRatio calcule le taux de remplissage en gas d'un bloc
+func gasRatio(gasUsed, gasLimit uint64) float64 {
+	if gasLimit == 0 {
+		return 0
+	}
+	return float64(gasUsed) / float64(gasLimit) * 100
+}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"benchy/internal/infrastructure/config"
+	"benchy/internal/infrastructure/ethereum"
+	"benchy/internal/infrastructure/feedback"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+)
+
+// Longueur maximale d'une valeur affichée par genesis diff (bytecode, extraData)
+const maxDiffValueLength = 42
+
+// GenesisService affiche, compare et vérifie le genesis du réseau
+type GenesisService struct {
+	ethClient     *ethereum.EthereumClient
+	feedback      *feedback.ConsoleFeedback
+	configManager *config.NodeConfigManager
+	baseDir       string
+}
+
+// NewGenesisService crée un nouveau service genesis
+func NewGenesisService(baseDir string) (*GenesisService, error) {
+	return &GenesisService{
+		ethClient:     ethereum.NewEthereumClient(),
+		feedback:      feedback.NewConsoleFeedback(),
+		configManager: config.NewNodeConfigManager(baseDir),
+		baseDir:       baseDir,
+	}, nil
+}
+
+// ShowGenesis affiche le résumé d'un fichier genesis, par défaut celui du réseau lancé
+func (gs *GenesisService) ShowGenesis(ctx context.Context, path string, raw bool) error {
+	path, err := gs.genesisFile(path)
+	if err != nil {
+		return err
+	}
+	if raw {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read genesis file: %w", err)
+		}
+		fmt.Print(string(data))
+		return nil
+	}
+	genesis, err := config.LoadGenesisFromFile(path)
+	if err != nil {
+		return err
+	}
+
+	book := gs.configManager.LoadAddressBook()
+	signers, err := ethereum.ParseCliqueSigners(genesis.ExtraData)
+	if err != nil {
+		return fmt.Errorf("failed to read signers from genesis: %w", err)
+	}
+	signerNames := make([]string, len(signers))
+	for i, signer := range signers {
+		signerNames[i] = signer.Hex()
+		if name, ok := book[signer]; ok {
+			signerNames[i] = fmt.Sprintf("%s (%s)", name, signer.Hex())
+		}
+	}
+	contracts := 0
+	for _, account := range genesis.Alloc {
+		if len(account.Code) > 0 {
+			contracts++
+		}
+	}
+
+	gs.feedback.Info(ctx, fmt.Sprintf("📄 Genesis %s", path))
+	gs.feedback.Info(ctx, fmt.Sprintf("   - Block hash: %s", genesis.ToBlock().Hash().Hex()))
+	gs.feedback.Info(ctx, fmt.Sprintf("   - Chain ID: %s", genesis.Config.ChainID))
+	if clique := genesis.Config.Clique; clique != nil {
+		gs.feedback.Info(ctx, fmt.Sprintf("   - Clique: %ds blocks, epoch %d", clique.Period, clique.Epoch))
+	}
+	gs.feedback.Info(ctx, fmt.Sprintf("   - Gas limit: %d", genesis.GasLimit))
+	gs.feedback.Info(ctx, fmt.Sprintf("   - Timestamp: %s", time.Unix(int64(genesis.Timestamp), 0).UTC().Format(time.RFC3339)))
+	gs.feedback.Info(ctx, fmt.Sprintf("   - Forks: %s", strings.Join(config.ActiveForks(genesis.Config), ", ")))
+	gs.feedback.Info(ctx, fmt.Sprintf("   - Signers: %s", strings.Join(signerNames, ", ")))
+	gs.feedback.Info(ctx, fmt.Sprintf("   - Allocations: %d accounts, %d with code", len(genesis.Alloc), contracts))
+	return nil
+}
+
+// DiffGenesis compare deux fichiers genesis champ par champ ; sans second fichier,
+// le premier est comparé au genesis du réseau lancé
+func (gs *GenesisService) DiffGenesis(ctx context.Context, pathA, pathB string) error {
+	pathB, err := gs.genesisFile(pathB)
+	if err != nil {
+		return err
+	}
+	genesisA, err := config.LoadGenesisFromFile(pathA)
+	if err != nil {
+		return err
+	}
+	genesisB, err := config.LoadGenesisFromFile(pathB)
+	if err != nil {
+		return err
+	}
+
+	hashA, hashB := genesisA.ToBlock().Hash(), genesisB.ToBlock().Hash()
+	gs.feedback.Info(ctx, fmt.Sprintf("   A: %s (%s)", pathA, hashA.Hex()))
+	gs.feedback.Info(ctx, fmt.Sprintf("   B: %s (%s)", pathB, hashB.Hex()))
+
+	fieldsA, err := flattenGenesis(genesisA)
+	if err != nil {
+		return err
+	}
+	fieldsB, err := flattenGenesis(genesisB)
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, field := range unionKeys(fieldsA, fieldsB) {
+		if fieldsA[field] != fieldsB[field] {
+			rows = append(rows, []string{field, shortValue(fieldsA[field]), shortValue(fieldsB[field])})
+		}
+	}
+
+	if len(rows) == 0 {
+		gs.feedback.Success(ctx, "✅ Genesis files are identical")
+		return nil
+	}
+	if err := gs.feedback.DisplayTable(ctx, []string{"Field", "A", "B"}, rows); err != nil {
+		return fmt.Errorf("failed to display table: %w", err)
+	}
+	if hashA == hashB {
+		gs.feedback.Info(ctx, "💡 The genesis blocks are the same: only the chain config differs")
+	} else {
+		gs.feedback.Warning(ctx, fmt.Sprintf("⚠️  %d fields differ: nodes started from these files will not peer", len(rows)))
+	}
+	return nil
+}
+
+// VerifyGenesis compare le bloc 0 de chaque node au hash du genesis enregistré dans le manifest
+func (gs *GenesisService) VerifyGenesis(ctx context.Context) error {
+	manifest, err := config.LoadManifest(gs.baseDir)
+	if err != nil {
+		return err
+	}
+	expected := manifest.GenesisHash
+	if expected == (common.Hash{}) {
+		return fmt.Errorf("the manifest has no genesis hash, relaunch the network with 'benchy launch-network'")
+	}
+	gs.feedback.Info(ctx, fmt.Sprintf("🔍 Expected genesis hash: %s", expected.Hex()))
+
+	headers := []string{"Node", "Client", "Block 0 Hash", "Status"}
+	var rows [][]string
+	var mismatched, unreachable []string
+	for _, node := range manifest.Nodes {
+		hash, err := nodeGenesisHash(ctx, gs.ethClient, node.RPCPort)
+		switch {
+		case err != nil:
+			unreachable = append(unreachable, node.Name)
+			rows = append(rows, []string{node.Name, string(node.Client), "-", "⚠️  Unreachable"})
+		case hash != expected:
+			mismatched = append(mismatched, node.Name)
+			rows = append(rows, []string{node.Name, string(node.Client), hash.Hex(), "❌ Mismatch"})
+		default:
+			rows = append(rows, []string{node.Name, string(node.Client), hash.Hex(), "✅ Match"})
+		}
+	}
+	if err := gs.feedback.DisplayTable(ctx, headers, rows); err != nil {
+		return fmt.Errorf("failed to display table: %w", err)
+	}
+
+	if len(mismatched) > 0 {
+		gs.feedback.Info(ctx, "💡 A node started from a stale data directory keeps its old genesis: remove its data directory and relaunch")
+		return fmt.Errorf("genesis mismatch on %s", strings.Join(mismatched, ", "))
+	}
+	if len(unreachable) > 0 {
+		return fmt.Errorf("could not verify the genesis of %s", strings.Join(unreachable, ", "))
+	}
+	gs.feedback.Success(ctx, "✅ All nodes share the expected genesis")
+	return nil
+}
+
+// genesisFile retourne le fichier demandé, ou le genesis du réseau lancé
+func (gs *GenesisService) genesisFile(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	manifest, err := config.LoadManifest(gs.baseDir)
+	if err != nil {
+		return "", err
+	}
+	if manifest.GenesisFile == "" {
+		return "", fmt.Errorf("the manifest does not record its genesis file, pass the path explicitly")
+	}
+	return manifest.GenesisFile, nil
+}
+
+// genesisFiles est l'emplacement du genesis du réseau dans chacun des formats lus par les clients
+type genesisFiles struct {
+	genesis   string // Format geth, lu par geth et erigon
+	chainspec string // Nethermind ne lit pas le genesis geth : même chaîne au format chainspec
+	besu      string // Besu nomme autrement les paramètres Clique
+}
+
+// networkGenesisFiles retourne les fichiers genesis du réseau, les mêmes quel que soit
+// le chemin de lancement
+func networkGenesisFiles(baseDir string) genesisFiles {
+	configsDir := filepath.Join(baseDir, "configs")
+	return genesisFiles{
+		genesis:   filepath.Join(configsDir, "genesis.json"),
+		chainspec: filepath.Join(configsDir, "chainspec.json"),
+		besu:      filepath.Join(configsDir, "besu-genesis.json"),
+	}
+}
+
+// save écrit genesis dans les trois formats
+func (f genesisFiles) save(genesis *core.Genesis) error {
+	generator := config.NewGenesisGenerator()
+	if err := generator.SaveGenesisToFile(genesis, f.genesis); err != nil {
+		return fmt.Errorf("failed to save genesis file: %w", err)
+	}
+	if err := generator.SaveChainspecToFile(genesis, f.chainspec); err != nil {
+		return err
+	}
+	return generator.SaveBesuGenesisToFile(genesis, f.besu)
+}
+
+// mount ajoute aux volumes d'un container les fichiers genesis, aux chemins lus par les clients
+func (f genesisFiles) mount(volumes map[string]string) {
+	volumes[f.genesis] = "/genesis.json"
+	volumes[f.chainspec] = "/chainspec.json"
+	volumes[f.besu] = "/besu-genesis.json"
+}
+
+// nodeGenesisHash retourne le hash du bloc 0 tel qu'un node l'a calculé
+func nodeGenesisHash(ctx context.Context, ethClient *ethereum.EthereumClient, rpcPort int) (common.Hash, error) {
+	return ethClient.GetBlockHash(ctx, fmt.Sprintf("http://localhost:%d", rpcPort), 0)
+}
+
+// Attente du premier appel RPC d'un node qui vient de démarrer, avant de lire son bloc 0
+const (
+	genesisCheckTimeout  = 60 * time.Second
+	genesisCheckInterval = 2 * time.Second
+)
+
+// checkNodesGenesis compare le bloc 0 de chaque node au genesis généré, celui que le
+// manifest enregistre : un node sur un autre genesis ne trouverait jamais de peers
+func checkNodesGenesis(ctx context.Context, fb *feedback.ConsoleFeedback, ethClient *ethereum.EthereumClient, nodes []*config.NodeConfig, genesis *core.Genesis) error {
+	expected := genesis.ToBlock().Hash()
+	deadline := time.Now().Add(genesisCheckTimeout)
+	var mismatched []string
+	for _, node := range nodes {
+		hash, err := nodeGenesisHash(ctx, ethClient, node.RPCPort)
+		for err != nil && time.Now().Before(deadline) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(genesisCheckInterval):
+			}
+			hash, err = nodeGenesisHash(ctx, ethClient, node.RPCPort)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read genesis block of %s: %w", node.Name, err)
+		}
+		if hash != expected {
+			fb.Error(ctx, fmt.Sprintf("❌ %s runs genesis %s, expected %s", node.Name, hash.Hex(), expected.Hex()))
+			mismatched = append(mismatched, node.Name)
+		}
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("genesis mismatch on %s: compare their genesis files with 'benchy genesis diff'", strings.Join(mismatched, ", "))
+	}
+	fb.Success(ctx, fmt.Sprintf("✅ All nodes share genesis %s", expected.TerminalString()))
+	return nil
+}
+
+// checkDataDirGenesis refuse de relancer des nodes dont le datadir contient la chaîne d'un
+// genesis précédent : ils démarreraient sur l'ancien bloc 0 et ne se connecteraient jamais
+func checkDataDirGenesis(baseDir string, nodes []*config.NodeConfig, genesis *core.Genesis) error {
+	manifest, err := config.LoadManifest(baseDir)
+	if errors.Is(err, config.ErrNoManifest) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	expected := genesis.ToBlock().Hash()
+	if manifest.GenesisHash == expected || manifest.GenesisHash == (common.Hash{}) {
+		return nil
+	}
+
+	var stale []string
+	for _, node := range nodes {
+		if dataDirInitialized(node.DataDir) {
+			stale = append(stale, node.Name)
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	return fmt.Errorf("the genesis changed since the last launch (%s, now %s) but the data directories of %s still hold the old chain: remove them under %s or restore the previous configuration",
+		manifest.GenesisHash.TerminalString(), expected.TerminalString(), strings.Join(stale, ", "), filepath.Join(baseDir, "nodes"))
+}
+
+// flattenGenesis aplatit le JSON d'un genesis en chemins (config.clique.period, alloc.<adresse>.balance)
+func flattenGenesis(genesis *core.Genesis) (map[string]string, error) {
+	data, err := json.Marshal(genesis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis: %w", err)
+	}
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("failed to parse genesis: %w", err)
+	}
+	fields := make(map[string]string)
+	flattenJSON("", tree, fields)
+	return fields, nil
+}
+
+// flattenJSON ajoute les feuilles d'un arbre JSON sous leur chemin
+func flattenJSON(prefix string, value interface{}, fields map[string]string) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flattenJSON(path, child, fields)
+		}
+	case nil:
+		// Un champ nul équivaut à un champ absent
+	default:
+		data, _ := json.Marshal(value)
+		fields[prefix] = strings.Trim(string(data), `"`)
+	}
+}
+
+// unionKeys retourne les clés des deux maps, triées
+func unionKeys(a, b map[string]string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	for key := range a {
+		seen[key] = true
+	}
+	for key := range b {
+		seen[key] = true
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// shortValue tronque une valeur trop longue pour le tableau ; une valeur absente s'affiche "-"
+func shortValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	if len(value) > maxDiffValueLength {
+		return value[:maxDiffValueLength-3] + "..."
+	}
+	return value
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/config"
+	"benchy/internal/infrastructure/monitoring"
+)
+
+// Largeur des courbes de tendance de infos --history
+const trendWidth = 20
+
+// Caractères des courbes de tendance, du plus bas au plus haut
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// startCollector démarre le collecteur du réseau ; ses tours complètent l'historique
+// enregistré dans le répertoire du réseau. updateInterval remplace l'intervalle configuré.
+func (ms *MonitoringService) startCollector(ctx context.Context, manifest *config.Manifest, updateInterval int) error {
+	monitoringConfig, err := config.LoadMonitoringConfig()
+	if err != nil {
+		return err
+	}
+	interval := monitoringConfig.Interval
+	if updateInterval > 0 {
+		interval = time.Duration(updateInterval) * time.Second
+	}
+
+	history := monitoring.NewMetricsHistory(monitoring.HistoryPath(ms.configManager.BaseDir()), interval, monitoringConfig.Resolution, monitoringConfig.Retention)
+	if err := history.Load(); err != nil {
+		ms.feedback.Warning(ctx, fmt.Sprintf("⚠️  Starting a new metrics history: %v", err))
+	}
+	ms.systemMonitor.SetSampleInterval(interval)
+	ms.systemMonitor.SetHistory(history)
+
+	if err := ms.systemMonitor.StartMonitoring(ctx, manifestNetwork(manifest)); err != nil {
+		return fmt.Errorf("failed to start monitoring: %w", err)
+	}
+	return nil
+}
+
+// stopCollector arrête le collecteur du réseau et enregistre son historique
+func (ms *MonitoringService) stopCollector(ctx context.Context, networkName string) {
+	if err := ms.systemMonitor.StopMonitoring(context.Background(), networkName); err != nil {
+		ms.feedback.Warning(ctx, fmt.Sprintf("⚠️  %v", err))
+	}
+}
+
+// DisplayMetricsHistory affiche la tendance de chaque node sur la fenêtre demandée : progression
+// des blocs, pertes de peers, croissance mémoire et disponibilité
+func (ms *MonitoringService) DisplayMetricsHistory(ctx context.Context, window time.Duration) error {
+	manifest, containers, err := ms.getBenchyContainers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get containers: %w", err)
+	}
+	if len(containers) == 0 {
+		ms.feedback.Warning(ctx, "⚠️  No benchy containers found. Did you run 'benchy launch-network'?")
+		return nil
+	}
+
+	// Un échantillon frais complète l'historique enregistré par les sessions précédentes
+	if err := ms.startCollector(ctx, manifest, 0); err != nil {
+		return err
+	}
+	defer ms.stopCollector(ctx, manifest.Network)
+	if _, err := ms.systemMonitor.WaitForSample(ctx, manifest.Network, time.Time{}); err != nil {
+		return fmt.Errorf("failed to sample nodes: %w", err)
+	}
+
+	ms.feedback.Info(ctx, fmt.Sprintf("📈 Node trends over the last %s", window))
+	headers := []string{"Node", "Samples", "Blocks", "Blocks/min", "Peers (min-max)", "Peer Drops", "Memory", "Memory Trend", "CPU avg", "Uptime"}
+	var rows [][]string
+	var oldest time.Time
+	for _, container := range containers {
+		history, err := ms.systemMonitor.GetMetricsHistory(ctx, manifest.Network, container.NodeName, window)
+		if err != nil || len(history) == 0 {
+			rows = append(rows, []string{container.NodeName, "0", "N/A", "N/A", "N/A", "N/A", "N/A", "", "N/A", "N/A"})
+			continue
+		}
+		if oldest.IsZero() || history[0].Timestamp.Before(oldest) {
+			oldest = history[0].Timestamp
+		}
+		rows = append(rows, nodeTrendRow(container.NodeName, history))
+	}
+	if err := ms.feedback.DisplayTable(ctx, headers, rows); err != nil {
+		return fmt.Errorf("failed to display table: %w", err)
+	}
+
+	if networkHistory, err := ms.systemMonitor.GetNetworkMetricsHistory(ctx, manifest.Network, window); err == nil && len(networkHistory) > 0 {
+		ms.displayNetworkTrend(ctx, networkHistory)
+	}
+
+	// Seules les sessions avec un collecteur actif (infos, infos -u) alimentent l'historique
+	if covered := time.Since(oldest); covered < window/2 {
+		ms.feedback.Info(ctx, fmt.Sprintf("💡 History only covers the last %s: keep 'benchy infos -u 5' running to record more", covered.Round(time.Second)))
+	}
+	return nil
+}
+
+// nodeTrendRow résume l'historique d'un node en une ligne du tableau
+func nodeTrendRow(nodeName string, history []*ports.NodeMetrics) []string {
+	var online []*ports.NodeMetrics
+	var cpu float64
+	memory := make([]float64, 0, len(history))
+	for _, metrics := range history {
+		cpu += metrics.CPUUsage
+		memory = append(memory, float64(metrics.MemoryBytes))
+		if metrics.IsOnline {
+			online = append(online, metrics)
+		}
+	}
+
+	first, last := history[0], history[len(history)-1]
+	row := []string{nodeName, fmt.Sprintf("%d", len(history))}
+
+	if len(online) == 0 {
+		row = append(row, "N/A", "N/A", "N/A", "N/A")
+	} else {
+		firstOnline, lastOnline := online[0], online[len(online)-1]
+		row = append(row, fmt.Sprintf("#%d → #%d", firstOnline.LatestBlock, lastOnline.LatestBlock))
+
+		rate := "N/A"
+		if elapsed := lastOnline.Timestamp.Sub(firstOnline.Timestamp); elapsed >= time.Minute && lastOnline.LatestBlock >= firstOnline.LatestBlock {
+			rate = fmt.Sprintf("%.1f", float64(lastOnline.LatestBlock-firstOnline.LatestBlock)/elapsed.Minutes())
+		}
+		row = append(row, rate)
+
+		// Une baisse du nombre de peers entre deux échantillons compte comme une perte
+		minPeers, maxPeers, drops := online[0].ConnectedPeers, online[0].ConnectedPeers, 0
+		for i, metrics := range online {
+			if metrics.ConnectedPeers < minPeers {
+				minPeers = metrics.ConnectedPeers
+			}
+			if metrics.ConnectedPeers > maxPeers {
+				maxPeers = metrics.ConnectedPeers
+			}
+			if i > 0 && metrics.ConnectedPeers < online[i-1].ConnectedPeers {
+				drops++
+			}
+		}
+		row = append(row, fmt.Sprintf("%d (%d-%d)", lastOnline.ConnectedPeers, minPeers, maxPeers), fmt.Sprintf("%d", drops))
+	}
+
+	growth := int64(last.MemoryBytes) - int64(first.MemoryBytes)
+	row = append(row,
+		fmt.Sprintf("%.0fMB (%+.0fMB)", float64(last.MemoryBytes)/1024/1024, float64(growth)/1024/1024),
+		sparkline(memory, trendWidth),
+		fmt.Sprintf("%.1f%%", cpu/float64(len(history))),
+		fmt.Sprintf("%.0f%%", float64(len(online))/float64(len(history))*100),
+	)
+	return row
+}
+
+// displayNetworkTrend affiche la progression du réseau sur la fenêtre
+func (ms *MonitoringService) displayNetworkTrend(ctx context.Context, history []*ports.NetworkMetrics) {
+	first, last := history[0], history[len(history)-1]
+	minOnline := last.OnlineNodes
+	var latency time.Duration
+	for _, metrics := range history {
+		if metrics.OnlineNodes < minOnline {
+			minOnline = metrics.OnlineNodes
+		}
+		latency += metrics.NetworkLatency
+	}
+
+	fmt.Println()
+	ms.feedback.Info(ctx, "📈 Network Trend:")
+	ms.feedback.Info(ctx, fmt.Sprintf("   • Since: %s (%d samples)", first.Timestamp.Format("2006-01-02 15:04:05"), len(history)))
+	ms.feedback.Info(ctx, fmt.Sprintf("   • Blocks: #%d → #%d", first.LatestBlock, last.LatestBlock))
+	ms.feedback.Info(ctx, fmt.Sprintf("   • Online nodes: %d/%d now, %d at worst", last.OnlineNodes, last.TotalNodes, minOnline))
+	ms.feedback.Info(ctx, fmt.Sprintf("   • Average RPC latency: %s", (latency/time.Duration(len(history))).Round(time.Millisecond)))
+	if minOnline < last.TotalNodes {
+		ms.feedback.Warning(ctx, fmt.Sprintf("⚠️  Up to %d nodes were offline during this window", last.TotalNodes-minOnline))
+	}
+}
+
+// sparkline dessine une courbe de width caractères au plus, chaque caractère moyennant
+// une tranche des valeurs
+func sparkline(values []float64, width int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			from, to := i*len(values)/width, (i+1)*len(values)/width
+			for _, value := range values[from:to] {
+				buckets[i] += value
+			}
+			buckets[i] /= float64(to - from)
+		}
+		values = buckets
+	}
+
+	low, high := values[0], values[0]
+	for _, value := range values {
+		if value < low {
+			low = value
+		}
+		if value > high {
+			high = value
+		}
+	}
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if high > low {
+			level = int((value - low) / (high - low) * float64(len(sparkRunes)-1))
+		}
+		line.WriteRune(sparkRunes[level])
+	}
+	return line.String()
+}
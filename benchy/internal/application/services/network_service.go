@@ -2,26 +2,32 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
-	"math/big"
 	"benchy/internal/domain/entities"
 	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/clients"
 	"benchy/internal/infrastructure/config"
 	"benchy/internal/infrastructure/docker"
 	"benchy/internal/infrastructure/ethereum"
 	"benchy/internal/infrastructure/feedback"
-	"benchy/internal/infrastructure/monitoring"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/spf13/viper"
 )
 
 // NetworkService implémente les opérations réseau de haut niveau
 type NetworkService struct {
 	dockerClient  *docker.DockerClient
 	ethClient     *ethereum.EthereumClient
-	monitor       *monitoring.SystemMonitor
 	feedback      *feedback.ConsoleFeedback
 	configManager *config.NodeConfigManager
+	clients       *clients.Provider
 	baseDir       string
 }
 
@@ -34,16 +40,16 @@ func NewNetworkService(baseDir string) (*NetworkService, error) {
 	}
 
 	ethClient := ethereum.NewEthereumClient()
-	monitor := monitoring.NewSystemMonitor()
+	provider := clients.NewProvider(ethClient)
 	feedback := feedback.NewConsoleFeedback()
 	configManager := config.NewNodeConfigManager(baseDir)
 
 	return &NetworkService{
 		dockerClient:  dockerClient,
 		ethClient:     ethClient,
-		monitor:       monitor,
 		feedback:      feedback,
 		configManager: configManager,
+		clients:       provider,
 		baseDir:       baseDir,
 	}, nil
 }
@@ -51,15 +57,42 @@ func NewNetworkService(baseDir string) (*NetworkService, error) {
 // LaunchNetwork lance le réseau Ethereum complet
 func (ns *NetworkService) LaunchNetwork(ctx context.Context) error {
 	ns.feedback.Info(ctx, "🚀 Launching Ethereum network...")
+	// Réserver l'emplacement du réseau avant d'en dériver les ports et le chain ID
+	network, err := ns.configManager.Network()
+	if err != nil {
+		return err
+	}
+	if err := displayValidation(ctx, ns.feedback, validateNetwork(ctx, ns.configManager, ns.clients, ns.dockerClient)); err != nil {
+		return err
+	}
+	if err := network.Register(); err != nil {
+		return err
+	}
+	topology, err := ns.configManager.Topology()
+	if err != nil {
+		return err
+	}
 	ns.feedback.Info(ctx, "📋 Configuration:")
-	ns.feedback.Info(ctx, "   - 5 nodes: Alice, Bob, Cassandra, Driss, Elena")
-	ns.feedback.Info(ctx, "   - 3 validators: Alice, Bob, Cassandra")
-	ns.feedback.Info(ctx, "   - Clients: Geth + Nethermind")
+	ns.feedback.Info(ctx, "   - "+network.Summary())
+	for _, line := range topology.Summary() {
+		ns.feedback.Info(ctx, "   - "+line)
+	}
+	keys, err := ns.configManager.Keys()
+	if err != nil {
+		return err
+	}
+	ns.feedback.Info(ctx, "   - "+keys.Summary())
+	genesisConfig, err := ns.configManager.Genesis()
+	if err != nil {
+		return err
+	}
+	ns.feedback.Info(ctx, "   - "+genesisConfig.Summary())
 	ns.feedback.Info(ctx, "   - Consensus: Clique")
 
-	// 1. Générer les configurations des nodes
-	if err := ns.configManager.GenerateDefaultNodes(); err != nil {
-		return fmt.Errorf("failed to generate node configurations: %w", err)
+	// 1. Générer les configurations des nodes et le genesis, avec les clés du réseau déjà lancé
+	genesis, err := prepareNodes(ctx, ns.feedback, ns.configManager, ns.clients)
+	if err != nil {
+		return err
 	}
 
 	// 2. Sauvegarder les configurations
@@ -67,22 +100,21 @@ func (ns *NetworkService) LaunchNetwork(ctx context.Context) error {
 		return fmt.Errorf("failed to save configurations: %w", err)
 	}
 
-	// 3. Générer le fichier genesis
-	genesis, err := ns.configManager.GenerateGenesisWithNodes()
-	if err != nil {
-		return fmt.Errorf("failed to generate genesis: %w", err)
+	// 3. Écrire le fichier genesis
+	// Un datadir initialisé avec un autre genesis ne se connecterait jamais aux autres nodes
+	if err := checkDataDirGenesis(ns.baseDir, ns.configManager.GetAllNodes(), genesis); err != nil {
+		return err
 	}
 
-	genesisPath := filepath.Join(ns.baseDir, "configs", "genesis.json")
-	generator := config.NewGenesisGenerator()
-	if err := generator.SaveGenesisToFile(genesis, genesisPath); err != nil {
-		return fmt.Errorf("failed to save genesis file: %w", err)
+	genesisFiles := networkGenesisFiles(ns.baseDir)
+	if err := genesisFiles.save(genesis); err != nil {
+		return err
 	}
 
 	ns.feedback.Success(ctx, "✅ Configuration generated successfully")
 
 	// 4. Créer le réseau Docker
-	if err := ns.dockerClient.CreateNetwork(ctx, "benchy-network"); err != nil {
+	if err := ns.dockerClient.CreateNetwork(ctx, network.DockerNetwork); err != nil {
 		return fmt.Errorf("failed to create docker network: %w", err)
 	}
 
@@ -97,7 +129,7 @@ func (ns *NetworkService) LaunchNetwork(ctx context.Context) error {
 	defer progress.Close()
 
 	for i, nodeConfig := range nodes {
-		if err := ns.launchNode(ctx, nodeConfig); err != nil {
+		if _, err := ns.launchNode(ctx, nodeConfig, genesisConfig.ChainID); err != nil {
 			progress.Error(fmt.Sprintf("Failed to launch %s: %v", nodeConfig.Name, err))
 			return fmt.Errorf("failed to launch node %s: %w", nodeConfig.Name, err)
 		}
@@ -106,11 +138,17 @@ func (ns *NetworkService) LaunchNetwork(ctx context.Context) error {
 
 	progress.Complete("All nodes launched successfully")
 
-	// 6. Démarrer le monitoring
-	network := ns.createNetworkEntity(nodes)
-	if err := ns.monitor.StartMonitoring(ctx, network); err != nil {
-		ns.feedback.Warning(ctx, fmt.Sprintf("Warning: monitoring failed to start: %v", err))
+	// Un node sur un autre genesis ne trouverait jamais de peers : échouer avant d'écrire le manifest
+	ns.feedback.Info(ctx, "⏳ Checking the genesis block of each node...")
+	if err := checkNodesGenesis(ctx, ns.feedback, ns.ethClient, nodes, genesis); err != nil {
+		return err
+	}
+
+	// Les autres commandes relisent le manifest au lieu de régénérer les clés
+	if err := ns.configManager.SaveManifest(network.Name, genesis, genesisFiles.genesis); err != nil {
+		return fmt.Errorf("failed to save network manifest: %w", err)
 	}
+	ns.feedback.Success(ctx, fmt.Sprintf("✅ Network manifest saved to %s", config.ManifestPath(ns.baseDir)))
 
 	ns.feedback.Success(ctx, "🎉 Network launched successfully!")
 	ns.feedback.Info(ctx, "💡 Use 'benchy infos' to monitor the network")
@@ -119,10 +157,63 @@ func (ns *NetworkService) LaunchNetwork(ctx context.Context) error {
 	return nil
 }
 
+// prepareNodes génère les nodes de la topologie et leur genesis. Si le réseau a déjà été
+// lancé, ses clés sont reprises : les datadirs restent valides tant que la topologie et la
+// configuration du genesis n'ont pas changé, sinon de nouvelles clés sont générées.
+func prepareNodes(ctx context.Context, fb *feedback.ConsoleFeedback, configManager *config.NodeConfigManager, provider *clients.Provider) (*core.Genesis, error) {
+	if err := generateNodes(configManager, provider); err != nil {
+		return nil, fmt.Errorf("failed to generate node configurations: %w", err)
+	}
+
+	manifest, err := config.LoadManifest(configManager.BaseDir())
+	if errors.Is(err, config.ErrNoManifest) {
+		return generateGenesis(configManager)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reused, err := configManager.ReuseManifestKeys(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if !reused {
+		fb.Warning(ctx, "⚠️  The topology changed since the last launch: generating new keys")
+		return generateGenesis(configManager)
+	}
+
+	genesis, err := generateGenesis(configManager)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.GenesisHash == (common.Hash{}) || genesis.ToBlock().Hash() == manifest.GenesisHash {
+		fb.Info(ctx, "♻️  Reusing the keys of the launched network")
+		return genesis, nil
+	}
+
+	fb.Warning(ctx, "⚠️  The genesis configuration changed since the last launch: generating new keys")
+	if err := generateNodes(configManager, provider); err != nil {
+		return nil, fmt.Errorf("failed to generate node configurations: %w", err)
+	}
+	return generateGenesis(configManager)
+}
+
+// generateGenesis génère le genesis des nodes configurés
+func generateGenesis(configManager *config.NodeConfigManager) (*core.Genesis, error) {
+	genesis, err := configManager.GenerateGenesisWithNodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate genesis: %w", err)
+	}
+	return genesis, nil
+}
+
 // launchNode lance un node individuel
-func (ns *NetworkService) launchNode(ctx context.Context, nodeConfig *config.NodeConfig) error {
+func (ns *NetworkService) launchNode(ctx context.Context, nodeConfig *config.NodeConfig, networkID uint64) (*entities.Node, error) {
 	// Préparer la configuration du container
-	containerConfig := ns.buildContainerConfig(nodeConfig)
+	containerConfig, err := ns.buildContainerConfig(nodeConfig, networkID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Créer le node entity
 	node := entities.NewNode(
@@ -137,27 +228,35 @@ func (ns *NetworkService) launchNode(ctx context.Context, nodeConfig *config.Nod
 	// Créer le container
 	containerID, err := ns.dockerClient.CreateContainer(ctx, node, containerConfig)
 	if err != nil {
-		return fmt.Errorf("failed to create container: %w", err)
+		return nil, fmt.Errorf("failed to create container: %w", err)
 	}
 
 	// Démarrer le container
 	if err := ns.dockerClient.StartContainer(ctx, containerID); err != nil {
-		return fmt.Errorf("failed to start container: %w", err)
+		return nil, fmt.Errorf("failed to start container: %w", err)
 	}
 
 	// Mettre à jour le node avec l'ID du container
 	node.ContainerID = containerID
+	nodeConfig.ContainerID = containerID
 	node.Status = entities.StatusStarting
 
-	return nil
+	return node, nil
 }
 
 // buildContainerConfig construit la configuration du container pour un node
-func (ns *NetworkService) buildContainerConfig(nodeConfig *config.NodeConfig) ports.ContainerConfig {
-	genesisPath := filepath.Join(ns.baseDir, "configs", "genesis.json")
-	
+func (ns *NetworkService) buildContainerConfig(nodeConfig *config.NodeConfig, networkID uint64) (ports.ContainerConfig, error) {
+	network, err := ns.configManager.Network()
+	if err != nil {
+		return ports.ContainerConfig{}, err
+	}
+	passwordFile, err := ns.configManager.PasswordFile()
+	if err != nil {
+		return ports.ContainerConfig{}, err
+	}
+
 	config := ports.ContainerConfig{
-		Name: fmt.Sprintf("benchy-%s", nodeConfig.Name),
+		Name: nodeConfig.Container,
 		Ports: map[string]string{
 			fmt.Sprintf("%d", nodeConfig.Port):    fmt.Sprintf("%d", nodeConfig.Port),
 			fmt.Sprintf("%d", nodeConfig.RPCPort): fmt.Sprintf("%d", nodeConfig.RPCPort),
@@ -165,86 +264,238 @@ func (ns *NetworkService) buildContainerConfig(nodeConfig *config.NodeConfig) po
 		Volumes: map[string]string{
 			nodeConfig.DataDir:     "/data",
 			nodeConfig.KeystoreDir: "/keystore",
-			genesisPath:           "/genesis.json",
+			passwordFile:           "/password.txt",
 		},
-		NetworkMode: "benchy-network",
+		NetworkMode: network.DockerNetwork,
 		Labels: map[string]string{
 			"benchy.node.name":      nodeConfig.Name,
 			"benchy.node.validator": fmt.Sprintf("%t", nodeConfig.IsValidator),
 			"benchy.node.client":    string(nodeConfig.Client),
+			"benchy.network":        network.Name,
 		},
 	}
+	networkGenesisFiles(ns.baseDir).mount(config.Volumes)
 
-	// Configuration spécifique selon le client
-	switch nodeConfig.Client {
-	case entities.ClientGeth:
-		config.Image = "ethereum/client-go:latest"
-		config.Command = ns.buildGethCommand(nodeConfig)
-	case entities.ClientNethermind:
-		config.Image = "nethermind/nethermind:latest"
-		config.Command = ns.buildNethermindCommand(nodeConfig)
+	// Configuration spécifique au client
+	adapter, err := ns.clients.Adapter(nodeConfig.Client)
+	if err != nil {
+		return ports.ContainerConfig{}, err
+	}
+	if err := configureClient(&config, adapter, nodeConfig, networkID); err != nil {
+		return ports.ContainerConfig{}, err
 	}
 
-	return config
+	return config, nil
 }
 
-// buildGethCommand construit la commande pour Geth
-func (ns *NetworkService) buildGethCommand(nodeConfig *config.NodeConfig) []string {
-	cmd := []string{
-		"geth",
-		"--datadir", "/data",
-		"--keystore", "/keystore",
-		"--networkid", "1337",
-		"--port", fmt.Sprintf("%d", nodeConfig.Port),
-		"--http",
-		"--http.addr", "0.0.0.0",
-		"--http.port", fmt.Sprintf("%d", nodeConfig.RPCPort),
-		"--http.api", "eth,net,web3,personal,miner,admin",
-		"--http.corsdomain", "*",
-		"--ws",
-		"--ws.addr", "0.0.0.0",
-		"--ws.port", fmt.Sprintf("%d", nodeConfig.WSPort),
-		"--ws.api", "eth,net,web3,personal,miner,admin",
-		"--ws.origins", "*",
-		"--allow-insecure-unlock",
-		"--nodiscover",
-		"--maxpeers", "25",
-		"--syncmode", "full",
-		"--verbosity", "3",
-	}
-
-	// Initialiser avec le genesis si c'est le premier démarrage
-	cmd = append(cmd, "--init", "/genesis.json")
-
-	if nodeConfig.IsValidator {
-		cmd = append(cmd, 
-			"--mine", 
-			"--miner.threads", "1",
-			"--miner.etherbase", nodeConfig.KeyPair.Address.Hex(),
-		)
+// configureClient complète la configuration du container avec ce qui dépend du client :
+// image, fichier de configuration rendu par l'adapter et commande. Commun aux deux modes de lancement.
+func configureClient(containerConfig *ports.ContainerConfig, adapter ports.ClientAdapter, nodeConfig *config.NodeConfig, networkID uint64) error {
+	containerConfig.Image = adapter.Image()
+	if nodeConfig.Image != "" {
+		containerConfig.Image = nodeConfig.Image
+	}
+
+	spec := nodeLaunchSpec(nodeConfig, networkID)
+	configPath, err := writeClientConfig(adapter, nodeConfig, spec)
+	if err != nil {
+		return err
+	}
+	containerConfig.Volumes[configPath] = adapter.ConfigPath()
+
+	nodeKeyPath, err := writeNodeKey(adapter, nodeConfig)
+	if err != nil {
+		return err
+	}
+	containerConfig.Volumes[nodeKeyPath] = adapter.NodeKeyPath()
+
+	files, err := writeClientFiles(adapter, nodeConfig, spec)
+	if err != nil {
+		return err
+	}
+	for path, containerPath := range files {
+		containerConfig.Volumes[path] = containerPath
+	}
+
+	// L'exécutable de la commande remplace l'entrypoint de l'image (geth, sh pour un premier démarrage...)
+	command := adapter.Command(spec)
+	containerConfig.Entrypoint = command[:1]
+	containerConfig.Command = command[1:]
+	containerConfig.CPUs = nodeConfig.CPUs
+	containerConfig.MemoryBytes = nodeConfig.MemoryBytes
+	return nil
+}
+
+// writeClientConfig écrit le fichier de configuration du client à côté du datadir du node
+func writeClientConfig(adapter ports.ClientAdapter, nodeConfig *config.NodeConfig, spec ports.NodeLaunchSpec) (string, error) {
+	content, err := adapter.RenderConfig(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to render client config of %s: %w", nodeConfig.Name, err)
+	}
+
+	nodeDir := filepath.Dir(nodeConfig.DataDir)
+	if err := os.MkdirAll(nodeDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create node directory: %w", err)
+	}
+	path := filepath.Join(nodeDir, filepath.Base(adapter.ConfigPath()))
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write client config of %s: %w", nodeConfig.Name, err)
+	}
+	return path, nil
+}
+
+// writeNodeKey écrit la clé P2P du node au format de son client, à côté du datadir
+func writeNodeKey(adapter ports.ClientAdapter, nodeConfig *config.NodeConfig) (string, error) {
+	path := filepath.Join(filepath.Dir(nodeConfig.DataDir), filepath.Base(adapter.NodeKeyPath()))
+	if err := os.WriteFile(path, adapter.RenderNodeKey(nodeConfig.NodeKey), 0600); err != nil {
+		return "", fmt.Errorf("failed to write node key of %s: %w", nodeConfig.Name, err)
+	}
+	return path, nil
+}
+
+// writeClientFiles écrit les autres fichiers lus par le client à côté du datadir ;
+// retourne leurs chemins sur l'hôte associés à leurs chemins dans le container
+func writeClientFiles(adapter ports.ClientAdapter, nodeConfig *config.NodeConfig, spec ports.NodeLaunchSpec) (map[string]string, error) {
+	files, err := adapter.RenderFiles(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render client files of %s: %w", nodeConfig.Name, err)
+	}
+
+	mounts := make(map[string]string, len(files))
+	for containerPath, content := range files {
+		path := filepath.Join(filepath.Dir(nodeConfig.DataDir), filepath.Base(containerPath))
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s of %s: %w", filepath.Base(containerPath), nodeConfig.Name, err)
+		}
+		mounts[path] = containerPath
+	}
+	return mounts, nil
+}
+
+// generateNodes génère les nodes de la topologie ; les clients qui scellent avec leur
+// clé P2P (Besu, Erigon) la partagent avec leur compte, avant que les enodes ne soient figés
+func generateNodes(configManager *config.NodeConfigManager, provider *clients.Provider) error {
+	if err := configManager.GenerateNodes(); err != nil {
+		return err
+	}
+	for _, node := range configManager.GetAllNodes() {
+		adapter, err := provider.Adapter(node.Client)
+		if err != nil {
+			return err
+		}
+		if adapter.SealsWithNodeKey() {
+			configManager.UseAccountKeyAsNodeKey(node.Name)
+		}
+	}
+	return nil
+}
+
+// imageReferencePattern reconnaît une référence d'image Docker : [registre/]nom[:tag][@digest]
+var imageReferencePattern = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*(:[0-9]+)?(/[a-z0-9]+([._-]+[a-z0-9]+)*)*(:[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+
+// validateNetwork vérifie la configuration du réseau puis, pour chaque node, son client et son image
+func validateNetwork(ctx context.Context, configManager *config.NodeConfigManager, provider *clients.Provider, dockerClient ports.DockerService) *config.ValidationReport {
+	topology, report := configManager.Validate()
+	if topology == nil {
+		return report
+	}
+
+	// Période 0 : le genesis est déjà signalé par Validate s'il est illisible
+	onDemand := false
+	if genesis, err := configManager.Genesis(); err == nil {
+		onDemand = genesis.Period == 0
+	}
+
+	checked := make(map[string]bool)
+	daemonDown := false
+	for _, node := range topology.Nodes {
+		if !node.Client.IsKnown() {
+			continue // Déjà signalé par la validation de la topologie
+		}
+		adapter, err := provider.Adapter(node.Client)
+		if err != nil {
+			report.Errorf("node %s: %v", node.Name, err)
+			continue
+		}
+		if onDemand && node.Validator && !adapter.SupportsOnDemandSealing() {
+			report.Errorf("genesis: period 0 (blocks on demand) is not supported by %s validator %s", node.Client, node.Name)
+		}
+		image := adapter.Image()
+		if node.Image != "" {
+			image = node.Image
+		}
+		if !imageReferencePattern.MatchString(image) {
+			report.Errorf("node %s: invalid image reference '%s'", node.Name, image)
+			continue
+		}
+		if checked[image] || daemonDown {
+			continue
+		}
+		checked[image] = true
+		exists, err := dockerClient.ImageExists(ctx, image)
+		switch {
+		case errors.Is(err, docker.ErrDaemonUnreachable):
+			// Inutile d'interroger le daemon pour les images suivantes
+			report.Warnf("cannot check local images: %v", err)
+			daemonDown = true
+		case err != nil:
+			report.Warnf("image %s: %v", image, err)
+		case !exists:
+			report.Warnf("image %s is not available locally and will be pulled at launch", image)
+		}
+	}
+	return report
+}
+
+// displayValidation affiche le rapport de validation et échoue s'il contient des erreurs
+func displayValidation(ctx context.Context, fb *feedback.ConsoleFeedback, report *config.ValidationReport) error {
+	for _, message := range report.Errors {
+		fb.Error(ctx, "❌ "+message)
+	}
+	for _, message := range report.Warnings {
+		fb.Warning(ctx, "⚠️  "+message)
+	}
+	if !report.OK() {
+		return fmt.Errorf("invalid configuration in %s: %d problems found, run 'benchy config validate' after fixing them", configFileName(), len(report.Errors))
 	}
+	return nil
+}
 
-	return cmd
+// configFileName retourne le fichier de configuration lu, pour les messages
+func configFileName() string {
+	if file := viper.ConfigFileUsed(); file != "" {
+		return file
+	}
+	return "default configuration"
 }
 
-// buildNethermindCommand construit la commande pour Nethermind
-func (ns *NetworkService) buildNethermindCommand(nodeConfig *config.NodeConfig) []string {
-	return []string{
-		"./Nethermind.Runner",
-		"--config", "mainnet",
-		"--datadir", "/data",
-		"--Network.DiscoveryPort", fmt.Sprintf("%d", nodeConfig.Port),
-		"--Network.P2PPort", fmt.Sprintf("%d", nodeConfig.Port),
-		"--JsonRpc.Enabled", "true",
-		"--JsonRpc.Host", "0.0.0.0",
-		"--JsonRpc.Port", fmt.Sprintf("%d", nodeConfig.RPCPort),
-		"--JsonRpc.EnabledModules", "Eth,Subscribe,Trace,TxPool,Web3,Personal,Proof,Net,Parity,Health,Rpc",
+// nodeLaunchSpec décrit un node configuré pour son adapter client
+func nodeLaunchSpec(nodeConfig *config.NodeConfig, networkID uint64) ports.NodeLaunchSpec {
+	return ports.NodeLaunchSpec{
+		Name:        nodeConfig.Name,
+		IsValidator: nodeConfig.IsValidator,
+		Etherbase:   nodeConfig.KeyPair.Address,
+		NetworkID:   networkID,
+		P2PPort:     nodeConfig.Port,
+		RPCPort:     nodeConfig.RPCPort,
+		WSPort:      nodeConfig.WSPort,
+		Initialized: dataDirInitialized(nodeConfig.DataDir),
+		ExtraFlags:  nodeConfig.ExtraFlags,
+		StaticPeers: nodeConfig.StaticPeers,
+
+		ConfigOverrides: nodeConfig.ClientConfig,
 	}
 }
 
+// dataDirInitialized indique qu'un datadir non vide a déjà été initialisé par le client
+func dataDirInitialized(dataDir string) bool {
+	entries, err := os.ReadDir(dataDir)
+	return err == nil && len(entries) > 0
+}
+
 // createNetworkEntity crée une entité Network depuis les configurations
-func (ns *NetworkService) createNetworkEntity(nodeConfigs []*config.NodeConfig) *entities.Network {
-	network := entities.NewNetwork("benchy-network", big.NewInt(1337))
+func (ns *NetworkService) createNetworkEntity(name string, chain entities.ChainParams, nodeConfigs []*config.NodeConfig) *entities.Network {
+	network := entities.NewNetwork(name, chain)
 	
 	for _, nodeConfig := range nodeConfigs {
 		node := entities.NewNode(
@@ -255,6 +506,7 @@ func (ns *NetworkService) createNetworkEntity(nodeConfigs []*config.NodeConfig)
 			nodeConfig.RPCPort,
 		)
 		node.Address = nodeConfig.KeyPair.Address
+		node.ContainerID = nodeConfig.ContainerID
 		
 		network.AddNode(node)
 	}
@@ -265,10 +517,159 @@ func (ns *NetworkService) createNetworkEntity(nodeConfigs []*config.NodeConfig)
 
 // GetNetworkStatus récupère le status du réseau
 func (ns *NetworkService) GetNetworkStatus(ctx context.Context) (*entities.Network, error) {
-	// Pour l'instant, on crée un réseau fictif
-	// TODO: Implémenter la récupération du vrai état
-	nodes := ns.configManager.GetAllNodes()
-	return ns.createNetworkEntity(nodes), nil
+	manifest, err := ns.configManager.LoadExistingConfigurations()
+	if err != nil {
+		return nil, err
+	}
+	return ns.createNetworkEntity(manifest.Network, manifest.ChainParams(), ns.configManager.GetAllNodes()), nil
+}
+
+// Topology retourne la topologie du réseau à lancer
+func (ns *NetworkService) Topology() (*config.Topology, error) {
+	return ns.configManager.Topology()
+}
+
+// Network retourne le réseau choisi par --network
+func (ns *NetworkService) Network() (*config.NetworkProfile, error) {
+	return ns.configManager.Network()
+}
+
+// ListNetworks affiche les réseaux de l'hôte en marquant le réseau courant
+func (ns *NetworkService) ListNetworks(ctx context.Context) error {
+	current, err := ns.configManager.Network()
+	if err != nil {
+		return err
+	}
+	networks, err := config.ListNetworks(current.Root)
+	if err != nil {
+		return err
+	}
+	if !current.Registered {
+		networks = append(networks, current)
+	}
+
+	headers := []string{"Network", "Status", "Chain ID", "Nodes", "RPC Ports", "Docker Network", "State Directory"}
+	var rows [][]string
+	for _, network := range networks {
+		name := "  " + network.Name
+		if network.Name == current.Name {
+			name = "* " + network.Name
+		}
+
+		manifest, err := config.LoadManifest(network.BaseDir)
+		if errors.Is(err, config.ErrNoManifest) {
+			rows = append(rows, []string{name, "⏳ Not launched", "-", "-", "-", network.DockerNetwork, network.BaseDir})
+			continue
+		}
+		if err != nil {
+			rows = append(rows, []string{name, "❌ Unreadable", "-", "-", "-", network.DockerNetwork, network.BaseDir})
+			continue
+		}
+
+		rpcPorts := "-"
+		if len(manifest.Nodes) > 0 {
+			first, last := manifest.Nodes[0].RPCPort, manifest.Nodes[0].RPCPort
+			for _, node := range manifest.Nodes {
+				if node.RPCPort < first {
+					first = node.RPCPort
+				}
+				if node.RPCPort > last {
+					last = node.RPCPort
+				}
+			}
+			rpcPorts = fmt.Sprintf("%d-%d", first, last)
+		}
+		rows = append(rows, []string{
+			name,
+			"✅ Launched " + manifest.CreatedAt.Local().Format("2006-01-02 15:04"),
+			fmt.Sprintf("%d", manifest.ChainID),
+			fmt.Sprintf("%d", len(manifest.Nodes)),
+			rpcPorts,
+			network.DockerNetwork,
+			network.BaseDir,
+		})
+	}
+
+	if err := ns.feedback.DisplayTable(ctx, headers, rows); err != nil {
+		return fmt.Errorf("failed to display table: %w", err)
+	}
+	ns.feedback.Info(ctx, "💡 Use 'benchy --network <name> launch-network' to create another network")
+	return nil
+}
+
+// ValidateConfiguration vérifie la topologie, les clés, le genesis et les images des clients,
+// et affiche tous les problèmes trouvés
+func (ns *NetworkService) ValidateConfiguration(ctx context.Context) error {
+	network, err := ns.configManager.Network()
+	if err != nil {
+		return err
+	}
+	ns.feedback.Info(ctx, fmt.Sprintf("🔍 Validating %s for network %s...", configFileName(), network.Name))
+
+	report := validateNetwork(ctx, ns.configManager, ns.clients, ns.dockerClient)
+	if err := displayValidation(ctx, ns.feedback, report); err != nil {
+		return err
+	}
+	if len(report.Warnings) > 0 {
+		ns.feedback.Success(ctx, fmt.Sprintf("✅ Configuration is valid (%d warnings)", len(report.Warnings)))
+	} else {
+		ns.feedback.Success(ctx, "✅ Configuration is valid")
+	}
+	return nil
+}
+
+// CheckNodeName vérifie qu'un node fait partie du réseau
+func (ns *NetworkService) CheckNodeName(nodeName string) error {
+	names, err := ns.configManager.NodeNames()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name == nodeName {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid node name '%s'. Valid nodes: %s", nodeName, strings.Join(names, ", "))
+}
+
+// RenderNodeConfig affiche le fichier de configuration effectif du client d'un node :
+// celui du réseau lancé, ou celui qui serait généré depuis .benchy.yaml
+func (ns *NetworkService) RenderNodeConfig(ctx context.Context, nodeName string) error {
+	if err := ns.CheckNodeName(nodeName); err != nil {
+		return err
+	}
+
+	var networkID uint64
+	if manifest, err := ns.configManager.LoadExistingConfigurations(); err == nil {
+		networkID = manifest.ChainID
+	} else if errors.Is(err, config.ErrNoManifest) {
+		genesisConfig, err := ns.configManager.Genesis()
+		if err != nil {
+			return err
+		}
+		if err := generateNodes(ns.configManager, ns.clients); err != nil {
+			return fmt.Errorf("failed to generate node configurations: %w", err)
+		}
+		networkID = genesisConfig.ChainID
+		ns.feedback.Warning(ctx, "⚠️  Network not launched yet: rendering from .benchy.yaml, addresses are placeholders unless keys are derived from a mnemonic")
+	} else {
+		return err
+	}
+
+	nodeConfig := ns.configManager.GetNodeByName(nodeName)
+	adapter, err := ns.clients.Adapter(nodeConfig.Client)
+	if err != nil {
+		return err
+	}
+	content, err := adapter.RenderConfig(nodeLaunchSpec(nodeConfig, networkID))
+	if err != nil {
+		return fmt.Errorf("failed to render client config of %s: %w", nodeName, err)
+	}
+
+	ns.feedback.Info(ctx, fmt.Sprintf("📄 %s config of %s, mounted at %s:", nodeConfig.Client, nodeName, adapter.ConfigPath()))
+	fmt.Println()
+	fmt.Print(string(content))
+	return nil
 }
 
 // StopNetwork arrête le réseau
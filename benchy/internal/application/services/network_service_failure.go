@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"benchy/internal/infrastructure/config"
+)
+
+// Durée d'arrêt par défaut d'une panne temporaire
+const DefaultFailureDuration = 40 * time.Second
+
+// Délai laissé à un node redémarré pour répondre de nouveau
+const failureRecoveryTimeout = 60 * time.Second
+
+// TemporaryFailure arrête le container d'un node, attend duration puis le redémarre et
+// attend qu'il réponde de nouveau. Le node est redémarré même si le contexte est annulé
+// pendant la panne.
+func (ns *NetworkService) TemporaryFailure(ctx context.Context, nodeName string, duration time.Duration) error {
+	node, err := ns.FailureTarget(nodeName)
+	if err != nil {
+		return err
+	}
+	ns.feedback.Info(ctx, fmt.Sprintf("🔥 Simulating failure for node: %s", nodeName))
+	if err := ns.dockerClient.StopContainer(ctx, node.ContainerID); err != nil {
+		return fmt.Errorf("failed to stop container of %s: %w", nodeName, err)
+	}
+	ns.feedback.Success(ctx, fmt.Sprintf("✅ Node %s stopped", nodeName))
+	ns.feedback.Info(ctx, fmt.Sprintf("⏳ Waiting %s before restart...", duration))
+
+	waitErr := sleepContext(ctx, duration)
+
+	// Un node ne doit pas rester arrêté parce que la commande a été interrompue
+	restartCtx, cancel := context.WithTimeout(context.Background(), failureRecoveryTimeout)
+	defer cancel()
+	ns.feedback.Info(ctx, fmt.Sprintf("🔄 Restarting node %s...", nodeName))
+	if err := ns.dockerClient.StartContainer(restartCtx, node.ContainerID); err != nil {
+		return fmt.Errorf("failed to restart container of %s: %w", nodeName, err)
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+
+	if err := ns.waitForNodeRecovery(ctx, node); err != nil {
+		return err
+	}
+	ns.feedback.Success(ctx, fmt.Sprintf("✅ Node %s recovered successfully!", nodeName))
+	ns.feedback.Info(ctx, "💡 Use 'benchy infos' to monitor the node synchronization")
+	return nil
+}
+
+// FailureTarget retourne le node du réseau lancé sur lequel injecter une panne. Seul le
+// manifest est lu, sans charger les clés : plusieurs pannes peuvent tourner en parallèle.
+func (ns *NetworkService) FailureTarget(nodeName string) (*config.ManifestNode, error) {
+	manifest, err := config.LoadManifest(ns.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(manifest.Nodes))
+	for i, node := range manifest.Nodes {
+		if node.Name == nodeName {
+			if node.ContainerID == "" {
+				return nil, fmt.Errorf("node %s has no container: did you run 'benchy launch-network'?", nodeName)
+			}
+			return &manifest.Nodes[i], nil
+		}
+		names = append(names, node.Name)
+	}
+	return nil, fmt.Errorf("invalid node name '%s'. Valid nodes: %s", nodeName, strings.Join(names, ", "))
+}
+
+// waitForNodeRecovery attend que le node réponde au contrôle de santé de son client
+func (ns *NetworkService) waitForNodeRecovery(ctx context.Context, node *config.ManifestNode) error {
+	adapter, err := ns.clients.Adapter(node.Client)
+	if err != nil {
+		return err
+	}
+	nodeURL := fmt.Sprintf("http://localhost:%d", node.RPCPort)
+
+	timeout := time.After(failureRecoveryTimeout)
+	ticker := time.NewTicker(3 * time.Second)
+	defer ticker.Stop()
+	for {
+		if _, err := adapter.Health(ctx, nodeURL); err == nil {
+			return nil
+		}
+		select {
+		case <-timeout:
+			return fmt.Errorf("node %s failed to recover within %s", node.Name, failureRecoveryTimeout)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// sleepContext attend d, ou retourne l'erreur du contexte s'il est annulé avant
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
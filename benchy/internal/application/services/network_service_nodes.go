@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"benchy/internal/infrastructure/config"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Délai minimal d'attente d'un vote Clique, en plus de quelques tours de validateurs
+const voteGracePeriod = 30 * time.Second
+
+// AddNode ajoute un node au réseau lancé : clés, container initialisé avec le genesis du
+// réseau, peering avec les autres nodes et, pour un validateur, vote Clique pour l'admettre
+func (ns *NetworkService) AddNode(ctx context.Context, spec config.NodeSpec) error {
+	manifest, err := ns.configManager.LoadExistingConfigurations()
+	if err != nil {
+		return err
+	}
+	adapter, err := ns.clients.Adapter(spec.Client)
+	if err != nil {
+		return err
+	}
+
+	existing := ns.configManager.GetAllNodes()
+	voters := validatorNodes(existing, "")
+	networkEntity := ns.createNetworkEntity(manifest.Network, manifest.ChainParams(), existing)
+
+	// 1. Générer les clés et la configuration du node
+	nodeConfig, err := ns.configManager.AddNode(spec)
+	if err != nil {
+		return err
+	}
+	if adapter.SealsWithNodeKey() {
+		ns.configManager.UseAccountKeyAsNodeKey(nodeConfig.Name)
+	}
+	if err := ns.configManager.SaveNodeConfiguration(nodeConfig.Name); err != nil {
+		return err
+	}
+	role := "node"
+	if nodeConfig.IsValidator {
+		role = "validator"
+	}
+	ns.feedback.Info(ctx, fmt.Sprintf("➕ Adding %s %s to network %s:", role, nodeConfig.Name, manifest.Network))
+	ns.feedback.Info(ctx, fmt.Sprintf("   - Client: %s", nodeConfig.Client))
+	ns.feedback.Info(ctx, fmt.Sprintf("   - Address: %s", nodeConfig.KeyPair.Address.Hex()))
+	ns.feedback.Info(ctx, fmt.Sprintf("   - Ports: p2p %d, rpc %d, ws %d", nodeConfig.Port, nodeConfig.RPCPort, nodeConfig.WSPort))
+
+	// 2. Lancer le container : le client initialise son datadir avec le genesis du réseau
+	node, err := ns.launchNode(ctx, nodeConfig, manifest.ChainID)
+	if err != nil {
+		return fmt.Errorf("failed to launch node %s: %w", nodeConfig.Name, err)
+	}
+	networkEntity.AddNode(node)
+	if err := ns.configManager.UpdateManifest(manifest); err != nil {
+		return fmt.Errorf("failed to update network manifest: %w", err)
+	}
+	ns.feedback.Success(ctx, fmt.Sprintf("✅ %s container started", nodeConfig.Name))
+
+	// 3. Le node se connecte seul à ses nodes statiques ; les autres l'ajoutent aussi
+	ns.connectPeers(ctx, nodeConfig, existing)
+
+	// 4. Un validateur ne scelle qu'une fois admis par la majorité des validateurs
+	if nodeConfig.IsValidator {
+		if err := ns.voteSigner(ctx, voters, nodeConfig, true, manifest.ChainParams().BlockTime); err != nil {
+			return fmt.Errorf("%s was added but not admitted as a validator: %w", nodeConfig.Name, err)
+		}
+	}
+
+	ns.feedback.Success(ctx, fmt.Sprintf("🎉 %s joined the network (%d nodes, %d validators)", nodeConfig.Name, networkEntity.TotalNodes, len(networkEntity.Validators)))
+	return nil
+}
+
+// RemoveNode retire un node du réseau lancé : vote Clique pour exclure un validateur,
+// déconnexion des autres nodes puis suppression du container et de son répertoire
+func (ns *NetworkService) RemoveNode(ctx context.Context, nodeName string, force bool) error {
+	manifest, err := ns.configManager.LoadExistingConfigurations()
+	if err != nil {
+		return err
+	}
+	if err := ns.CheckNodeName(nodeName); err != nil {
+		return err
+	}
+	nodes := ns.configManager.GetAllNodes()
+	if len(nodes) == 1 {
+		return fmt.Errorf("cannot remove %s: it is the last node of the network", nodeName)
+	}
+	nodeConfig := ns.configManager.GetNodeByName(nodeName)
+	networkEntity := ns.createNetworkEntity(manifest.Network, manifest.ChainParams(), nodes)
+	ns.feedback.Info(ctx, fmt.Sprintf("➖ Removing %s from network %s...", nodeName, manifest.Network))
+
+	// 1. Exclure le validateur tant qu'il est encore en ligne pour ne pas perdre le quorum
+	if nodeConfig.IsValidator {
+		if len(validatorNodes(nodes, nodeName)) == 0 {
+			return fmt.Errorf("cannot remove %s: it is the only validator of the network", nodeName)
+		}
+		// Le validateur vote aussi son propre retrait : à deux validateurs, la majorité est de deux
+		if err := ns.voteSigner(ctx, validatorNodes(nodes, ""), nodeConfig, false, manifest.ChainParams().BlockTime); err != nil {
+			if !force {
+				return fmt.Errorf("failed to vote %s out: %w (use --force to remove it anyway)", nodeName, err)
+			}
+			ns.feedback.Warning(ctx, fmt.Sprintf("⚠️  %s is still a signer: %v", nodeName, err))
+		}
+	}
+
+	// 2. Les autres nodes ne doivent plus tenter de s'y reconnecter
+	for _, other := range nodes {
+		if other.Name == nodeName {
+			continue
+		}
+		adapter, err := ns.clients.Adapter(other.Client)
+		if err != nil {
+			return err
+		}
+		if err := adapter.RemovePeer(ctx, fmt.Sprintf("http://localhost:%d", other.RPCPort), nodeConfig.Enode); err != nil {
+			ns.feedback.Warning(ctx, fmt.Sprintf("⚠️  Could not disconnect %s from %s: %v", other.Name, nodeName, err))
+		}
+	}
+
+	// 3. Supprimer le container puis l'état du node
+	if nodeConfig.ContainerID != "" {
+		if err := ns.dockerClient.StopContainer(ctx, nodeConfig.ContainerID); err != nil && !force {
+			return fmt.Errorf("failed to stop container of %s: %w", nodeName, err)
+		}
+		if err := ns.dockerClient.RemoveContainer(ctx, nodeConfig.ContainerID); err != nil && !force {
+			return fmt.Errorf("failed to remove container of %s: %w", nodeName, err)
+		}
+	}
+	ns.feedback.Success(ctx, fmt.Sprintf("✅ %s container removed", nodeName))
+
+	if _, err := ns.configManager.RemoveNode(nodeName); err != nil {
+		return err
+	}
+	if err := ns.configManager.UpdateManifest(manifest); err != nil {
+		return fmt.Errorf("failed to update network manifest: %w", err)
+	}
+	if err := os.RemoveAll(filepath.Join(ns.baseDir, "nodes", nodeName)); err != nil {
+		ns.feedback.Warning(ctx, fmt.Sprintf("⚠️  Could not remove the directory of %s: %v", nodeName, err))
+	}
+
+	networkEntity.RemoveNode(nodeName)
+	ns.feedback.Success(ctx, fmt.Sprintf("🎉 %s left the network (%d nodes, %d validators)", nodeName, networkEntity.TotalNodes, len(networkEntity.Validators)))
+	return nil
+}
+
+// connectPeers demande aux nodes existants d'ajouter le nouveau node comme peer
+func (ns *NetworkService) connectPeers(ctx context.Context, nodeConfig *config.NodeConfig, others []*config.NodeConfig) {
+	var failed []string
+	for _, other := range others {
+		adapter, err := ns.clients.Adapter(other.Client)
+		if err != nil {
+			failed = append(failed, other.Name)
+			continue
+		}
+		if err := adapter.AddPeer(ctx, fmt.Sprintf("http://localhost:%d", other.RPCPort), nodeConfig.Enode); err != nil {
+			failed = append(failed, other.Name)
+		}
+	}
+	if len(failed) > 0 {
+		ns.feedback.Warning(ctx, fmt.Sprintf("⚠️  %s could not add %s as a peer, it will dial them from its static nodes", strings.Join(failed, ", "), nodeConfig.Name))
+		return
+	}
+	ns.feedback.Success(ctx, fmt.Sprintf("✅ %s peered with %d nodes", nodeConfig.Name, len(others)))
+}
+
+// voteSigner fait voter les validateurs pour admettre (authorize) ou exclure un validateur,
+// puis attend que la majorité des votes ait été scellée
+func (ns *NetworkService) voteSigner(ctx context.Context, voters []*config.NodeConfig, candidate *config.NodeConfig, authorize bool, blockTime time.Duration) error {
+	address := candidate.KeyPair.Address
+	action, outcome := "admit", "now one of"
+	if !authorize {
+		action, outcome = "exclude", "no longer one of"
+	}
+	ns.feedback.Info(ctx, fmt.Sprintf("🗳️  Asking %d validators to %s %s (%s)...", len(voters), action, candidate.Name, address.Hex()))
+
+	var voted []*config.NodeConfig
+	for _, voter := range voters {
+		adapter, err := ns.clients.Adapter(voter.Client)
+		if err != nil {
+			return err
+		}
+		if err := adapter.ProposeSigner(ctx, fmt.Sprintf("http://localhost:%d", voter.RPCPort), address, authorize); err != nil {
+			ns.feedback.Warning(ctx, fmt.Sprintf("⚠️  %s could not vote: %v", voter.Name, err))
+			continue
+		}
+		voted = append(voted, voter)
+	}
+	// Clique applique un vote dès que plus de la moitié des validateurs l'ont scellé
+	if needed := len(voters)/2 + 1; len(voted) < needed {
+		return fmt.Errorf("only %d of %d validators voted, %d votes are needed", len(voted), len(voters), needed)
+	}
+	if blockTime == 0 {
+		ns.feedback.Warning(ctx, "⚠️  Blocks are sealed on demand (period 0): votes only apply once transactions are sent")
+	}
+
+	// Chaque validateur scelle son vote à son tour : quelques rotations suffisent
+	timeout := voteGracePeriod + 2*time.Duration(len(voters)+1)*blockTime
+	spinner, err := ns.feedback.StartSpinner(ctx, fmt.Sprintf("Waiting for the vote to be sealed (up to %s)...", timeout))
+	if err != nil {
+		return err
+	}
+	adapter, err := ns.clients.Adapter(voted[0].Client)
+	if err != nil {
+		return err
+	}
+	nodeURL := fmt.Sprintf("http://localhost:%d", voted[0].RPCPort)
+	deadline := time.Now().Add(timeout)
+	for {
+		signers, err := adapter.Signers(ctx, nodeURL)
+		if err == nil && containsAddress(signers, address) == authorize {
+			spinner.Success(fmt.Sprintf("✅ %s is %s the validators (%d signers)", candidate.Name, outcome, len(signers)))
+			return nil
+		}
+		if time.Now().After(deadline) {
+			spinner.Error("❌ Vote not sealed in time")
+			if err != nil {
+				return fmt.Errorf("failed to read signers from %s: %w", voted[0].Name, err)
+			}
+			return fmt.Errorf("the vote was not sealed after %s, check 'benchy consensus'", timeout)
+		}
+		select {
+		case <-ctx.Done():
+			spinner.Error("❌ Context cancelled")
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// validatorNodes retourne les validateurs, sauf celui nommé except
+func validatorNodes(nodes []*config.NodeConfig, except string) []*config.NodeConfig {
+	var validators []*config.NodeConfig
+	for _, node := range nodes {
+		if node.IsValidator && node.Name != except {
+			validators = append(validators, node)
+		}
+	}
+	return validators
+}
+
+// containsAddress indique si une adresse fait partie de la liste
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, candidate := range addresses {
+		if candidate == address {
+			return true
+		}
+	}
+	return false
+}
@@ -4,16 +4,18 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 	"time"
 
 	"benchy/internal/domain/entities"
 	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/clients"
 	"benchy/internal/infrastructure/config"
 	"benchy/internal/infrastructure/docker"
 	"benchy/internal/infrastructure/ethereum"
 	"benchy/internal/infrastructure/feedback"
 	"benchy/internal/infrastructure/monitoring"
+	"github.com/ethereum/go-ethereum/core"
 )
 
 // NetworkServiceReal implémente le lancement de vrais containers
@@ -23,6 +25,7 @@ type NetworkServiceReal struct {
 	monitor       *monitoring.SystemMonitor
 	feedback      *feedback.ConsoleFeedback
 	configManager *config.NodeConfigManager
+	clients       *clients.Provider
 	baseDir       string
 }
 
@@ -35,7 +38,8 @@ func NewNetworkServiceReal(baseDir string) (*NetworkServiceReal, error) {
 	}
 
 	ethClient := ethereum.NewEthereumClient()
-	monitor := monitoring.NewSystemMonitor()
+	provider := clients.NewProvider(ethClient)
+	monitor := monitoring.NewSystemMonitor(dockerClient, ethClient, provider)
 	feedback := feedback.NewConsoleFeedback()
 	configManager := config.NewNodeConfigManager(baseDir)
 
@@ -45,6 +49,7 @@ func NewNetworkServiceReal(baseDir string) (*NetworkServiceReal, error) {
 		monitor:       monitor,
 		feedback:      feedback,
 		configManager: configManager,
+		clients:       provider,
 		baseDir:       baseDir,
 	}, nil
 }
@@ -52,10 +57,36 @@ func NewNetworkServiceReal(baseDir string) (*NetworkServiceReal, error) {
 // LaunchNetworkReal lance vraiment des containers Docker
 func (ns *NetworkServiceReal) LaunchNetworkReal(ctx context.Context) error {
 	ns.feedback.Info(ctx, "🚀 Launching REAL Ethereum network...")
+	// Réserver l'emplacement du réseau avant d'en dériver les ports et le chain ID
+	network, err := ns.configManager.Network()
+	if err != nil {
+		return err
+	}
+	if err := displayValidation(ctx, ns.feedback, validateNetwork(ctx, ns.configManager, ns.clients, ns.dockerClient)); err != nil {
+		return err
+	}
+	if err := network.Register(); err != nil {
+		return err
+	}
+	topology, err := ns.configManager.Topology()
+	if err != nil {
+		return err
+	}
 	ns.feedback.Info(ctx, "📋 Configuration:")
-	ns.feedback.Info(ctx, "   - 5 nodes: Alice, Bob, Cassandra, Driss, Elena")
-	ns.feedback.Info(ctx, "   - 3 validators: Alice, Bob, Cassandra")
-	ns.feedback.Info(ctx, "   - Clients: Geth + Nethermind")
+	ns.feedback.Info(ctx, "   - "+network.Summary())
+	for _, line := range topology.Summary() {
+		ns.feedback.Info(ctx, "   - "+line)
+	}
+	keys, err := ns.configManager.Keys()
+	if err != nil {
+		return err
+	}
+	ns.feedback.Info(ctx, "   - "+keys.Summary())
+	genesisConfig, err := ns.configManager.Genesis()
+	if err != nil {
+		return err
+	}
+	ns.feedback.Info(ctx, "   - "+genesisConfig.Summary())
 	ns.feedback.Info(ctx, "   - Consensus: Clique")
 
 	// 1. Vérifier que Docker fonctionne
@@ -73,19 +104,29 @@ func (ns *NetworkServiceReal) LaunchNetworkReal(ctx context.Context) error {
 		return fmt.Errorf("failed to create base directory: %w", err)
 	}
 
-	// 4. Générer les configurations
-	if err := ns.configManager.GenerateDefaultNodes(); err != nil {
-		return fmt.Errorf("failed to generate node configurations: %w", err)
+	// 4. Générer les configurations et le genesis, avec les clés du réseau déjà lancé
+	genesis, err := prepareNodes(ctx, ns.feedback, ns.configManager, ns.clients)
+	if err != nil {
+		return err
+	}
+
+	// Chiffrer les clés dans les keystores des nodes
+	if err := ns.configManager.SaveAllConfigurations(); err != nil {
+		return fmt.Errorf("failed to save node configurations: %w", err)
 	}
 
 	// 5. Créer le fichier genesis
-	if err := ns.createGenesisFile(ctx); err != nil {
+	if err := ns.createGenesisFile(ctx, genesis); err != nil {
 		return fmt.Errorf("failed to create genesis file: %w", err)
 	}
+	// Un datadir initialisé avec un autre genesis ne se connecterait jamais aux autres nodes
+	if err := checkDataDirGenesis(ns.baseDir, ns.configManager.GetAllNodes(), genesis); err != nil {
+		return err
+	}
 
 	// 6. Créer le réseau Docker
 	ns.feedback.Info(ctx, "🌐 Creating Docker network...")
-	if err := ns.dockerClient.CreateNetwork(ctx, "benchy-network"); err != nil {
+	if err := ns.dockerClient.CreateNetwork(ctx, network.DockerNetwork); err != nil {
 		return fmt.Errorf("failed to create docker network: %w", err)
 	}
 
@@ -116,6 +157,21 @@ func (ns *NetworkServiceReal) LaunchNetworkReal(ctx context.Context) error {
 		return fmt.Errorf("nodes failed to become ready: %w", err)
 	}
 
+	// Un node sur un autre genesis ne trouverait jamais de peers : échouer avant d'écrire le manifest
+	if err := checkNodesGenesis(ctx, ns.feedback, ns.ethClient, nodes, genesis); err != nil {
+		return err
+	}
+
+	// Les autres commandes relisent le manifest au lieu de régénérer les clés : seulement
+	// un réseau dont les nodes ont démarré sur le bon genesis
+	if err := ns.configManager.SaveManifest(network.Name, genesis, networkGenesisFiles(ns.baseDir).genesis); err != nil {
+		return fmt.Errorf("failed to save network manifest: %w", err)
+	}
+	ns.feedback.Success(ctx, fmt.Sprintf("✅ Network manifest saved to %s", config.ManifestPath(ns.baseDir)))
+
+	// Les enodes sont connus d'avance : vérifier que le maillage s'est formé
+	ns.checkPeering(ctx, nodes)
+
 	ns.feedback.Success(ctx, "🎉 Real Ethereum network launched successfully!")
 	ns.feedback.Info(ctx, "💡 Use 'benchy infos' to monitor the live network")
 	ns.feedback.Info(ctx, "💡 Use 'docker ps' to see the running containers")
@@ -148,18 +204,10 @@ func (ns *NetworkServiceReal) cleanupOldContainers(ctx context.Context) error {
 }
 
 // createGenesisFile crée le fichier genesis
-func (ns *NetworkServiceReal) createGenesisFile(ctx context.Context) error {
+func (ns *NetworkServiceReal) createGenesisFile(ctx context.Context, genesis *core.Genesis) error {
 	ns.feedback.Info(ctx, "📄 Creating genesis file...")
-	
-	genesis, err := ns.configManager.GenerateGenesisWithNodes()
-	if err != nil {
-		return err
-	}
 
-	genesisPath := filepath.Join(ns.baseDir, "genesis.json")
-	generator := config.NewGenesisGenerator()
-	
-	return generator.SaveGenesisToFile(genesis, genesisPath)
+	return networkGenesisFiles(ns.baseDir).save(genesis)
 }
 
 // launchRealContainer lance un vrai container Docker
@@ -173,13 +221,11 @@ func (ns *NetworkServiceReal) launchRealContainer(ctx context.Context, nodeConfi
 		return fmt.Errorf("failed to create keystore directory: %w", err)
 	}
 
-	// Sauvegarder les clés
-	if err := nodeConfig.KeyPair.SaveKeyPairToFile(nodeConfig.KeystoreDir, nodeConfig.Name); err != nil {
-		return fmt.Errorf("failed to save keys: %w", err)
-	}
-
 	// Configuration du container
-	containerConfig := ns.buildRealContainerConfig(nodeConfig)
+	containerConfig, err := ns.buildRealContainerConfig(nodeConfig)
+	if err != nil {
+		return err
+	}
 
 	// Créer le node entity
 	node := entities.NewNode(
@@ -200,16 +246,24 @@ func (ns *NetworkServiceReal) launchRealContainer(ctx context.Context, nodeConfi
 	if err := ns.dockerClient.StartContainer(ctx, containerID); err != nil {
 		return fmt.Errorf("failed to start container: %w", err)
 	}
+	nodeConfig.ContainerID = containerID
 
 	return nil
 }
 
 // buildRealContainerConfig construit la config pour un vrai container
-func (ns *NetworkServiceReal) buildRealContainerConfig(nodeConfig *config.NodeConfig) ports.ContainerConfig {
-	genesisPath := filepath.Join(ns.baseDir, "genesis.json")
-	
+func (ns *NetworkServiceReal) buildRealContainerConfig(nodeConfig *config.NodeConfig) (ports.ContainerConfig, error) {
+	network, err := ns.configManager.Network()
+	if err != nil {
+		return ports.ContainerConfig{}, err
+	}
+	passwordFile, err := ns.configManager.PasswordFile()
+	if err != nil {
+		return ports.ContainerConfig{}, err
+	}
+
 	config := ports.ContainerConfig{
-		Name: fmt.Sprintf("benchy-%s", nodeConfig.Name),
+		Name: nodeConfig.Container,
 		Ports: map[string]string{
 			fmt.Sprintf("%d", nodeConfig.Port):    fmt.Sprintf("%d", nodeConfig.Port),
 			fmt.Sprintf("%d", nodeConfig.RPCPort): fmt.Sprintf("%d", nodeConfig.RPCPort),
@@ -217,87 +271,32 @@ func (ns *NetworkServiceReal) buildRealContainerConfig(nodeConfig *config.NodeCo
 		Volumes: map[string]string{
 			nodeConfig.DataDir:     "/data",
 			nodeConfig.KeystoreDir: "/keystore",
-			genesisPath:           "/genesis.json",
+			passwordFile:           "/password.txt",
 		},
-		NetworkMode: "benchy-network",
+		NetworkMode: network.DockerNetwork,
 		Labels: map[string]string{
 			"benchy.node.name":      nodeConfig.Name,
 			"benchy.node.validator": fmt.Sprintf("%t", nodeConfig.IsValidator),
 			"benchy.node.client":    string(nodeConfig.Client),
+			"benchy.network":        network.Name,
 		},
 	}
+	networkGenesisFiles(ns.baseDir).mount(config.Volumes)
 
-	// Configuration spécifique par client
-	switch nodeConfig.Client {
-	case entities.ClientGeth:
-		config.Image = "ethereum/client-go:v1.10.26"  // Version stable
-		config.Command = ns.buildRealGethCommand(nodeConfig)
-	case entities.ClientNethermind:
-		config.Image = "nethermind/nethermind:1.14.7"  // Version stable
-		config.Command = ns.buildRealNethermindCommand(nodeConfig)
+	// Configuration spécifique au client
+	adapter, err := ns.clients.Adapter(nodeConfig.Client)
+	if err != nil {
+		return ports.ContainerConfig{}, err
 	}
-
-	return config
-}
-
-// buildRealGethCommand construit une vraie commande Geth
-func (ns *NetworkServiceReal) buildRealGethCommand(nodeConfig *config.NodeConfig) []string {
-	cmd := []string{
-		"geth",
-		"--datadir", "/data",
-		"--keystore", "/keystore",
-		"--networkid", "1337",
-		"--port", fmt.Sprintf("%d", nodeConfig.Port),
-		"--http",
-		"--http.addr", "0.0.0.0",
-		"--http.port", fmt.Sprintf("%d", nodeConfig.RPCPort),
-		"--http.api", "eth,net,web3,personal,miner,admin,debug",
-		"--http.corsdomain", "*",
-		"--ws",
-		"--ws.addr", "0.0.0.0",
-		"--ws.port", fmt.Sprintf("%d", nodeConfig.WSPort),
-		"--ws.api", "eth,net,web3,personal,miner,admin",
-		"--ws.origins", "*",
-		"--allow-insecure-unlock",
-		"--nodiscover",
-		"--maxpeers", "25",
-		"--syncmode", "full",
-		"--gcmode", "archive",
-		"--verbosity", "3",
-		"--nat", "extip:127.0.0.1",
-	}
-
-	// Initialisation avec genesis au premier démarrage
-	if _, err := os.Stat(filepath.Join(nodeConfig.DataDir, "geth")); os.IsNotExist(err) {
-		// Premier démarrage - initialiser avec genesis
-		return append([]string{"geth", "init", "/genesis.json", "--datadir", "/data", "&&"}, cmd...)
-	}
-
-	if nodeConfig.IsValidator {
-		cmd = append(cmd,
-			"--mine",
-			"--miner.threads", "1",
-			"--miner.etherbase", nodeConfig.KeyPair.Address.Hex(),
-		)
-	}
-
-	return cmd
-}
-
-// buildRealNethermindCommand construit une vraie commande Nethermind
-func (ns *NetworkServiceReal) buildRealNethermindCommand(nodeConfig *config.NodeConfig) []string {
-	return []string{
-		"./Nethermind.Runner",
-		"--config", "mainnet",
-		"--datadir", "/data",
-		"--Network.DiscoveryPort", fmt.Sprintf("%d", nodeConfig.Port),
-		"--Network.P2PPort", fmt.Sprintf("%d", nodeConfig.Port),
-		"--JsonRpc.Enabled", "true",
-		"--JsonRpc.Host", "0.0.0.0",
-		"--JsonRpc.Port", fmt.Sprintf("%d", nodeConfig.RPCPort),
-		"--JsonRpc.EnabledModules", "Eth,Subscribe,Trace,TxPool,Web3,Personal,Proof,Net,Parity,Health,Rpc",
-		"--Init.ChainSpecPath", "/genesis.json",
+	genesisConfig, err := ns.configManager.Genesis()
+	if err != nil {
+		return ports.ContainerConfig{}, err
 	}
+	if err := configureClient(&config, adapter, nodeConfig, genesisConfig.ChainID); err != nil {
+		return ports.ContainerConfig{}, err
+	}
+
+	return config, nil
 }
 
 // waitForNodesReady attend que les nodes soient opérationnels
@@ -348,12 +347,57 @@ func (ns *NetworkServiceReal) waitForNodesReady(ctx context.Context, nodes []*co
 func (ns *NetworkServiceReal) checkNodeReady(ctx context.Context, nodeConfig *config.NodeConfig) bool {
 	nodeURL := fmt.Sprintf("http://localhost:%d", nodeConfig.RPCPort)
 	
-	// Essayer de se connecter
-	if err := ns.ethClient.ConnectToNode(ctx, nodeURL); err != nil {
+	adapter, err := ns.clients.Adapter(nodeConfig.Client)
+	if err != nil {
 		return false
 	}
-	
-	// Essayer de récupérer le dernier bloc
-	_, err := ns.ethClient.GetLatestBlockNumber(ctx, nodeURL)
+
+	// Le node est prêt dès qu'il répond au contrôle de santé de son client
+	_, err = adapter.Health(ctx, nodeURL)
 	return err == nil
 }
+
+// checkPeering compare les peers de chaque node aux enodes attendus du manifest
+func (ns *NetworkServiceReal) checkPeering(ctx context.Context, nodes []*config.NodeConfig) {
+	connected := true
+	for _, node := range nodes {
+		missing, err := ns.missingPeers(ctx, node, nodes)
+		if err != nil {
+			ns.feedback.Warning(ctx, fmt.Sprintf("⚠️  Could not check peers of %s: %v", node.Name, err))
+			connected = false
+			continue
+		}
+		if len(missing) > 0 {
+			ns.feedback.Warning(ctx, fmt.Sprintf("⚠️  %s is not connected to %s yet", node.Name, strings.Join(missing, ", ")))
+			connected = false
+		}
+	}
+	if connected {
+		ns.feedback.Success(ctx, "✅ All nodes are connected to each other")
+	}
+}
+
+// missingPeers retourne les nodes du réseau auxquels un node n'est pas connecté
+func (ns *NetworkServiceReal) missingPeers(ctx context.Context, node *config.NodeConfig, nodes []*config.NodeConfig) ([]string, error) {
+	adapter, err := ns.clients.Adapter(node.Client)
+	if err != nil {
+		return nil, err
+	}
+	peers, err := adapter.Peers(ctx, fmt.Sprintf("http://localhost:%d", node.RPCPort))
+	if err != nil {
+		return nil, err
+	}
+
+	connected := make(map[string]bool, len(peers))
+	for _, peer := range peers {
+		connected[config.EnodeID(peer.Enode)] = true
+	}
+
+	var missing []string
+	for _, other := range nodes {
+		if other.Name != node.Name && !connected[config.EnodeID(other.Enode)] {
+			missing = append(missing, other.Name)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/config"
+	"benchy/internal/infrastructure/contracts"
+	"benchy/internal/infrastructure/ethereum"
+	"benchy/internal/infrastructure/feedback"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Node qui reçoit et trace les transactions des scénarios
+const scenarioNode = "alice"
+
+// Node dont la clé signe les transactions des scénarios
+const scenarioSender = "alice"
+
+// Nom sous lequel le token du scénario ERC20 est déployé
+const scenarioTokenAlias = "bst"
+
+// Nombre de transferts du scénario 1, délai entre deux transferts et montant de chacun (0.1 ETH)
+const (
+	scenarioTransfers        = 3
+	scenarioTransferInterval = 10 * time.Second
+)
+
+var scenarioTransferValue = big.NewInt(1e17)
+
+// ScenarioService exécute les scénarios de test, depuis benchy scenario ou l'API de benchy serve
+type ScenarioService struct {
+	ethClient     *ethereum.EthereumClient
+	configManager *config.NodeConfigManager
+	explorer      *ExplorerService
+	tokens        *TokenService
+	feedback      *feedback.ConsoleFeedback
+}
+
+// NewScenarioService crée le service des scénarios ; le token du scénario ERC20 est
+// déployé par le service des tokens et les transactions échouées sont tracées par l'explorateur
+func NewScenarioService(baseDir string, explorerService *ExplorerService, tokenService *TokenService) *ScenarioService {
+	return &ScenarioService{
+		ethClient:     ethereum.NewEthereumClient(),
+		configManager: config.NewNodeConfigManager(baseDir),
+		explorer:      explorerService,
+		tokens:        tokenService,
+		feedback:      feedback.NewConsoleFeedback(),
+	}
+}
+
+// ParseScenario retourne le type d'un scénario désigné par son numéro ou son alias
+func ParseScenario(name string) (entities.ScenarioType, error) {
+	switch name {
+	case "0", "init":
+		return entities.ScenarioInit, nil
+	case "1", "transfers":
+		return entities.ScenarioTransfers, nil
+	case "2", "erc20":
+		return entities.ScenarioERC20, nil
+	case "3", "replacement":
+		return entities.ScenarioReplacement, nil
+	}
+	return "", fmt.Errorf("unknown scenario: %s (use 0, 1, 2, 3 or init, transfers, erc20, replacement)", name)
+}
+
+// Run exécute le scénario jusqu'à sa fin ou l'annulation du contexte ; les transactions
+// échouées du scénario sont tracées et jointes au rapport
+func (ss *ScenarioService) Run(ctx context.Context, scenario *entities.Scenario) error {
+	var run func(ctx context.Context, scenario *entities.Scenario) error
+	switch scenario.Type {
+	case entities.ScenarioInit:
+		run = ss.runInit
+	case entities.ScenarioTransfers:
+		run = ss.runTransfers
+	case entities.ScenarioERC20:
+		run = ss.runERC20
+	case entities.ScenarioReplacement:
+		run = ss.runReplacement
+	default:
+		return fmt.Errorf("unknown scenario type: %s", scenario.Type)
+	}
+
+	scenario.Start()
+	if err := run(ctx, scenario); err != nil {
+		if errors.Is(err, context.Canceled) {
+			scenario.Cancel()
+			ss.feedback.Warning(ctx, fmt.Sprintf("⚠️  Scenario %s stopped", scenario.Name))
+			return err
+		}
+		scenario.Fail(err)
+		ss.explorer.AttachFailureTraces(ctx, scenario, scenarioNode)
+		return err
+	}
+	scenario.Complete()
+
+	return ss.explorer.AttachFailureTraces(ctx, scenario, scenarioNode)
+}
+
+// send diffuse une transaction, mémorise son hash dans le rapport puis attend son reçu ;
+// une transaction annulée (status 0) fait échouer le scénario et sa trace est jointe au rapport
+func (ss *ScenarioService) send(ctx context.Context, scenario *entities.Scenario, nodeURL, message string, send func() (common.Hash, error)) (*ports.TransactionReceipt, error) {
+	spinner, err := ss.feedback.StartSpinner(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+
+	txHash, err := send()
+	if err != nil {
+		spinner.Error("Transaction failed")
+		return nil, err
+	}
+	scenario.AddTransactionHash(txHash.Hex())
+
+	receipt, err := waitForReceipt(ctx, ss.ethClient, nodeURL, txHash)
+	if err != nil {
+		spinner.Error("Transaction not confirmed")
+		return nil, err
+	}
+	if receipt.Status != 1 {
+		spinner.Error(fmt.Sprintf("Transaction %s reverted", txHash.Hex()))
+		return nil, fmt.Errorf("transaction %s reverted", txHash.Hex())
+	}
+
+	spinner.Success(fmt.Sprintf("Confirmed in block #%d (gas used: %d)", receipt.BlockNumber, receipt.GasUsed))
+	return receipt, nil
+}
+
+// accounts charge la clé de l'émetteur et résout l'adresse des destinataires
+func (ss *ScenarioService) accounts(from string, recipients ...string) (common.Address, []common.Address, error) {
+	sender, err := registerSigner(ss.configManager, ss.ethClient, from)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	addresses := make([]common.Address, 0, len(recipients))
+	for _, recipient := range recipients {
+		address, err := ss.tokens.resolveHolder(recipient)
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return sender, addresses, nil
+}
+
+// Scénarios individuels
+
+func (ss *ScenarioService) runInit(ctx context.Context, scenario *entities.Scenario) error {
+	ss.feedback.Info(ctx, "🎯 Running Scenario 0: Network Initialization")
+
+	nodeURL, err := nodeRPCURL(ss.configManager, scenarioNode)
+	if err != nil {
+		return err
+	}
+	ethClient := ss.ethClient
+
+	block, err := ethClient.GetLatestBlockNumber(ctx, nodeURL)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", scenarioNode, err)
+	}
+	peers, err := ethClient.GetPeerCount(ctx, nodeURL)
+	if err != nil {
+		return fmt.Errorf("failed to get peer count of %s: %w", scenarioNode, err)
+	}
+	ss.feedback.Info(ctx, fmt.Sprintf("📦 %s is at block #%d with %d peers", scenarioNode, block, peers))
+
+	for address, name := range ss.configManager.LoadAddressBook() {
+		if name != scenarioSender {
+			continue
+		}
+		balance, err := ethClient.GetBalance(ctx, nodeURL, address)
+		if err != nil {
+			return fmt.Errorf("failed to get balance of %s: %w", name, err)
+		}
+		if balance.Sign() == 0 {
+			return fmt.Errorf("%s has no funds to send the scenario transactions", name)
+		}
+		ss.feedback.Info(ctx, fmt.Sprintf("💰 %s holds %s ETH", name, contracts.FormatUnits(balance, 18)))
+	}
+
+	ss.feedback.Success(ctx, "✅ Scenario 0 completed successfully!")
+	return nil
+}
+
+func (ss *ScenarioService) runTransfers(ctx context.Context, scenario *entities.Scenario) error {
+	ss.feedback.Info(ctx, "🎯 Running Scenario 1: Transfers")
+
+	nodeURL, err := nodeRPCURL(ss.configManager, scenarioNode)
+	if err != nil {
+		return err
+	}
+	sender, recipients, err := ss.accounts(scenarioSender, "bob")
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i <= scenarioTransfers; i++ {
+		if i > 1 {
+			if err := sleepContext(ctx, scenarioTransferInterval); err != nil {
+				return err
+			}
+		}
+		tx := entities.NewTransaction(sender, recipients[0], new(big.Int).Set(scenarioTransferValue), entities.TxTypeTransfer)
+		_, err := ss.send(ctx, scenario, nodeURL, fmt.Sprintf("📤 Transfer #%d: Alice → Bob (0.1 ETH)", i), func() (common.Hash, error) {
+			return ss.ethClient.SendTransaction(ctx, nodeURL, tx)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	ss.feedback.Success(ctx, "✅ Scenario 1 completed successfully!")
+	return nil
+}
+
+func (ss *ScenarioService) runERC20(ctx context.Context, scenario *entities.Scenario) error {
+	ss.feedback.Info(ctx, "🎯 Running Scenario 2: ERC20 Token Deployment")
+
+	nodeURL, err := nodeRPCURL(ss.configManager, scenarioNode)
+	if err != nil {
+		return err
+	}
+
+	deployment, err := ss.tokens.deployToken(ctx, "Benchy Scenario Token", "BST", "1000000e18", scenarioTokenAlias, scenarioSender, scenarioNode,
+		func(txHash common.Hash) { scenario.AddTransactionHash(txHash.Hex()) })
+	if err != nil {
+		return err
+	}
+
+	sender, recipients, err := ss.accounts(scenarioSender, "driss", "elena")
+	if err != nil {
+		return err
+	}
+	amount, _ := contracts.ParseAmount("100e18")
+	for _, recipient := range recipients {
+		name := ss.configManager.LoadAddressBook()[recipient]
+		_, err := ss.send(ctx, scenario, nodeURL, fmt.Sprintf("🪙 Transferring 100 BST from Alice to %s...", name), func() (common.Hash, error) {
+			return ss.ethClient.TransferToken(ctx, nodeURL, deployment.Address, sender, recipient, amount)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	ss.feedback.Info(ctx, fmt.Sprintf("💡 Use 'benchy token balances %s' to see holders", scenarioTokenAlias))
+	ss.feedback.Success(ctx, "✅ Scenario 2 completed successfully!")
+	return nil
+}
+
+func (ss *ScenarioService) runReplacement(ctx context.Context, scenario *entities.Scenario) error {
+	ss.feedback.Info(ctx, "🎯 Running Scenario 3: Transaction Replacement")
+
+	nodeURL, err := nodeRPCURL(ss.configManager, scenarioNode)
+	if err != nil {
+		return err
+	}
+	sender, recipients, err := ss.accounts(scenarioSender, "driss", "elena")
+	if err != nil {
+		return err
+	}
+	ethClient := ss.ethClient
+
+	ss.feedback.Info(ctx, "📤 Sending transaction to Driss...")
+	original := entities.NewTransaction(sender, recipients[0], new(big.Int).Set(scenarioTransferValue), entities.TxTypeTransfer)
+	originalHash, err := ethClient.SendTransaction(ctx, nodeURL, original)
+	if err != nil {
+		return err
+	}
+	scenario.AddTransactionHash(originalHash.Hex())
+
+	// Même nonce, prix du gas doublé : le mempool remplace la première transaction
+	replacement := entities.NewTransaction(sender, recipients[1], new(big.Int).Set(scenarioTransferValue), entities.TxTypeReplacement)
+	replacement.Nonce = original.Nonce
+	replacement.GasPrice = new(big.Int).Mul(original.GasPrice, big.NewInt(2))
+	_, err = ss.send(ctx, scenario, nodeURL, "📤 Replacing with higher fee transaction to Elena...", func() (common.Hash, error) {
+		hash, err := ethClient.SendTransaction(ctx, nodeURL, replacement)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("replacement rejected (was %s already mined?): %w", originalHash.Hex(), err)
+		}
+		return hash, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ss.feedback.Success(ctx, "✅ Scenario 3 completed successfully!")
+	return nil
+}
@@ -0,0 +1,384 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/infrastructure/api"
+	"benchy/internal/infrastructure/config"
+)
+
+// Statuts d'une panne injectée par l'API
+const (
+	failureRunning   = "running"
+	failureRecovered = "recovered"
+	failureFailed    = "failed"
+)
+
+// apiBackend exécute les requêtes de l'API de benchy serve sur le réseau lancé. Les
+// scénarios et les pannes tournent en tâche de fond jusqu'à l'arrêt du serveur.
+type apiBackend struct {
+	ss      *ServeService
+	network string
+	ctx     context.Context // Annulé à l'arrêt du serveur
+	wg      sync.WaitGroup
+
+	mu        sync.Mutex
+	scenarios []*scenarioRun
+	failures  []*api.Failure
+}
+
+// scenarioRun est un scénario lancé par l'API. Seul view est lu par les requêtes :
+// le scénario lui-même appartient à sa goroutine jusqu'à la fin.
+type scenarioRun struct {
+	view   api.Scenario
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newAPIBackend crée le backend de l'API du réseau ; ses tâches s'arrêtent avec ctx
+func (ss *ServeService) newAPIBackend(ctx context.Context, network string) *apiBackend {
+	return &apiBackend{ss: ss, network: network, ctx: ctx}
+}
+
+// wait attend la fin des scénarios et des pannes, une fois le contexte du backend annulé
+func (b *apiBackend) wait() {
+	b.wg.Wait()
+}
+
+// Manifest relit le manifest à chaque requête pour suivre les nodes ajoutés ou retirés
+func (b *apiBackend) Manifest(ctx context.Context) (*config.Manifest, error) {
+	manifest, _, err := b.ss.monitoring.getBenchyContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("%w: network not launched", api.ErrNotFound)
+	}
+	return manifest, nil
+}
+
+func (b *apiBackend) NetworkMetrics(ctx context.Context) (*api.NetworkMetrics, error) {
+	networkMetrics, err := b.ss.monitoring.systemMonitor.GetNetworkMetrics(ctx, b.network)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", api.ErrUnavailable, err)
+	}
+
+	metrics := &api.NetworkMetrics{
+		Network:          b.network,
+		SampledAt:        networkMetrics.Timestamp,
+		TotalNodes:       networkMetrics.TotalNodes,
+		OnlineNodes:      networkMetrics.OnlineNodes,
+		Validators:       networkMetrics.ValidatorNodes,
+		HeadBlock:        networkMetrics.LatestBlock,
+		BlockTimeSeconds: networkMetrics.AvgBlockTime.Seconds(),
+		LatencyMs:        float64(networkMetrics.NetworkLatency) / float64(time.Millisecond),
+		ConsensusStatus:  networkMetrics.ConsensusStatus,
+		MissedBlocks:     networkMetrics.MissedBlocks,
+		Sealers:          make([]api.Validator, 0, len(networkMetrics.Validators)),
+	}
+	for _, validator := range networkMetrics.Validators {
+		metrics.Sealers = append(metrics.Sealers, api.Validator{
+			Name:            validator.Name,
+			Address:         validator.Address.Hex(),
+			SealedBlocks:    validator.SealedBlocks,
+			InTurnPercent:   validator.InTurnRatio,
+			MissedTurns:     validator.MissedTurns,
+			LastSealedBlock: validator.LastSealedBlock,
+		})
+	}
+	return metrics, nil
+}
+
+func (b *apiBackend) Nodes(ctx context.Context) ([]api.Node, error) {
+	manifest, containers, err := b.ss.monitoring.getBenchyContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]api.Node, 0, len(containers))
+	if manifest == nil {
+		return nodes, nil
+	}
+	for i, container := range containers {
+		nodes = append(nodes, b.node(ctx, &manifest.Nodes[i], container))
+	}
+	return nodes, nil
+}
+
+func (b *apiBackend) Node(ctx context.Context, name string) (*api.Node, error) {
+	manifest, containers, err := b.ss.monitoring.getBenchyContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if manifest != nil {
+		for i, container := range containers {
+			if container.NodeName == name {
+				node := b.node(ctx, &manifest.Nodes[i], container)
+				return &node, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: unknown node '%s'", api.ErrNotFound, name)
+}
+
+// node assemble l'état d'un node à partir du manifest et du dernier tour du collecteur
+func (b *apiBackend) node(ctx context.Context, manifestNode *config.ManifestNode, container *ContainerInfo) api.Node {
+	node := api.Node{
+		Name:          container.NodeName,
+		Client:        string(manifestNode.Client),
+		Validator:     container.IsValidator,
+		Address:       container.Address.Hex(),
+		RPCPort:       container.RPCPort,
+		Container:     shortContainerID(container.ID),
+		Status:        "unknown",
+		ExpectedPeers: container.ExpectedPeers,
+		Failing:       b.failing(container.NodeName),
+	}
+	if metrics, err := b.ss.monitoring.systemMonitor.GetNodeMetrics(ctx, b.ss.monitoring.network, container.NodeName); err == nil {
+		node.Online = metrics.IsOnline
+		node.Status = metrics.SyncStatus
+		node.HeadBlock = metrics.LatestBlock
+		node.Peers = metrics.ConnectedPeers
+		node.PendingTxs = metrics.PendingTxs
+		node.QueuedTxs = metrics.QueuedTxs
+		node.CPUPercent = metrics.CPUUsage
+		node.MemoryBytes = metrics.MemoryBytes
+		node.LatencyMs = float64(metrics.ResponseTime) / float64(time.Millisecond)
+		node.LastSeen = metrics.LastSeen
+	}
+	return node
+}
+
+func (b *apiBackend) Alerts(ctx context.Context) ([]api.Alert, error) {
+	alerts, err := b.ss.monitoring.systemMonitor.GetActiveAlerts(ctx, b.network)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].ID < alerts[j].ID })
+
+	result := make([]api.Alert, 0, len(alerts))
+	for _, alert := range alerts {
+		result = append(result, api.Alert{
+			ID:       alert.ID,
+			Type:     string(alert.Type),
+			Severity: string(alert.Severity),
+			Node:     alert.NodeName,
+			Message:  alert.Message,
+			Since:    alert.Timestamp,
+		})
+	}
+	return result, nil
+}
+
+// StartScenario lance un scénario en tâche de fond ; un seul scénario de chaque type tourne à la fois
+func (b *apiBackend) StartScenario(ctx context.Context, name string) (*api.Scenario, error) {
+	scenarioType, err := ParseScenario(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", api.ErrInvalid, err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ctx.Err() != nil {
+		return nil, fmt.Errorf("%w: server is stopping", api.ErrUnavailable)
+	}
+	for _, run := range b.scenarios {
+		if run.view.Type == string(scenarioType) && run.view.Status == string(entities.ScenarioStatusRunning) {
+			return nil, fmt.Errorf("%w: scenario %s is already running as %s", api.ErrConflict, scenarioType, run.view.ID)
+		}
+	}
+
+	scenario := entities.NewScenario(scenarioType, name, "")
+	runCtx, cancel := context.WithCancel(b.ctx)
+	run := &scenarioRun{
+		view: api.Scenario{
+			ID:                scenario.ID,
+			Type:              string(scenarioType),
+			Status:            string(entities.ScenarioStatusRunning),
+			StartedAt:         time.Now(),
+			TransactionHashes: []string{},
+			Errors:            []string{},
+			FailedTraces:      []string{},
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	b.scenarios = append(b.scenarios, run)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		defer close(run.done)
+		defer cancel()
+		b.ss.scenarios.Run(runCtx, scenario)
+
+		b.mu.Lock()
+		run.view = scenarioView(scenario)
+		b.mu.Unlock()
+	}()
+
+	view := run.view
+	return &view, nil
+}
+
+func (b *apiBackend) Scenarios(ctx context.Context) ([]api.Scenario, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	scenarios := make([]api.Scenario, 0, len(b.scenarios))
+	for _, run := range b.scenarios {
+		scenarios = append(scenarios, run.view)
+	}
+	return scenarios, nil
+}
+
+func (b *apiBackend) Scenario(ctx context.Context, id string) (*api.Scenario, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	run := b.scenarioRun(id)
+	if run == nil {
+		return nil, fmt.Errorf("%w: unknown scenario '%s'", api.ErrNotFound, id)
+	}
+	view := run.view
+	return &view, nil
+}
+
+// StopScenario interrompt un scénario et attend qu'il se soit arrêté
+func (b *apiBackend) StopScenario(ctx context.Context, id string) (*api.Scenario, error) {
+	b.mu.Lock()
+	run := b.scenarioRun(id)
+	if run == nil {
+		b.mu.Unlock()
+		return nil, fmt.Errorf("%w: unknown scenario '%s'", api.ErrNotFound, id)
+	}
+	if run.view.Status != string(entities.ScenarioStatusRunning) {
+		b.mu.Unlock()
+		return nil, fmt.Errorf("%w: scenario %s is not running (%s)", api.ErrConflict, id, run.view.Status)
+	}
+	b.mu.Unlock()
+
+	run.cancel()
+	select {
+	case <-run.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return b.Scenario(ctx, id)
+}
+
+// scenarioRun retourne le scénario lancé sous cet ID, nil sinon ; b.mu doit être tenu
+func (b *apiBackend) scenarioRun(id string) *scenarioRun {
+	for _, run := range b.scenarios {
+		if run.view.ID == id {
+			return run
+		}
+	}
+	return nil
+}
+
+// scenarioView retourne l'état exposé d'un scénario terminé
+func scenarioView(scenario *entities.Scenario) api.Scenario {
+	view := api.Scenario{
+		ID:                scenario.ID,
+		Type:              string(scenario.Type),
+		Status:            string(scenario.Status),
+		StartedAt:         scenario.StartedAt,
+		TransactionHashes: append([]string{}, scenario.TransactionHashes...),
+		Errors:            append([]string{}, scenario.Errors...),
+		FailedTraces:      make([]string, 0, len(scenario.Traces)),
+	}
+	if !scenario.CompletedAt.IsZero() {
+		completedAt := scenario.CompletedAt
+		view.CompletedAt = &completedAt
+	}
+	for hash := range scenario.Traces {
+		view.FailedTraces = append(view.FailedTraces, hash)
+	}
+	sort.Strings(view.FailedTraces)
+	return view
+}
+
+// InjectFailure lance une panne temporaire en tâche de fond ; duration nulle applique la durée par défaut
+func (b *apiBackend) InjectFailure(ctx context.Context, nodeName string, duration time.Duration) (*api.Failure, error) {
+	manifest, err := b.Manifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := manifest.Node(nodeName); !ok {
+		return nil, fmt.Errorf("%w: unknown node '%s'", api.ErrNotFound, nodeName)
+	}
+	if _, err := b.ss.network.FailureTarget(nodeName); err != nil {
+		return nil, fmt.Errorf("%w: %v", api.ErrConflict, err)
+	}
+	if duration == 0 {
+		duration = DefaultFailureDuration
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ctx.Err() != nil {
+		return nil, fmt.Errorf("%w: server is stopping", api.ErrUnavailable)
+	}
+	if b.failingLocked(nodeName) {
+		return nil, fmt.Errorf("%w: a failure is already running on %s", api.ErrConflict, nodeName)
+	}
+
+	failure := &api.Failure{
+		ID:              fmt.Sprintf("failure-%d", len(b.failures)+1),
+		Node:            nodeName,
+		DurationSeconds: duration.Seconds(),
+		Status:          failureRunning,
+		StartedAt:       time.Now(),
+	}
+	b.failures = append(b.failures, failure)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		err := b.ss.network.TemporaryFailure(b.ctx, nodeName, duration)
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		completedAt := time.Now()
+		failure.CompletedAt = &completedAt
+		failure.Status = failureRecovered
+		if err != nil {
+			failure.Status = failureFailed
+			failure.Error = err.Error()
+		}
+	}()
+
+	view := *failure
+	return &view, nil
+}
+
+func (b *apiBackend) Failures(ctx context.Context) ([]api.Failure, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	failures := make([]api.Failure, 0, len(b.failures))
+	for _, failure := range b.failures {
+		failures = append(failures, *failure)
+	}
+	return failures, nil
+}
+
+// failing indique si une panne injectée est en cours sur le node
+func (b *apiBackend) failing(nodeName string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failingLocked(nodeName)
+}
+
+// failingLocked est failing quand b.mu est déjà tenu
+func (b *apiBackend) failingLocked(nodeName string) bool {
+	for _, failure := range b.failures {
+		if failure.Node == nodeName && failure.Status == failureRunning {
+			return true
+		}
+	}
+	return false
+}
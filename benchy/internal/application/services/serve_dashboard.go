@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"benchy/internal/infrastructure/config"
+	"benchy/internal/infrastructure/contracts"
+	"benchy/internal/infrastructure/dashboard"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Fenêtre de la courbe de progression de la tête de chaîne
+const dashboardHeadWindow = 10 * time.Minute
+
+// Nombre de blocs parcourus au plus à chaque tour pour les transactions récentes
+const dashboardScanBlocks = 20
+
+// Nombre de transactions récentes affichées
+const dashboardRecentTxs = 25
+
+// Dashboard démarre le collecteur du réseau lancé et sert sur addr une page web mise à
+// jour à chaque tour, jusqu'à l'annulation du contexte. updateInterval remplace
+// l'intervalle de la section monitoring.
+func (ss *ServeService) Dashboard(ctx context.Context, addr string, updateInterval int) error {
+	manifest, containers, err := ss.launchedNetwork(ctx)
+	if err != nil || manifest == nil {
+		return err
+	}
+
+	server := dashboard.NewServer()
+	web := endpoint{
+		addr:   addr,
+		routes: server.Routes,
+		ready: func(baseURL string) {
+			ss.feedback.Success(ctx, fmt.Sprintf("✅ Dashboard of network %s available on %s/", manifest.Network, baseURL))
+		},
+	}
+	publish := func(ctx context.Context) {
+		ss.publishDashboard(ctx, manifest, containers, server)
+	}
+	return ss.run(ctx, manifest, updateInterval, publish, web)
+}
+
+// publishDashboard publie l'état du réseau après chaque tour du collecteur
+func (ss *ServeService) publishDashboard(ctx context.Context, manifest *config.Manifest, containers []*ContainerInfo, server *dashboard.Server) {
+	feed := &transactionFeed{}
+	var since time.Time
+	for {
+		sampledAt, err := ss.monitoring.systemMonitor.WaitForSample(ctx, manifest.Network, since)
+		if err != nil {
+			return
+		}
+		since = sampledAt
+
+		snapshot, err := ss.dashboardSnapshot(ctx, manifest, containers, feed)
+		if err == nil {
+			err = server.Publish(snapshot)
+		}
+		if err != nil && ctx.Err() == nil {
+			ss.feedback.Warning(ctx, fmt.Sprintf("⚠️  Dashboard not updated: %v", err))
+		}
+	}
+}
+
+// dashboardSnapshot assemble l'état affiché à partir des métriques du collecteur,
+// comme benchy infos, et des dernières transactions minées
+func (ss *ServeService) dashboardSnapshot(ctx context.Context, manifest *config.Manifest, containers []*ContainerInfo, feed *transactionFeed) (*dashboard.Snapshot, error) {
+	monitor := ss.monitoring.systemMonitor
+	networkMetrics, err := monitor.GetNetworkMetrics(ctx, manifest.Network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network metrics: %w", err)
+	}
+
+	snapshot := &dashboard.Snapshot{
+		Network:     manifest.Network,
+		GeneratedAt: time.Now(),
+		Summary: dashboard.Summary{
+			TotalNodes:       networkMetrics.TotalNodes,
+			OnlineNodes:      networkMetrics.OnlineNodes,
+			Validators:       networkMetrics.ValidatorNodes,
+			HeadBlock:        networkMetrics.LatestBlock,
+			BlockTimeSeconds: networkMetrics.AvgBlockTime.Seconds(),
+			LatencyMs:        float64(networkMetrics.NetworkLatency) / float64(time.Millisecond),
+			ConsensusStatus:  networkMetrics.ConsensusStatus,
+			MissedBlocks:     networkMetrics.MissedBlocks,
+		},
+		Nodes:        make([]dashboard.Node, 0, len(containers)),
+		Head:         make([]dashboard.HeadPoint, 0),
+		Validators:   make([]dashboard.Validator, 0, len(networkMetrics.Validators)),
+		Alerts:       make([]dashboard.Alert, 0),
+		Transactions: make([]dashboard.Transaction, 0),
+	}
+
+	for i, container := range containers {
+		// Même lecture que benchy infos : métriques du collecteur et solde ETH
+		info, _ := ss.monitoring.getNodeInfo(ctx, container, nil)
+		node := dashboard.Node{
+			Name:          container.NodeName,
+			Client:        string(manifest.Nodes[i].Client),
+			Validator:     container.IsValidator,
+			Address:       container.Address.Hex(),
+			Container:     shortContainerID(container.ID),
+			ExpectedPeers: container.ExpectedPeers,
+			CPUPercent:    info.CPUUsage,
+			MemoryMB:      info.MemoryUsage,
+			BalanceETH:    info.ETHBalance,
+		}
+		if metrics, err := monitor.GetNodeMetrics(ctx, manifest.Network, container.NodeName); err == nil {
+			node.Online = metrics.IsOnline
+			node.Status = metrics.SyncStatus
+			node.HeadBlock = metrics.LatestBlock
+			node.Peers = metrics.ConnectedPeers
+			node.PendingTxs = metrics.PendingTxs
+			node.QueuedTxs = metrics.QueuedTxs
+			node.LatencyMs = float64(metrics.ResponseTime) / float64(time.Millisecond)
+		}
+		snapshot.Nodes = append(snapshot.Nodes, node)
+	}
+
+	if history, err := monitor.GetNetworkMetricsHistory(ctx, manifest.Network, dashboardHeadWindow); err == nil {
+		for _, metrics := range history {
+			if metrics.LatestBlock == 0 {
+				continue
+			}
+			// Un réseau relancé repart du genesis : seule la chaîne courante est tracée
+			if last := len(snapshot.Head) - 1; last >= 0 && metrics.LatestBlock < snapshot.Head[last].Block {
+				snapshot.Head = snapshot.Head[:0]
+			}
+			snapshot.Head = append(snapshot.Head, dashboard.HeadPoint{Time: metrics.Timestamp, Block: metrics.LatestBlock})
+		}
+	}
+
+	for _, validator := range networkMetrics.Validators {
+		snapshot.Validators = append(snapshot.Validators, dashboard.Validator{
+			Name:            validator.Name,
+			Address:         validator.Address.Hex(),
+			SealedBlocks:    validator.SealedBlocks,
+			InTurnPercent:   validator.InTurnRatio,
+			MissedTurns:     validator.MissedTurns,
+			LastSealedBlock: validator.LastSealedBlock,
+		})
+	}
+
+	if alerts, err := monitor.GetActiveAlerts(ctx, manifest.Network); err == nil {
+		for _, alert := range alerts {
+			snapshot.Alerts = append(snapshot.Alerts, dashboard.Alert{
+				Type:     string(alert.Type),
+				Severity: string(alert.Severity),
+				Node:     alert.NodeName,
+				Message:  alert.Message,
+				Since:    alert.Timestamp,
+			})
+		}
+	}
+
+	if node := ss.onlineNode(ctx, manifest); node != nil {
+		ss.scanTransactions(ctx, fmt.Sprintf("http://localhost:%d", node.RPCPort), networkMetrics.LatestBlock, feed)
+	}
+	snapshot.Transactions = append(snapshot.Transactions, feed.transactions...)
+
+	return snapshot, nil
+}
+
+// transactionFeed garde les dernières transactions minées, de la plus récente à la plus ancienne
+type transactionFeed struct {
+	scanned      uint64 // Dernier bloc parcouru
+	transactions []dashboard.Transaction
+}
+
+// scanTransactions ajoute au flux les transactions des blocs minés depuis le dernier tour.
+// Un bloc illisible sera relu au tour suivant.
+func (ss *ServeService) scanTransactions(ctx context.Context, nodeURL string, head uint64, feed *transactionFeed) {
+	from := feed.scanned + 1
+	if head >= dashboardScanBlocks && from <= head-dashboardScanBlocks {
+		from = head - dashboardScanBlocks + 1
+	}
+
+	ethClient := ss.monitoring.ethClient
+	label := newAddressLabeler(ss.monitoring.configManager.LoadAddressBook(), ss.monitoring.registry, ss.monitoring.network)
+	for number := from; number <= head; number++ {
+		block, err := ethClient.GetBlockByNumber(ctx, nodeURL, number)
+		if err != nil {
+			return
+		}
+
+		var mined []dashboard.Transaction
+		for _, txHash := range block.Transactions {
+			tx := dashboard.Transaction{
+				Hash:   txHash.Hex(),
+				Block:  block.Number,
+				Time:   time.Unix(int64(block.Timestamp), 0),
+				Status: "unknown",
+			}
+			if receipt, err := ethClient.GetTransactionReceipt(ctx, nodeURL, txHash); err == nil {
+				tx.From = label(receipt.From)
+				tx.To = recipientLabel(label, receipt.To, receipt.ContractAddress)
+				tx.GasUsed = receipt.GasUsed
+				tx.Status = "failed"
+				if receipt.Status == 1 {
+					tx.Status = "success"
+				}
+			}
+			mined = append(mined, tx)
+		}
+
+		// Les plus récentes en premier, y compris au sein d'un bloc
+		for i, j := 0, len(mined)-1; i < j; i, j = i+1, j-1 {
+			mined[i], mined[j] = mined[j], mined[i]
+		}
+		feed.transactions = append(mined, feed.transactions...)
+		if len(feed.transactions) > dashboardRecentTxs {
+			feed.transactions = feed.transactions[:dashboardRecentTxs]
+		}
+		feed.scanned = number
+	}
+}
+
+// recipientLabel nomme le destinataire d'une transaction ou le contrat qu'elle a créé
+func recipientLabel(label contracts.AddressLabeler, to, contractAddress common.Address) string {
+	if contractAddress != (common.Address{}) {
+		return "new contract " + label(contractAddress)
+	}
+	return label(to)
+}
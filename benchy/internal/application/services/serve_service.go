@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"benchy/internal/infrastructure/api"
+	"benchy/internal/infrastructure/config"
+	"benchy/internal/infrastructure/feedback"
+	"benchy/internal/infrastructure/monitoring"
+)
+
+// Nombre de blocs analysés pour les métriques de consensus exportées
+const serveConsensusBlocks = 100
+
+// Délai laissé aux requêtes en cours à l'arrêt du serveur
+const serveShutdownTimeout = 5 * time.Second
+
+// ServeService sert le réseau lancé en HTTP tant que benchy serve ou benchy dashboard
+// tourne, à partir du collecteur du service de monitoring
+type ServeService struct {
+	monitoring *MonitoringService
+	consensus  *ConsensusService
+	network    *NetworkService
+	scenarios  *ScenarioService
+	feedback   *feedback.ConsoleFeedback
+}
+
+// NewServeService crée le service de benchy serve à partir des services de monitoring
+// et de consensus, dont il partage le collecteur, et des services pilotés par l'API
+func NewServeService(monitoringService *MonitoringService, consensusService *ConsensusService, networkService *NetworkService, scenarioService *ScenarioService) *ServeService {
+	return &ServeService{
+		monitoring: monitoringService,
+		consensus:  consensusService,
+		network:    networkService,
+		scenarios:  scenarioService,
+		feedback:   feedback.NewConsoleFeedback(),
+	}
+}
+
+// endpoint est une adresse d'écoute et les routes qui y sont servies
+type endpoint struct {
+	addr   string
+	routes func(mux *http.ServeMux)
+	ready  func(baseURL string) // Annonce les URLs servies une fois à l'écoute
+}
+
+// Serve démarre le collecteur du réseau lancé et expose ses métriques au format
+// Prometheus sur metricsAddr et l'API REST sur apiAddr, jusqu'à l'annulation du
+// contexte. Une adresse vide désactive l'endpoint correspondant.
+func (ss *ServeService) Serve(ctx context.Context, metricsAddr, apiAddr string) error {
+	if metricsAddr == "" && apiAddr == "" {
+		return fmt.Errorf("nothing to serve: set --metrics-addr and/or --api-addr")
+	}
+
+	manifest, _, err := ss.launchedNetwork(ctx)
+	if err != nil || manifest == nil {
+		return err
+	}
+
+	var endpoints []endpoint
+	if metricsAddr != "" {
+		endpoints = append(endpoints, endpoint{
+			addr: metricsAddr,
+			routes: func(mux *http.ServeMux) {
+				mux.Handle("/metrics", monitoring.NewPrometheusExporter(ss.monitoring.systemMonitor, manifest.Network))
+			},
+			ready: func(baseURL string) {
+				ss.feedback.Success(ctx, fmt.Sprintf("✅ Serving Prometheus metrics of network %s on %s/metrics", manifest.Network, baseURL))
+			},
+		})
+	}
+
+	// Les scénarios et pannes lancés par l'API s'arrêtent avec le serveur
+	apiCtx, cancel := context.WithCancel(ctx)
+	backend := ss.newAPIBackend(apiCtx, manifest.Network)
+	defer backend.wait()
+	defer cancel()
+	if apiAddr != "" {
+		endpoints = append(endpoints, endpoint{
+			addr:   apiAddr,
+			routes: api.NewServer(backend).Routes,
+			ready: func(baseURL string) {
+				ss.feedback.Success(ctx, fmt.Sprintf("✅ Serving REST API of network %s on %s/api/v1 (schema: %s/api/v1/openapi.yaml)", manifest.Network, baseURL, baseURL))
+			},
+		})
+	}
+	return ss.run(ctx, manifest, 0, nil, endpoints...)
+}
+
+// launchedNetwork charge le manifest du réseau lancé et ses containers, nil s'il n'y en a pas
+func (ss *ServeService) launchedNetwork(ctx context.Context) (*config.Manifest, []*ContainerInfo, error) {
+	manifest, containers, err := ss.monitoring.getBenchyContainers(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get containers: %w", err)
+	}
+	if len(containers) == 0 {
+		ss.feedback.Warning(ctx, "⚠️  No benchy containers found. Did you run 'benchy launch-network'?")
+		return nil, nil, nil
+	}
+	return manifest, containers, nil
+}
+
+// run démarre le collecteur et le suivi du consensus du réseau, puis sert chaque endpoint
+// jusqu'à l'annulation du contexte. background tourne à côté et doit s'arrêter avec son contexte.
+func (ss *ServeService) run(ctx context.Context, manifest *config.Manifest, updateInterval int, background func(ctx context.Context), endpoints ...endpoint) error {
+	listeners := make([]net.Listener, 0, len(endpoints))
+	defer func() {
+		for _, listener := range listeners {
+			listener.Close()
+		}
+	}()
+	for _, e := range endpoints {
+		listener, err := net.Listen("tcp", e.addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", e.addr, err)
+		}
+		listeners = append(listeners, listener)
+	}
+
+	if err := ss.monitoring.startCollector(ctx, manifest, updateInterval); err != nil {
+		return err
+	}
+	defer ss.monitoring.stopCollector(ctx, manifest.Network)
+
+	// Les tâches de fond s'arrêtent avant le collecteur dont elles lisent les tours
+	runCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+	tasks := []func(context.Context){func(ctx context.Context) { ss.trackConsensus(ctx, manifest) }}
+	if background != nil {
+		tasks = append(tasks, background)
+	}
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(task func(context.Context)) {
+			defer wg.Done()
+			task(runCtx)
+		}(task)
+	}
+
+	servers := make([]*http.Server, len(endpoints))
+	served := make(chan error, len(endpoints))
+	for i, e := range endpoints {
+		mux := http.NewServeMux()
+		e.routes(mux)
+		servers[i] = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func(server *http.Server, listener net.Listener) {
+			served <- fmt.Errorf("HTTP server on %s stopped: %w", listener.Addr(), server.Serve(listener))
+		}(servers[i], listeners[i])
+		e.ready("http://" + displayAddr(listeners[i].Addr()))
+	}
+	ss.feedback.Info(ctx, "💡 Press Ctrl+C to stop")
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancelShutdown()
+	for _, server := range servers {
+		if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("failed to stop HTTP server: %w", err)
+		}
+	}
+	ss.feedback.Info(ctx, "👋 Server stopped")
+	return nil
+}
+
+// trackConsensus analyse les derniers blocs scellés à chaque nouvelle tête de chaîne,
+// pour exporter les blocs manqués et l'activité des validateurs. Le service de consensus
+// garde la fenêtre en mémoire : seuls les blocs scellés depuis l'analyse précédente sont lus.
+func (ss *ServeService) trackConsensus(ctx context.Context, manifest *config.Manifest) {
+	var since time.Time
+	var analyzedHead uint64
+	failing := false
+	for {
+		sampledAt, err := ss.monitoring.systemMonitor.WaitForSample(ctx, manifest.Network, since)
+		if err != nil {
+			return
+		}
+		since = sampledAt
+
+		networkMetrics, err := ss.monitoring.systemMonitor.GetNetworkMetrics(ctx, manifest.Network)
+		if err != nil || networkMetrics.OnlineNodes == 0 || networkMetrics.LatestBlock == analyzedHead {
+			continue
+		}
+
+		node := ss.onlineNode(ctx, manifest)
+		if node == nil {
+			continue
+		}
+
+		// L'analyse alimente le moniteur partagé, lu par l'exporter et l'API
+		_, err = ss.consensus.AnalyzeRecentBlocks(ctx, node.Name, serveConsensusBlocks)
+		if err != nil {
+			// Prévenir une seule fois tant que l'analyse échoue
+			if !failing && ctx.Err() == nil {
+				ss.feedback.Warning(ctx, fmt.Sprintf("⚠️  Consensus metrics unavailable: %v", err))
+			}
+			failing = true
+			continue
+		}
+		failing = false
+		analyzedHead = networkMetrics.LatestBlock
+	}
+}
+
+// onlineNode retourne le premier node qui a répondu au dernier tour du collecteur, nil sinon
+func (ss *ServeService) onlineNode(ctx context.Context, manifest *config.Manifest) *config.ManifestNode {
+	for i, node := range manifest.Nodes {
+		if metrics, err := ss.monitoring.systemMonitor.GetNodeMetrics(ctx, manifest.Network, node.Name); err == nil && metrics.IsOnline {
+			return &manifest.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// displayAddr retourne l'adresse d'écoute à afficher, localhost pour toutes les interfaces
+func displayAddr(addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"benchy/internal/infrastructure/contracts"
+	"benchy/internal/infrastructure/ethereum"
+	"benchy/internal/infrastructure/feedback"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenService gère les tokens ERC20 déployés sur le réseau
+type TokenService struct {
+	contracts *ContractService
+	feedback  *feedback.ConsoleFeedback
+}
+
+// NewTokenService crée un nouveau service de tokens
+func NewTokenService(baseDir string) (*TokenService, error) {
+	contractService, err := NewContractService(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenService{
+		contracts: contractService,
+		feedback:  feedback.NewConsoleFeedback(),
+	}, nil
+}
+
+// Longueur maximale du nom et du symbole du token embarqué, stockés comme chaînes courtes
+const maxTokenStringBytes = 31
+
+// DeployToken déploie le token ERC20 embarqué ; l'offre initiale revient à from
+func (ts *TokenService) DeployToken(ctx context.Context, name, symbol, supply, alias, from, nodeName string) error {
+	if alias == "" {
+		alias = strings.ToLower(symbol)
+	}
+
+	if _, err := ts.deployToken(ctx, name, symbol, supply, alias, from, nodeName, nil); err != nil {
+		return err
+	}
+
+	ts.feedback.Info(ctx, fmt.Sprintf("💡 Use 'benchy token balances %s' to see holders", alias))
+	return nil
+}
+
+// deployToken déploie le token ERC20 embarqué sous alias ; sent reçoit le hash de la
+// transaction dès sa diffusion
+func (ts *TokenService) deployToken(ctx context.Context, name, symbol, supply, alias, from, nodeName string, sent func(common.Hash)) (*contracts.Deployment, error) {
+	// Le constructeur annulerait la transaction : refuser avant de l'envoyer
+	if err := checkTokenString("name", name); err != nil {
+		return nil, err
+	}
+	if err := checkTokenString("symbol", symbol); err != nil {
+		return nil, err
+	}
+
+	return ts.contracts.deploy(ctx, contracts.ERC20ArtifactName, alias, []string{name, symbol, supply}, from, nodeName, sent)
+}
+
+// checkTokenString vérifie qu'un nom ou un symbole tient dans une chaîne courte
+func checkTokenString(field, value string) error {
+	if len(value) > maxTokenStringBytes {
+		return fmt.Errorf("token %s '%s' is %d bytes long: the embedded ERC20 stores at most %d bytes", field, value, len(value), maxTokenStringBytes)
+	}
+	return nil
+}
+
+// ShowBalances affiche les soldes d'un token pour chaque node du réseau
+func (ts *TokenService) ShowBalances(ctx context.Context, tokenRef, nodeName string) error {
+	nodeURL, err := nodeRPCURL(ts.contracts.configManager, nodeName)
+	if err != nil {
+		return err
+	}
+
+	deployment, err := ts.resolveToken(tokenRef)
+	if err != nil {
+		return err
+	}
+
+	info, err := ts.contracts.ethClient.GetTokenInfo(ctx, nodeURL, deployment.Address)
+	if err != nil {
+		return fmt.Errorf("failed to read token %s: %w", deployment.Name, err)
+	}
+
+	ts.feedback.Info(ctx, fmt.Sprintf("🪙 %s (%s) at %s", info.Name, info.Symbol, info.Address.Hex()))
+	ts.feedback.Info(ctx, fmt.Sprintf("   Total supply: %s %s", contracts.FormatUnits(info.TotalSupply, info.Decimals), info.Symbol))
+
+	book := ts.contracts.configManager.LoadAddressBook()
+	holders := make([]common.Address, 0, len(book))
+	for address := range book {
+		holders = append(holders, address)
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		return book[holders[i]] < book[holders[j]]
+	})
+
+	var rows [][]string
+	for _, holder := range holders {
+		balance, err := ts.contracts.ethClient.GetTokenBalance(ctx, nodeURL, deployment.Address, holder)
+		if err != nil {
+			rows = append(rows, []string{book[holder], holder.Hex(), "N/A", "N/A"})
+			continue
+		}
+		rows = append(rows, []string{
+			book[holder],
+			holder.Hex(),
+			fmt.Sprintf("%s %s", contracts.FormatUnits(balance, info.Decimals), info.Symbol),
+			fmt.Sprintf("%.2f%%", tokenShare(balance, info.TotalSupply)),
+		})
+	}
+	return ts.feedback.DisplayTable(ctx, []string{"Node", "Address", "Balance", "Share"}, rows)
+}
+
+// ShowAllowance affiche le montant que spender peut dépenser pour owner
+func (ts *TokenService) ShowAllowance(ctx context.Context, tokenRef, ownerRef, spenderRef, nodeName string) error {
+	nodeURL, err := nodeRPCURL(ts.contracts.configManager, nodeName)
+	if err != nil {
+		return err
+	}
+
+	deployment, err := ts.resolveToken(tokenRef)
+	if err != nil {
+		return err
+	}
+	owner, err := ts.resolveHolder(ownerRef)
+	if err != nil {
+		return err
+	}
+	spender, err := ts.resolveHolder(spenderRef)
+	if err != nil {
+		return err
+	}
+
+	info, err := ts.contracts.ethClient.GetTokenInfo(ctx, nodeURL, deployment.Address)
+	if err != nil {
+		return fmt.Errorf("failed to read token %s: %w", deployment.Name, err)
+	}
+
+	allowance, err := ts.contracts.ethClient.GetTokenAllowance(ctx, nodeURL, deployment.Address, owner, spender)
+	if err != nil {
+		return err
+	}
+
+	ts.feedback.Info(ctx, fmt.Sprintf("🔐 %s can spend %s %s on behalf of %s", spenderRef, contracts.FormatUnits(allowance, info.Decimals), info.Symbol, ownerRef))
+	return nil
+}
+
+// Transfer envoie des tokens de from vers to
+func (ts *TokenService) Transfer(ctx context.Context, tokenRef, toRef, amount, from, nodeName string) error {
+	deployment, to, value, err := ts.prepareTransfer(tokenRef, toRef, amount)
+	if err != nil {
+		return err
+	}
+
+	return ts.submit(ctx, nodeName, from, fmt.Sprintf("Transferring %s %s from %s to %s...", amount, deployment.Name, from, toRef),
+		func(nodeURL string, sender common.Address) (common.Hash, error) {
+			return ts.contracts.ethClient.TransferToken(ctx, nodeURL, deployment.Address, sender, to, value)
+		})
+}
+
+// Approve autorise spender à dépenser les tokens de from
+func (ts *TokenService) Approve(ctx context.Context, tokenRef, spenderRef, amount, from, nodeName string) error {
+	deployment, spender, value, err := ts.prepareTransfer(tokenRef, spenderRef, amount)
+	if err != nil {
+		return err
+	}
+
+	return ts.submit(ctx, nodeName, from, fmt.Sprintf("Approving %s to spend %s %s of %s...", spenderRef, amount, deployment.Name, from),
+		func(nodeURL string, owner common.Address) (common.Hash, error) {
+			return ts.contracts.ethClient.ApproveToken(ctx, nodeURL, deployment.Address, owner, spender, value)
+		})
+}
+
+// TransferFrom dépense l'allowance de from pour envoyer les tokens de owner vers to
+func (ts *TokenService) TransferFrom(ctx context.Context, tokenRef, ownerRef, toRef, amount, from, nodeName string) error {
+	deployment, to, value, err := ts.prepareTransfer(tokenRef, toRef, amount)
+	if err != nil {
+		return err
+	}
+	owner, err := ts.resolveHolder(ownerRef)
+	if err != nil {
+		return err
+	}
+
+	return ts.submit(ctx, nodeName, from, fmt.Sprintf("%s moves %s %s from %s to %s...", from, amount, deployment.Name, ownerRef, toRef),
+		func(nodeURL string, spender common.Address) (common.Hash, error) {
+			return ts.contracts.ethClient.TransferTokenFrom(ctx, nodeURL, deployment.Address, spender, owner, to, value)
+		})
+}
+
+// prepareTransfer résout le token, le destinataire et le montant d'une opération
+func (ts *TokenService) prepareTransfer(tokenRef, recipientRef, amount string) (*contracts.Deployment, common.Address, *big.Int, error) {
+	deployment, err := ts.resolveToken(tokenRef)
+	if err != nil {
+		return nil, common.Address{}, nil, err
+	}
+
+	recipient, err := ts.resolveHolder(recipientRef)
+	if err != nil {
+		return nil, common.Address{}, nil, err
+	}
+
+	value, err := contracts.ParseAmount(amount)
+	if err != nil {
+		return nil, common.Address{}, nil, err
+	}
+	if value.Sign() < 0 {
+		return nil, common.Address{}, nil, fmt.Errorf("invalid amount '%s': negative value", amount)
+	}
+
+	return deployment, recipient, value, nil
+}
+
+// submit signe une opération avec la clé de from puis attend sa confirmation
+func (ts *TokenService) submit(ctx context.Context, nodeName, from, message string, send func(nodeURL string, sender common.Address) (common.Hash, error)) error {
+	nodeURL, err := nodeRPCURL(ts.contracts.configManager, nodeName)
+	if err != nil {
+		return err
+	}
+
+	sender, err := ts.contracts.registerSigner(from)
+	if err != nil {
+		return err
+	}
+
+	spinner, err := ts.feedback.StartSpinner(ctx, message)
+	if err != nil {
+		return err
+	}
+
+	txHash, err := send(nodeURL, sender)
+	if err != nil {
+		spinner.Error("Transaction failed")
+		return err
+	}
+
+	receipt, err := ts.contracts.waitForReceipt(ctx, nodeURL, txHash)
+	if err != nil {
+		spinner.Error("Transaction not confirmed")
+		return err
+	}
+	if receipt.Status != 1 {
+		spinner.Error(fmt.Sprintf("Transaction %s reverted (insufficient balance or allowance?)", txHash.Hex()))
+		return fmt.Errorf("transaction reverted")
+	}
+
+	spinner.Success(fmt.Sprintf("Confirmed in block #%d (gas used: %d)", receipt.BlockNumber, receipt.GasUsed))
+	displayReceiptEvents(ctx, ts.feedback, ts.contracts.registry, ts.contracts.network, ts.contracts.configManager.LoadAddressBook(), receipt)
+	return nil
+}
+
+// resolveToken retrouve un token déployé par son nom ou son adresse
+func (ts *TokenService) resolveToken(tokenRef string) (*contracts.Deployment, error) {
+	deployment, err := ts.contracts.registry.Resolve(ts.contracts.network, tokenRef)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := ts.contracts.registry.Artifact(deployment.Artifact)
+	if err != nil {
+		return nil, err
+	}
+	if !artifact.IsERC20() {
+		return nil, fmt.Errorf("contract '%s' is not an ERC20 token", deployment.Name)
+	}
+	return deployment, nil
+}
+
+// resolveHolder convertit une adresse ou un nom de node/contrat en adresse
+func (ts *TokenService) resolveHolder(ref string) (common.Address, error) {
+	if common.IsHexAddress(ref) {
+		return common.HexToAddress(ref), nil
+	}
+	if address, ok := ts.contracts.resolveAddress(ref); ok {
+		return address, nil
+	}
+	return common.Address{}, fmt.Errorf("unknown address or name '%s'", ref)
+}
+
+// tokenShare calcule la part de l'offre totale détenue
+func tokenShare(balance, totalSupply *big.Int) float64 {
+	if totalSupply == nil || totalSupply.Sign() == 0 {
+		return 0
+	}
+	share, _ := new(big.Float).Quo(new(big.Float).SetInt(balance), new(big.Float).SetInt(totalSupply)).Float64()
+	return share * 100
+}
+
+// deployedToken associe un token déployé à ses métadonnées lues on-chain
+type deployedToken struct {
+	Deployment *contracts.Deployment
+	Info       *ethereum.TokenInfo
+}
+
+// loadTokenInfos lit les métadonnées des tokens déployés, ignorés s'ils sont injoignables
+func loadTokenInfos(ctx context.Context, ethClient *ethereum.EthereumClient, nodeURL string, deployments []*contracts.Deployment) []deployedToken {
+	var tokens []deployedToken
+	for _, deployment := range deployments {
+		info, err := ethClient.GetTokenInfo(ctx, nodeURL, deployment.Address)
+		if err != nil {
+			continue
+		}
+		tokens = append(tokens, deployedToken{Deployment: deployment, Info: info})
+	}
+	return tokens
+}
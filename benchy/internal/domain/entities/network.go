@@ -43,16 +43,36 @@ type Network struct {
 	StartedAt time.Time `json:"started_at"`
 }
 
-// NewNetwork crée un nouveau réseau avec la configuration par défaut
-func NewNetwork(name string, chainID *big.Int) *Network {
+// ChainParams regroupe les paramètres de consensus issus du genesis
+type ChainParams struct {
+	ChainID     *big.Int
+	BlockTime   time.Duration
+	EpochLength uint64
+}
+
+// NetworkLayout décrit où vit un réseau sur l'hôte Docker : son nom, son réseau Docker
+// et le préfixe des noms de ses containers
+type NetworkLayout struct {
+	Name            string
+	DockerNetwork   string
+	ContainerPrefix string
+}
+
+// ContainerName retourne le nom du container d'un node du réseau
+func (l NetworkLayout) ContainerName(node string) string {
+	return l.ContainerPrefix + node
+}
+
+// NewNetwork crée un nouveau réseau avec les paramètres de son genesis
+func NewNetwork(name string, chain ChainParams) *Network {
 	return &Network{
 		Name:        name,
-		ChainID:     chainID,
+		ChainID:     chain.ChainID,
 		Consensus:   "clique",
 		Status:      NetworkStatusStopped,
-		BlockTime:   5 * time.Second,
-		EpochLength: 30000,
-		NetworkID:   "benchy-network",
+		BlockTime:   chain.BlockTime,
+		EpochLength: chain.EpochLength,
+		NetworkID:   name,
 		Nodes:       make([]*Node, 0),
 		Validators:  make([]*Node, 0),
 		CreatedAt:   time.Now(),
@@ -68,6 +88,30 @@ func (n *Network) AddNode(node *Node) {
 	n.TotalNodes = len(n.Nodes)
 }
 
+// RemoveNode retire un node du réseau ; false s'il n'en fait pas partie
+func (n *Network) RemoveNode(name string) bool {
+	removed := false
+	nodes := n.Nodes[:0]
+	for _, node := range n.Nodes {
+		if node.Name == name {
+			removed = true
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	n.Nodes = nodes
+
+	validators := n.Validators[:0]
+	for _, validator := range n.Validators {
+		if validator.Name != name {
+			validators = append(validators, validator)
+		}
+	}
+	n.Validators = validators
+	n.TotalNodes = len(n.Nodes)
+	return removed
+}
+
 // GetNodeByName retourne un node par son nom
 func (n *Network) GetNodeByName(name string) *Node {
 	for _, node := range n.Nodes {
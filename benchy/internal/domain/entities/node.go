@@ -25,8 +25,23 @@ type ClientType string
 const (
 	ClientGeth       ClientType = "geth"
 	ClientNethermind ClientType = "nethermind"
+	ClientBesu       ClientType = "besu"
+	ClientErigon     ClientType = "erigon"
 )
 
+// ClientTypes liste les clients que benchy sait lancer
+var ClientTypes = []ClientType{ClientGeth, ClientNethermind, ClientBesu, ClientErigon}
+
+// IsKnown indique si le client fait partie de ClientTypes
+func (c ClientType) IsKnown() bool {
+	for _, client := range ClientTypes {
+		if c == client {
+			return true
+		}
+	}
+	return false
+}
+
 // Node représente un node Ethereum dans notre réseau
 type Node struct {
 	Name        string              `json:"name"`
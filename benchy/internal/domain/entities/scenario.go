@@ -2,6 +2,8 @@ package entities
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,10 +21,11 @@ const (
 type ScenarioStatus string
 
 const (
-	ScenarioStatusIdle    ScenarioStatus = "idle"
-	ScenarioStatusRunning ScenarioStatus = "running"
-	ScenarioStatusStopped ScenarioStatus = "stopped"
-	ScenarioStatusFailed  ScenarioStatus = "failed"
+	ScenarioStatusIdle      ScenarioStatus = "idle"
+	ScenarioStatusRunning   ScenarioStatus = "running"
+	ScenarioStatusStopped   ScenarioStatus = "stopped"
+	ScenarioStatusFailed    ScenarioStatus = "failed"
+	ScenarioStatusCancelled ScenarioStatus = "cancelled"
 )
 
 // Scenario représente un scénario de test
@@ -46,6 +49,7 @@ type Scenario struct {
 	// Résultats
 	TransactionHashes []string    `json:"transaction_hashes"`
 	Errors           []string    `json:"errors"`
+	Traces           map[string]*CallFrame `json:"traces,omitempty"` // Traces des transactions échouées
 	Metrics          interface{} `json:"metrics"`
 	
 	// Timestamps
@@ -88,11 +92,25 @@ func (s *Scenario) Fail(err error) {
 	s.Errors = append(s.Errors, err.Error())
 }
 
+// Cancel marque le scénario comme interrompu avant la fin
+func (s *Scenario) Cancel() {
+	s.Status = ScenarioStatusCancelled
+	s.CompletedAt = time.Now()
+}
+
 // AddTransactionHash ajoute un hash de transaction
 func (s *Scenario) AddTransactionHash(hash string) {
 	s.TransactionHashes = append(s.TransactionHashes, hash)
 }
 
+// AttachTrace associe la trace d'une transaction échouée au rapport
+func (s *Scenario) AttachTrace(hash string, trace *CallFrame) {
+	if s.Traces == nil {
+		s.Traces = make(map[string]*CallFrame)
+	}
+	s.Traces[hash] = trace
+}
+
 // UpdateProgress met à jour la progression
 func (s *Scenario) UpdateProgress(currentStep, totalSteps int) {
 	s.CurrentStep = currentStep
@@ -112,7 +130,10 @@ type ScenarioRunner interface {
 	Run(ctx context.Context, scenario *Scenario, network *Network) error
 }
 
+// Numéro des scénarios créés par le processus, pour distinguer ceux lancés dans la même seconde
+var scenarioSequence uint64
+
 // generateScenarioID génère un ID unique pour le scénario
 func generateScenarioID() string {
-	return "scenario-" + time.Now().Format("20060102-150405")
+	return fmt.Sprintf("scenario-%s-%d", time.Now().Format("20060102-150405"), atomic.AddUint64(&scenarioSequence, 1))
 }
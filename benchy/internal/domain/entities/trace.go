@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CallFrame représente un appel dans l'arbre d'exécution d'une transaction
+type CallFrame struct {
+	Type         string         `json:"type"` // CALL, STATICCALL, DELEGATECALL, CREATE...
+	From         common.Address `json:"from"`
+	To           common.Address `json:"to"`
+	Value        *big.Int       `json:"value,omitempty"`
+	Gas          uint64         `json:"gas"`
+	GasUsed      uint64         `json:"gas_used"`
+	Input        []byte         `json:"input,omitempty"`
+	Output       []byte         `json:"output,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revert_reason,omitempty"`
+	Calls        []*CallFrame   `json:"calls,omitempty"`
+}
+
+// Failed retourne true si l'appel a échoué
+func (f *CallFrame) Failed() bool {
+	return f.Error != ""
+}
+
+// FailureReason retourne la raison d'échec la plus profonde de l'arbre,
+// celle qui a provoqué le revert en cascade
+func (f *CallFrame) FailureReason() string {
+	for _, call := range f.Calls {
+		if reason := call.FailureReason(); reason != "" {
+			return reason
+		}
+	}
+	if f.RevertReason != "" {
+		return f.RevertReason
+	}
+	return f.Error
+}
@@ -40,7 +40,7 @@ type Transaction struct {
 	Value    *big.Int       `json:"value"`
 	Gas      uint64         `json:"gas"`
 	GasPrice *big.Int       `json:"gas_price"`
-	Nonce    uint64         `json:"nonce"`
+	Nonce    *uint64        `json:"nonce,omitempty"` // nil = prochain nonce de l'émetteur
 	Data     []byte         `json:"data"`
 	
 	// Informations de bloc
@@ -0,0 +1,99 @@
+package ports
+
+import (
+	"context"
+	"crypto/ecdsa"
+
+	"benchy/internal/domain/entities"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ClientAdapter isole tout ce qui diffère d'un client Ethereum à l'autre :
+// lancement du container et namespaces RPC non standard (admin, txpool, health, traces).
+// Le reste de benchy ne doit jamais tester le type de client.
+type ClientAdapter interface {
+	Type() entities.ClientType
+
+	// Lancement du container
+	Image() string
+	// Ligne de commande complète, exécutable compris : elle remplace l'entrypoint de l'image
+	Command(spec NodeLaunchSpec) []string
+	RPCModules() []string
+	ConfigPath() string                                         // Fichier de configuration dans le container
+	RenderConfig(spec NodeLaunchSpec) ([]byte, error)           // Contenu effectif de ce fichier
+	NodeKeyPath() string                                        // Clé P2P dans le container
+	RenderNodeKey(key *ecdsa.PrivateKey) []byte                 // Clé P2P au format lu par le client
+	RenderFiles(spec NodeLaunchSpec) (map[string][]byte, error) // Autres fichiers lus par le client, par chemin dans le container
+	SealsWithNodeKey() bool                                     // Le client signe les blocs Clique avec sa clé P2P
+	SupportsOnDemandSealing() bool                              // Le client accepte une période Clique de 0 (blocs à la demande)
+
+	// Appels RPC spécifiques au client
+	NodeInfo(ctx context.Context, nodeURL string) (*ClientNodeInfo, error)
+	Peers(ctx context.Context, nodeURL string) ([]PeerInfo, error)
+	AddPeer(ctx context.Context, nodeURL, enode string) error
+	RemovePeer(ctx context.Context, nodeURL, enode string) error
+	TxPoolStatus(ctx context.Context, nodeURL string) (*TxPoolStatus, error)
+	Health(ctx context.Context, nodeURL string) (*NodeHealth, error)
+	TraceTransaction(ctx context.Context, nodeURL string, txHash common.Hash) (*entities.CallFrame, error)
+	ProposeSigner(ctx context.Context, nodeURL string, address common.Address, authorize bool) error // Vote Clique
+	Signers(ctx context.Context, nodeURL string) ([]common.Address, error)                           // Validateurs Clique actuels
+	SignersAt(ctx context.Context, nodeURL string, block uint64) ([]common.Address, error)           // Validateurs Clique après un bloc
+}
+
+// ClientAdapterProvider retourne l'adapter d'un type de client
+type ClientAdapterProvider interface {
+	Adapter(client entities.ClientType) (ClientAdapter, error)
+}
+
+// RPCCaller exécute un appel JSON-RPC brut sur un node
+type RPCCaller interface {
+	Call(ctx context.Context, nodeURL string, result interface{}, method string, args ...interface{}) error
+}
+
+// NodeLaunchSpec décrit un node à lancer, indépendamment du client
+type NodeLaunchSpec struct {
+	Name        string
+	IsValidator bool
+	Etherbase   common.Address
+	NetworkID   uint64
+	P2PPort     int
+	RPCPort     int
+	WSPort      int
+	Initialized bool     // Le datadir contient déjà une chaîne initialisée
+	ExtraFlags  []string // Ajoutés tels quels à la fin de la commande
+	StaticPeers []string // URLs enode auxquelles se connecter dès le démarrage
+
+	// Surcharges fusionnées dans le fichier de configuration du client
+	ConfigOverrides map[string]interface{}
+}
+
+// ClientNodeInfo représente l'identité d'un node (admin_nodeInfo)
+type ClientNodeInfo struct {
+	Client     entities.ClientType
+	Version    string // web3_clientVersion
+	Enode      string
+	ID         string
+	IP         string
+	ListenAddr string
+}
+
+// PeerInfo représente un peer connecté
+type PeerInfo struct {
+	Enode         string
+	Name          string // Version du client distant
+	RemoteAddress string
+	Inbound       bool
+}
+
+// TxPoolStatus représente le contenu du txpool
+type TxPoolStatus struct {
+	Pending int
+	Queued  int
+}
+
+// NodeHealth représente l'état de santé d'un node
+type NodeHealth struct {
+	Healthy  bool
+	Syncing  bool
+	Messages []string
+}
@@ -43,6 +43,9 @@ type DockerService interface {
 	CreateNetwork(ctx context.Context, networkName string) error
 	RemoveNetwork(ctx context.Context, networkName string) error
 	ConnectToNetwork(ctx context.Context, containerID, networkName string) error
+
+	// Images
+	ImageExists(ctx context.Context, image string) (bool, error)
 }
 
 // ContainerConfig représente la configuration d'un container
@@ -52,9 +55,12 @@ type ContainerConfig struct {
 	Ports       map[string]string // host:container
 	Volumes     map[string]string // host:container
 	Environment []string
+	Entrypoint  []string // Vide : entrypoint de l'image
 	Command     []string
 	NetworkMode string
 	Labels      map[string]string
+	CPUs        float64 // 0 = illimité
+	MemoryBytes int64   // 0 = illimitée
 }
 
 // ContainerStats représente les statistiques d'un container
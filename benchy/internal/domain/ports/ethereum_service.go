@@ -28,6 +28,7 @@ type EthereumService interface {
 	SendTransaction(ctx context.Context, nodeURL string, tx *entities.Transaction) (common.Hash, error)
 	GetTransactionStatus(ctx context.Context, nodeURL string, txHash common.Hash) (entities.TransactionStatus, error)
 	GetTransactionReceipt(ctx context.Context, nodeURL string, txHash common.Hash) (*TransactionReceipt, error)
+	FilterLogs(ctx context.Context, nodeURL string, filter LogFilter) ([]LogEntry, error)
 	
 	// Smart contracts
 	DeployContract(ctx context.Context, nodeURL string, contractCode []byte, from common.Address) (common.Address, common.Hash, error)
@@ -35,7 +36,10 @@ type EthereumService interface {
 	
 	// ERC20 tokens
 	GetTokenBalance(ctx context.Context, nodeURL string, tokenAddress, holderAddress common.Address) (*big.Int, error)
+	GetTokenAllowance(ctx context.Context, nodeURL string, tokenAddress, owner, spender common.Address) (*big.Int, error)
 	TransferToken(ctx context.Context, nodeURL string, tokenAddress, from, to common.Address, amount *big.Int) (common.Hash, error)
+	ApproveToken(ctx context.Context, nodeURL string, tokenAddress, owner, spender common.Address, amount *big.Int) (common.Hash, error)
+	TransferTokenFrom(ctx context.Context, nodeURL string, tokenAddress, spender, from, to common.Address, amount *big.Int) (common.Hash, error)
 }
 
 // BlockInfo représente les informations d'un bloc
@@ -49,6 +53,8 @@ type BlockInfo struct {
 	GasUsed      uint64
 	Transactions []common.Hash
 	Miner        common.Address
+	ExtraData    []byte
+	Signer       common.Address // Signataire Clique récupéré depuis le sceau de l'extraData
 }
 
 // TransactionReceipt représente le reçu d'une transaction
@@ -67,7 +73,18 @@ type TransactionReceipt struct {
 
 // LogEntry représente un log d'événement
 type LogEntry struct {
-	Address common.Address
-	Topics  []common.Hash
-	Data    []byte
+	Address     common.Address
+	Topics      []common.Hash
+	Data        []byte
+	BlockNumber uint64
+	TxHash      common.Hash
+	Index       uint // Position du log dans le bloc
+}
+
+// LogFilter sélectionne des logs sur une plage de blocs (bornes incluses)
+type LogFilter struct {
+	FromBlock uint64
+	ToBlock   uint64
+	Addresses []common.Address
+	Topics    [][]common.Hash // Alternatives par position, nil = toutes
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 	"benchy/internal/domain/entities"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // MonitoringService définit les opérations de monitoring
@@ -11,7 +12,8 @@ type MonitoringService interface {
 	// Monitoring des nodes
 	StartMonitoring(ctx context.Context, network *entities.Network) error
 	StopMonitoring(ctx context.Context, networkName string) error
-	GetNodeMetrics(ctx context.Context, nodeName string) (*NodeMetrics, error)
+	WaitForSample(ctx context.Context, networkName string, since time.Time) (time.Time, error)
+	GetNodeMetrics(ctx context.Context, networkName, nodeName string) (*NodeMetrics, error)
 	GetNetworkMetrics(ctx context.Context, networkName string) (*NetworkMetrics, error)
 	
 	// Alertes
@@ -19,10 +21,11 @@ type MonitoringService interface {
 	GetActiveAlerts(ctx context.Context, networkName string) ([]*Alert, error)
 	
 	// Historique
-	GetMetricsHistory(ctx context.Context, nodeName string, duration time.Duration) ([]*NodeMetrics, error)
+	GetMetricsHistory(ctx context.Context, networkName, nodeName string, duration time.Duration) ([]*NodeMetrics, error)
+	GetNetworkMetricsHistory(ctx context.Context, networkName string, duration time.Duration) ([]*NetworkMetrics, error)
 	
 	// Health checks
-	CheckNodeHealth(ctx context.Context, node *entities.Node) (*HealthStatus, error)
+	CheckNodeHealth(ctx context.Context, networkName string, node *entities.Node) (*HealthStatus, error)
 	CheckNetworkHealth(ctx context.Context, network *entities.Network) (*HealthStatus, error)
 }
 
@@ -31,9 +34,11 @@ type NodeMetrics struct {
 	NodeName    string
 	Timestamp   time.Time
 	
-	// Métriques système
+	// Métriques du container
 	CPUUsage    float64
-	MemoryUsage float64
+	MemoryUsage float64 // Pourcentage de MemoryLimit, 0 sans limite
+	MemoryBytes uint64
+	MemoryLimit uint64 // 0 = illimitée
 	DiskUsage   float64
 	NetworkIO   NetworkIOMetrics
 	
@@ -41,12 +46,13 @@ type NodeMetrics struct {
 	LatestBlock     uint64
 	ConnectedPeers  int
 	PendingTxs      int
+	QueuedTxs       int
 	SyncStatus      string
 	
 	// Métriques performance
 	BlockTime       time.Duration
 	TxThroughput    float64
-	ResponseTime    time.Duration
+	ResponseTime    time.Duration // Latence de eth_blockNumber
 	
 	// Status
 	IsOnline        bool
@@ -74,6 +80,20 @@ type NetworkMetrics struct {
 	ConsensusStatus string
 	MissedBlocks    int
 	ForkCount       int
+	Validators      []ValidatorMetrics
+}
+
+// ValidatorMetrics représente l'activité de scellement d'un validateur
+type ValidatorMetrics struct {
+	Address         common.Address
+	Name            string
+	SealedBlocks    int
+	InTurnBlocks    int
+	OutOfTurnBlocks int
+	InTurnRatio     float64 // 0-100
+	MissedTurns     int     // Tours où un autre validateur a dû sceller à sa place
+	LongestGap      uint64  // Plus longue série de blocs consécutifs sans sceau de sa part
+	LastSealedBlock uint64
 }
 
 // NetworkIOMetrics représente les métriques réseau
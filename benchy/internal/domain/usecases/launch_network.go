@@ -3,7 +3,7 @@ package usecases
 import (
 	"context"
 	"fmt"
-	"math/big"
+	"strings"
 	"time"
 
 	"benchy/internal/domain/entities"
@@ -15,7 +15,11 @@ type LaunchNetworkUseCase struct {
 	networkRepo   ports.NetworkRepository
 	dockerService ports.DockerService
 	ethService    ports.EthereumService
+	clients       ports.ClientAdapterProvider
 	feedback      ports.FeedbackService
+	nodes         []*entities.Node       // Nodes décrits par la topologie
+	chain         entities.ChainParams   // Paramètres du genesis
+	layout        entities.NetworkLayout // Réseau choisi par --network
 }
 
 // NewLaunchNetworkUseCase crée une nouvelle instance
@@ -23,36 +27,50 @@ func NewLaunchNetworkUseCase(
 	networkRepo ports.NetworkRepository,
 	dockerService ports.DockerService,
 	ethService ports.EthereumService,
+	clients ports.ClientAdapterProvider,
 	feedback ports.FeedbackService,
+	nodes []*entities.Node,
+	chain entities.ChainParams,
+	layout entities.NetworkLayout,
 ) *LaunchNetworkUseCase {
 	return &LaunchNetworkUseCase{
 		networkRepo:   networkRepo,
 		dockerService: dockerService,
 		ethService:    ethService,
+		clients:       clients,
 		feedback:      feedback,
+		nodes:         nodes,
+		chain:         chain,
+		layout:        layout,
 	}
 }
 
 // Execute lance le réseau Ethereum
 func (uc *LaunchNetworkUseCase) Execute(ctx context.Context) error {
 	// 1. Créer le réseau
-	network := entities.NewNetwork("benchy-network", big.NewInt(1337))
+	network := entities.NewNetwork(uc.layout.Name, uc.chain)
 	
-	// 2. Ajouter les 5 nodes
+	// 2. Ajouter les nodes de la topologie
 	if err := uc.createNodes(network); err != nil {
 		return fmt.Errorf("failed to create nodes: %w", err)
 	}
 	
 	// 3. Feedback utilisateur
+	var names, validators []string
+	for _, node := range network.Nodes {
+		names = append(names, node.Name)
+		if node.IsValidator {
+			validators = append(validators, node.Name)
+		}
+	}
 	uc.feedback.Info(ctx, "🚀 Launching Ethereum network...")
 	uc.feedback.Info(ctx, "📋 Configuration:")
-	uc.feedback.Info(ctx, "   - 5 nodes: Alice, Bob, Cassandra, Driss, Elena")
-	uc.feedback.Info(ctx, "   - 3 validators: Alice, Bob, Cassandra")
-	uc.feedback.Info(ctx, "   - Clients: Geth + Nethermind")
+	uc.feedback.Info(ctx, fmt.Sprintf("   - %d nodes: %s", len(names), strings.Join(names, ", ")))
+	uc.feedback.Info(ctx, fmt.Sprintf("   - %d validators: %s", len(validators), strings.Join(validators, ", ")))
 	uc.feedback.Info(ctx, "   - Consensus: Clique")
 	
 	// 4. Créer le réseau Docker
-	if err := uc.dockerService.CreateNetwork(ctx, "benchy-network"); err != nil {
+	if err := uc.dockerService.CreateNetwork(ctx, uc.layout.DockerNetwork); err != nil {
 		return fmt.Errorf("failed to create docker network: %w", err)
 	}
 	
@@ -87,34 +105,13 @@ func (uc *LaunchNetworkUseCase) Execute(ctx context.Context) error {
 	return nil
 }
 
-// createNodes crée les 5 nodes avec leur configuration
+// createNodes ajoute au réseau les nodes de la topologie
 func (uc *LaunchNetworkUseCase) createNodes(network *entities.Network) error {
-	nodes := []struct {
-		name        string
-		isValidator bool
-		client      entities.ClientType
-		port        int
-		rpcPort     int
-	}{
-		{"alice", true, entities.ClientGeth, 30303, 8545},
-		{"bob", true, entities.ClientGeth, 30304, 8546},
-		{"cassandra", true, entities.ClientNethermind, 30305, 8547},
-		{"driss", false, entities.ClientGeth, 30306, 8548},
-		{"elena", false, entities.ClientNethermind, 30307, 8549},
+	if len(uc.nodes) == 0 {
+		return fmt.Errorf("topology has no nodes")
 	}
 	
-	for _, nodeConfig := range nodes {
-		node := entities.NewNode(
-			nodeConfig.name,
-			nodeConfig.isValidator,
-			nodeConfig.client,
-			nodeConfig.port,
-			nodeConfig.rpcPort,
-		)
-		
-		// Générer une clé privée pour ce node
-		// TODO: Implémenter la génération de clé
-		
+	for _, node := range uc.nodes {
 		network.AddNode(node)
 	}
 	
@@ -125,12 +122,12 @@ func (uc *LaunchNetworkUseCase) createNodes(network *entities.Network) error {
 func (uc *LaunchNetworkUseCase) launchNode(ctx context.Context, node *entities.Node) error {
 	// Configuration du container
 	config := ports.ContainerConfig{
-		Name:        fmt.Sprintf("benchy-%s", node.Name),
+		Name:        uc.layout.ContainerName(node.Name),
 		Ports:       map[string]string{
 			fmt.Sprintf("%d", node.Port):    fmt.Sprintf("%d", node.Port),
 			fmt.Sprintf("%d", node.RPCPort): fmt.Sprintf("%d", node.RPCPort),
 		},
-		NetworkMode: "benchy-network",
+		NetworkMode: uc.layout.DockerNetwork,
 		Labels: map[string]string{
 			"benchy.node.name":        node.Name,
 			"benchy.node.validator":   fmt.Sprintf("%t", node.IsValidator),
@@ -138,15 +135,21 @@ func (uc *LaunchNetworkUseCase) launchNode(ctx context.Context, node *entities.N
 		},
 	}
 	
-	// Choisir l'image Docker selon le client
-	switch node.Client {
-	case entities.ClientGeth:
-		config.Image = "ethereum/client-go:latest"
-		config.Command = uc.getGethCommand(node)
-	case entities.ClientNethermind:
-		config.Image = "nethermind/nethermind:latest"
-		config.Command = uc.getNethermindCommand(node)
+	// Image et commande fournies par l'adapter du client
+	adapter, err := uc.clients.Adapter(node.Client)
+	if err != nil {
+		return err
 	}
+	config.Image = adapter.Image()
+	config.Command = adapter.Command(ports.NodeLaunchSpec{
+		Name:        node.Name,
+		IsValidator: node.IsValidator,
+		Etherbase:   node.Address,
+		NetworkID:   uc.chain.ChainID.Uint64(),
+		P2PPort:     node.Port,
+		RPCPort:     node.RPCPort,
+		WSPort:      node.RPCPort + 1000,
+	})
 	
 	// Créer et démarrer le container
 	containerID, err := uc.dockerService.CreateContainer(ctx, node, config)
@@ -170,48 +173,6 @@ func (uc *LaunchNetworkUseCase) launchNode(ctx context.Context, node *entities.N
 	return nil
 }
 
-// getGethCommand retourne la commande pour Geth
-func (uc *LaunchNetworkUseCase) getGethCommand(node *entities.Node) []string {
-	cmd := []string{
-		"geth",
-		"--networkid", "1337",
-		"--datadir", "/data",
-		"--keystore", "/keystore",
-		"--port", fmt.Sprintf("%d", node.Port),
-		"--http",
-		"--http.addr", "0.0.0.0",
-		"--http.port", fmt.Sprintf("%d", node.RPCPort),
-		"--http.api", "eth,net,web3,personal,miner",
-		"--ws",
-		"--ws.addr", "0.0.0.0",
-		"--ws.port", fmt.Sprintf("%d", node.RPCPort+1000),
-		"--ws.api", "eth,net,web3,personal,miner",
-		"--allow-insecure-unlock",
-		"--nodiscover",
-		"--syncmode", "full",
-	}
-	
-	if node.IsValidator {
-		cmd = append(cmd, "--mine", "--miner.threads", "1")
-	}
-	
-	return cmd
-}
-
-// getNethermindCommand retourne la commande pour Nethermind
-func (uc *LaunchNetworkUseCase) getNethermindCommand(node *entities.Node) []string {
-	return []string{
-		"./Nethermind.Runner",
-		"--config", "mainnet",
-		"--datadir", "/data",
-		"--Network.DiscoveryPort", fmt.Sprintf("%d", node.Port),
-		"--Network.P2PPort", fmt.Sprintf("%d", node.Port),
-		"--JsonRpc.Enabled", "true",
-		"--JsonRpc.Host", "0.0.0.0",
-		"--JsonRpc.Port", fmt.Sprintf("%d", node.RPCPort),
-	}
-}
-
 // waitForNodeReady attend que le node soit prêt
 func (uc *LaunchNetworkUseCase) waitForNodeReady(ctx context.Context, node *entities.Node) error {
 	timeout := time.After(30 * time.Second)
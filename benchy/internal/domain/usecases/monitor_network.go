@@ -17,6 +17,7 @@ type MonitorNetworkUseCase struct {
 	ethService      ports.EthereumService
 	monitoringService ports.MonitoringService
 	feedback        ports.FeedbackService
+	networkName     string // Réseau choisi par --network
 }
 
 // NewMonitorNetworkUseCase crée une nouvelle instance
@@ -26,6 +27,7 @@ func NewMonitorNetworkUseCase(
 	ethService ports.EthereumService,
 	monitoringService ports.MonitoringService,
 	feedback ports.FeedbackService,
+	networkName string,
 ) *MonitorNetworkUseCase {
 	return &MonitorNetworkUseCase{
 		networkRepo:     networkRepo,
@@ -33,17 +35,27 @@ func NewMonitorNetworkUseCase(
 		ethService:      ethService,
 		monitoringService: monitoringService,
 		feedback:        feedback,
+		networkName:     networkName,
 	}
 }
 
 // Execute affiche les informations du réseau
 func (uc *MonitorNetworkUseCase) Execute(ctx context.Context, updateInterval int) error {
 	// Récupérer le réseau
-	network, err := uc.networkRepo.GetNetwork(ctx, "benchy-network")
+	network, err := uc.networkRepo.GetNetwork(ctx, uc.networkName)
 	if err != nil {
 		return fmt.Errorf("failed to get network: %w", err)
 	}
 	
+	// Les nodes sont échantillonnés en arrière-plan par le service de monitoring
+	if err := uc.monitoringService.StartMonitoring(ctx, network); err != nil {
+		return fmt.Errorf("failed to start monitoring: %w", err)
+	}
+	defer uc.monitoringService.StopMonitoring(context.Background(), network.Name)
+	if _, err := uc.monitoringService.WaitForSample(ctx, network.Name, time.Time{}); err != nil {
+		return fmt.Errorf("failed to sample nodes: %w", err)
+	}
+	
 	if updateInterval > 0 {
 		// Mode monitoring continu
 		return uc.continuousMonitoring(ctx, network, updateInterval)
@@ -83,7 +95,7 @@ func (uc *MonitorNetworkUseCase) displayNetworkInfo(ctx context.Context, network
 	headers := []string{"Node", "Status", "Latest Block", "Peers", "CPU/Memory", "ETH Balance", "Mempool"}
 	
 	for _, node := range network.Nodes {
-		nodeInfo, err := uc.getNodeInfo(ctx, node)
+		nodeInfo, err := uc.getNodeInfo(ctx, network.Name, node)
 		if err != nil {
 			// Node offline ou erreur
 			tableData = append(tableData, []string{
@@ -139,49 +151,28 @@ type NodeInfo struct {
 }
 
 // getNodeInfo récupère les informations d'un node
-func (uc *MonitorNetworkUseCase) getNodeInfo(ctx context.Context, node *entities.Node) (*NodeInfo, error) {
+func (uc *MonitorNetworkUseCase) getNodeInfo(ctx context.Context, networkName string, node *entities.Node) (*NodeInfo, error) {
 	info := &NodeInfo{
 		Name: node.Name,
 	}
 	
-	// Vérifier si le container est en cours d'exécution
-	if node.ContainerID != "" {
-		running, err := uc.dockerService.IsContainerRunning(ctx, node.ContainerID)
-		if err != nil || !running {
-			info.StatusDisplay = "❌ Offline"
-			return info, fmt.Errorf("container not running")
-		}
-		
-		// Récupérer les stats du container
-		stats, err := uc.dockerService.GetContainerStats(ctx, node.ContainerID)
-		if err == nil {
-			info.CPUUsage = stats.CPUUsage
-			info.MemoryUsage = float64(stats.MemoryUsage) / 1024 / 1024 // MB
-		}
-	}
-	
-	// Se connecter au node Ethereum
-	nodeURL := fmt.Sprintf("http://localhost:%d", node.RPCPort)
-	if err := uc.ethService.ConnectToNode(ctx, nodeURL); err != nil {
+	// Lire le dernier échantillon du collecteur (container, tête de chaîne, peers, txpool)
+	metrics, err := uc.monitoringService.GetNodeMetrics(ctx, networkName, node.Name)
+	if err != nil {
 		info.StatusDisplay = "❌ Offline"
-		return info, fmt.Errorf("failed to connect to ethereum node: %w", err)
+		return info, err
 	}
-	
-	// Récupérer les métriques blockchain
-	latestBlock, err := uc.ethService.GetLatestBlockNumber(ctx, nodeURL)
-	if err == nil {
-		info.LatestBlock = latestBlock
-	}
-	
-	peerCount, err := uc.ethService.GetPeerCount(ctx, nodeURL)
-	if err == nil {
-		info.PeerCount = peerCount
+	if !metrics.IsOnline {
+		info.StatusDisplay = "❌ Offline"
+		return info, fmt.Errorf("node %s is not reachable", node.Name)
 	}
+	info.CPUUsage = metrics.CPUUsage
+	info.MemoryUsage = float64(metrics.MemoryBytes) / 1024 / 1024 // MB
+	info.LatestBlock = metrics.LatestBlock
+	info.PeerCount = metrics.ConnectedPeers
+	info.PendingTxs = metrics.PendingTxs
 	
-	pendingTxs, err := uc.ethService.GetPendingTransactionCount(ctx, nodeURL)
-	if err == nil {
-		info.PendingTxs = pendingTxs
-	}
+	nodeURL := fmt.Sprintf("http://localhost:%d", node.RPCPort)
 	
 	// Récupérer la balance ETH
 	balance, err := uc.ethService.GetBalance(ctx, nodeURL, node.Address)
@@ -14,6 +14,7 @@ type SimulateFailureUseCase struct {
 	networkRepo   ports.NetworkRepository
 	dockerService ports.DockerService
 	feedback      ports.FeedbackService
+	networkName   string // Réseau choisi par --network
 }
 
 // NewSimulateFailureUseCase crée une nouvelle instance
@@ -21,18 +22,20 @@ func NewSimulateFailureUseCase(
 	networkRepo ports.NetworkRepository,
 	dockerService ports.DockerService,
 	feedback ports.FeedbackService,
+	networkName string,
 ) *SimulateFailureUseCase {
 	return &SimulateFailureUseCase{
 		networkRepo:   networkRepo,
 		dockerService: dockerService,
 		feedback:      feedback,
+		networkName:   networkName,
 	}
 }
 
 // Execute simule une panne temporaire du node spécifié
 func (uc *SimulateFailureUseCase) Execute(ctx context.Context, nodeName string) error {
 	// Récupérer le réseau
-	network, err := uc.networkRepo.GetNetwork(ctx, "benchy-network")
+	network, err := uc.networkRepo.GetNetwork(ctx, uc.networkName)
 	if err != nil {
 		return fmt.Errorf("failed to get network: %w", err)
 	}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Préfixe de toutes les routes, à changer avec la version du schéma
+const basePath = "/api/v1"
+
+// Taille maximale du corps d'une requête
+const maxBodyBytes = 1 << 16
+
+// Schéma OpenAPI de l'API, embarqué dans le binaire
+//
+//go:embed openapi.yaml
+var openAPISchema []byte
+
+// Server sert l'API REST de benchy serve au-dessus d'un backend
+type Server struct {
+	backend Backend
+}
+
+// NewServer crée le serveur de l'API
+func NewServer(backend Backend) *Server {
+	return &Server{backend: backend}
+}
+
+// Routes enregistre les routes de l'API sur mux
+func (s *Server) Routes(mux *http.ServeMux) {
+	mux.HandleFunc(basePath+"/openapi.yaml", s.handle(http.MethodGet, s.serveSchema))
+	mux.HandleFunc(basePath+"/network", s.handle(http.MethodGet, s.getManifest))
+	mux.HandleFunc(basePath+"/network/metrics", s.handle(http.MethodGet, s.getNetworkMetrics))
+	mux.HandleFunc(basePath+"/nodes", s.handle(http.MethodGet, s.getNodes))
+	mux.HandleFunc(basePath+"/nodes/", s.nodeRoutes)
+	mux.HandleFunc(basePath+"/failures", s.handle(http.MethodGet, s.getFailures))
+	mux.HandleFunc(basePath+"/scenarios", s.scenariosRoutes)
+	mux.HandleFunc(basePath+"/scenarios/", s.scenarioRoutes)
+	mux.HandleFunc(basePath+"/alerts", s.handle(http.MethodGet, s.getAlerts))
+	mux.HandleFunc(basePath+"/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, fmt.Errorf("%w: no route for %s", ErrNotFound, r.URL.Path))
+	})
+}
+
+// handle n'accepte que method sur la route
+func (s *Server) handle(method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			methodNotAllowed(w, method)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// nodeRoutes sert /nodes/{name} et /nodes/{name}/failure
+func (s *Server) nodeRoutes(w http.ResponseWriter, r *http.Request) {
+	name, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, basePath+"/nodes/"), "/")
+	switch {
+	case name == "":
+		writeError(w, fmt.Errorf("%w: missing node name", ErrNotFound))
+	case action == "":
+		s.handle(http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+			node, err := s.backend.Node(r.Context(), name)
+			writeResult(w, http.StatusOK, node, err)
+		})(w, r)
+	case action == "failure":
+		s.handle(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+			s.postFailure(w, r, name)
+		})(w, r)
+	default:
+		writeError(w, fmt.Errorf("%w: no route for %s", ErrNotFound, r.URL.Path))
+	}
+}
+
+// scenariosRoutes sert la liste des scénarios et le lancement d'un scénario
+func (s *Server) scenariosRoutes(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		scenarios, err := s.backend.Scenarios(r.Context())
+		writeResult(w, http.StatusOK, scenarios, err)
+	case http.MethodPost:
+		var request ScenarioRequest
+		if err := decodeBody(r, &request); err != nil {
+			writeError(w, err)
+			return
+		}
+		if request.Scenario == "" {
+			writeError(w, fmt.Errorf("%w: missing scenario", ErrInvalid))
+			return
+		}
+		scenario, err := s.backend.StartScenario(r.Context(), request.Scenario)
+		writeResult(w, http.StatusAccepted, scenario, err)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPost)
+	}
+}
+
+// scenarioRoutes sert /scenarios/{id} et /scenarios/{id}/stop
+func (s *Server) scenarioRoutes(w http.ResponseWriter, r *http.Request) {
+	id, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, basePath+"/scenarios/"), "/")
+	switch {
+	case id == "":
+		writeError(w, fmt.Errorf("%w: missing scenario id", ErrNotFound))
+	case action == "":
+		s.handle(http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+			scenario, err := s.backend.Scenario(r.Context(), id)
+			writeResult(w, http.StatusOK, scenario, err)
+		})(w, r)
+	case action == "stop":
+		s.handle(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+			scenario, err := s.backend.StopScenario(r.Context(), id)
+			writeResult(w, http.StatusOK, scenario, err)
+		})(w, r)
+	default:
+		writeError(w, fmt.Errorf("%w: no route for %s", ErrNotFound, r.URL.Path))
+	}
+}
+
+func (s *Server) serveSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(openAPISchema)
+}
+
+func (s *Server) getManifest(w http.ResponseWriter, r *http.Request) {
+	manifest, err := s.backend.Manifest(r.Context())
+	writeResult(w, http.StatusOK, manifest, err)
+}
+
+func (s *Server) getNetworkMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics, err := s.backend.NetworkMetrics(r.Context())
+	writeResult(w, http.StatusOK, metrics, err)
+}
+
+func (s *Server) getNodes(w http.ResponseWriter, r *http.Request) {
+	nodes, err := s.backend.Nodes(r.Context())
+	writeResult(w, http.StatusOK, nodes, err)
+}
+
+func (s *Server) getAlerts(w http.ResponseWriter, r *http.Request) {
+	alerts, err := s.backend.Alerts(r.Context())
+	writeResult(w, http.StatusOK, alerts, err)
+}
+
+func (s *Server) getFailures(w http.ResponseWriter, r *http.Request) {
+	failures, err := s.backend.Failures(r.Context())
+	writeResult(w, http.StatusOK, failures, err)
+}
+
+// postFailure injecte une panne temporaire ; sans corps, la durée par défaut du backend s'applique
+func (s *Server) postFailure(w http.ResponseWriter, r *http.Request, node string) {
+	var request FailureRequest
+	if err := decodeBody(r, &request); err != nil {
+		writeError(w, err)
+		return
+	}
+	if request.DurationSeconds < 0 {
+		writeError(w, fmt.Errorf("%w: duration_seconds must be positive", ErrInvalid))
+		return
+	}
+	duration := time.Duration(request.DurationSeconds * float64(time.Second))
+	failure, err := s.backend.InjectFailure(r.Context(), node, duration)
+	writeResult(w, http.StatusAccepted, failure, err)
+}
+
+// decodeBody lit le corps JSON d'une requête ; un corps vide laisse v inchangé
+func decodeBody(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(io.LimitReader(r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: malformed JSON body: %v", ErrInvalid, err)
+	}
+	return nil
+}
+
+// writeResult écrit v avec status, ou l'erreur du backend
+func writeResult(w http.ResponseWriter, status int, v interface{}, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, v)
+}
+
+// writeError traduit une erreur du backend en réponse JSON
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrInvalid):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, ErrUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, Error{Error: err.Error()})
+}
+
+func methodNotAllowed(w http.ResponseWriter, methods ...string) {
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeJSON(w, http.StatusMethodNotAllowed, Error{Error: "method not allowed, use " + strings.Join(methods, " or ")})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"benchy/internal/infrastructure/config"
+)
+
+// fakeBackend répond à toutes les requêtes avec err, ou des valeurs fixes, et retient
+// les arguments reçus
+type fakeBackend struct {
+	err error
+
+	node     string
+	duration time.Duration
+	scenario string
+}
+
+func (b *fakeBackend) Manifest(ctx context.Context) (*config.Manifest, error) {
+	return &config.Manifest{Network: "lab"}, b.err
+}
+
+func (b *fakeBackend) NetworkMetrics(ctx context.Context) (*NetworkMetrics, error) {
+	return &NetworkMetrics{Network: "lab", HeadBlock: 42}, b.err
+}
+
+func (b *fakeBackend) Nodes(ctx context.Context) ([]Node, error) {
+	return []Node{{Name: "alice"}, {Name: "bob"}}, b.err
+}
+
+func (b *fakeBackend) Node(ctx context.Context, name string) (*Node, error) {
+	b.node = name
+	return &Node{Name: name}, b.err
+}
+
+func (b *fakeBackend) Alerts(ctx context.Context) ([]Alert, error) {
+	return []Alert{}, b.err
+}
+
+func (b *fakeBackend) StartScenario(ctx context.Context, scenario string) (*Scenario, error) {
+	b.scenario = scenario
+	return &Scenario{ID: "s1", Type: scenario, Status: "running"}, b.err
+}
+
+func (b *fakeBackend) Scenarios(ctx context.Context) ([]Scenario, error) {
+	return []Scenario{{ID: "s1"}}, b.err
+}
+
+func (b *fakeBackend) Scenario(ctx context.Context, id string) (*Scenario, error) {
+	b.scenario = id
+	return &Scenario{ID: id}, b.err
+}
+
+func (b *fakeBackend) StopScenario(ctx context.Context, id string) (*Scenario, error) {
+	b.scenario = id
+	return &Scenario{ID: id, Status: "stopped"}, b.err
+}
+
+func (b *fakeBackend) InjectFailure(ctx context.Context, node string, duration time.Duration) (*Failure, error) {
+	b.node, b.duration = node, duration
+	return &Failure{ID: "f1", Node: node, DurationSeconds: duration.Seconds()}, b.err
+}
+
+func (b *fakeBackend) Failures(ctx context.Context) ([]Failure, error) {
+	return []Failure{}, b.err
+}
+
+func TestServerRoutes(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		err          error // Erreur retournée par le backend
+		wantStatus   int
+		wantAllow    string
+		wantBody     string // Fragment attendu dans la réponse
+		wantNode     string
+		wantDuration time.Duration
+		wantScenario string
+	}{
+		{name: "network", method: http.MethodGet, path: "/network", wantStatus: http.StatusOK, wantBody: `"network": "lab"`},
+		{name: "network metrics", method: http.MethodGet, path: "/network/metrics", wantStatus: http.StatusOK, wantBody: `"head_block": 42`},
+		{name: "nodes", method: http.MethodGet, path: "/nodes", wantStatus: http.StatusOK, wantBody: `"name": "bob"`},
+		{name: "node", method: http.MethodGet, path: "/nodes/alice", wantStatus: http.StatusOK, wantNode: "alice"},
+		{name: "alerts", method: http.MethodGet, path: "/alerts", wantStatus: http.StatusOK, wantBody: "[]"},
+		{name: "failures", method: http.MethodGet, path: "/failures", wantStatus: http.StatusOK, wantBody: "[]"},
+		{name: "schema", method: http.MethodGet, path: "/openapi.yaml", wantStatus: http.StatusOK, wantBody: "openapi:"},
+		{
+			name: "failure with duration", method: http.MethodPost, path: "/nodes/bob/failure", body: `{"duration_seconds": 1.5}`,
+			wantStatus: http.StatusAccepted, wantNode: "bob", wantDuration: 1500 * time.Millisecond,
+		},
+		{name: "failure without body", method: http.MethodPost, path: "/nodes/bob/failure", wantStatus: http.StatusAccepted, wantNode: "bob"},
+		{name: "negative failure duration", method: http.MethodPost, path: "/nodes/bob/failure", body: `{"duration_seconds": -1}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown body field", method: http.MethodPost, path: "/nodes/bob/failure", body: `{"seconds": 1}`, wantStatus: http.StatusBadRequest, wantBody: "malformed JSON body"},
+		{name: "start scenario", method: http.MethodPost, path: "/scenarios", body: `{"scenario": "transfers"}`, wantStatus: http.StatusAccepted, wantScenario: "transfers"},
+		{name: "start scenario without name", method: http.MethodPost, path: "/scenarios", body: `{}`, wantStatus: http.StatusBadRequest, wantBody: "missing scenario"},
+		{name: "scenarios", method: http.MethodGet, path: "/scenarios", wantStatus: http.StatusOK, wantBody: `"id": "s1"`},
+		{name: "scenario", method: http.MethodGet, path: "/scenarios/s1", wantStatus: http.StatusOK, wantScenario: "s1"},
+		{name: "stop scenario", method: http.MethodPost, path: "/scenarios/s1/stop", wantStatus: http.StatusOK, wantScenario: "s1", wantBody: `"status": "stopped"`},
+
+		{name: "wrong method", method: http.MethodPost, path: "/nodes", wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET"},
+		{name: "wrong method on node", method: http.MethodDelete, path: "/nodes/alice", wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET"},
+		{name: "get on failure", method: http.MethodGet, path: "/nodes/alice/failure", wantStatus: http.StatusMethodNotAllowed, wantAllow: "POST"},
+		{name: "wrong method on scenarios", method: http.MethodPut, path: "/scenarios", wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET, POST"},
+		{name: "unknown route", method: http.MethodGet, path: "/blocks", wantStatus: http.StatusNotFound, wantBody: "no route for"},
+		{name: "unknown node action", method: http.MethodPost, path: "/nodes/alice/restart", wantStatus: http.StatusNotFound},
+		{name: "missing node name", method: http.MethodGet, path: "/nodes/", wantStatus: http.StatusNotFound, wantBody: "missing node name"},
+		{name: "unknown scenario action", method: http.MethodPost, path: "/scenarios/s1/pause", wantStatus: http.StatusNotFound},
+
+		{name: "backend not found", method: http.MethodGet, path: "/nodes/zoe", err: fmt.Errorf("%w: unknown node 'zoe'", ErrNotFound), wantStatus: http.StatusNotFound, wantBody: "unknown node 'zoe'", wantNode: "zoe"},
+		{name: "backend invalid", method: http.MethodPost, path: "/scenarios", body: `{"scenario": "x"}`, err: fmt.Errorf("%w: unknown scenario", ErrInvalid), wantStatus: http.StatusBadRequest, wantScenario: "x"},
+		{name: "backend conflict", method: http.MethodPost, path: "/nodes/bob/failure", err: fmt.Errorf("%w: already failing", ErrConflict), wantStatus: http.StatusConflict, wantNode: "bob"},
+		{name: "backend unavailable", method: http.MethodGet, path: "/network/metrics", err: fmt.Errorf("%w: not monitored", ErrUnavailable), wantStatus: http.StatusServiceUnavailable},
+		{name: "backend failure", method: http.MethodGet, path: "/network", err: errors.New("disk full"), wantStatus: http.StatusInternalServerError, wantBody: `"error": "disk full"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &fakeBackend{err: tt.err}
+			mux := http.NewServeMux()
+			NewServer(backend).Routes(mux)
+
+			request := httptest.NewRequest(tt.method, basePath+tt.path, strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+			if tt.wantAllow != "" && recorder.Header().Get("Allow") != tt.wantAllow {
+				t.Errorf("Allow = %q, want %q", recorder.Header().Get("Allow"), tt.wantAllow)
+			}
+			if !strings.Contains(recorder.Body.String(), tt.wantBody) {
+				t.Errorf("body %s does not contain %s", recorder.Body, tt.wantBody)
+			}
+			if tt.wantStatus >= http.StatusBadRequest {
+				var body Error
+				if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil || body.Error == "" {
+					t.Errorf("error body %s is not an Error (%v)", recorder.Body, err)
+				}
+			}
+			if backend.node != tt.wantNode || backend.duration != tt.wantDuration || backend.scenario != tt.wantScenario {
+				t.Errorf("backend got node %q, duration %s, scenario %q; want %q, %s, %q",
+					backend.node, backend.duration, backend.scenario, tt.wantNode, tt.wantDuration, tt.wantScenario)
+			}
+		})
+	}
+}
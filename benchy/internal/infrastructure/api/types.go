@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"benchy/internal/infrastructure/config"
+)
+
+// Erreurs du backend traduites en codes HTTP
+var (
+	ErrNotFound    = errors.New("not found")       // 404
+	ErrInvalid     = errors.New("invalid request") // 400
+	ErrConflict    = errors.New("conflict")        // 409
+	ErrUnavailable = errors.New("unavailable")     // 503
+)
+
+// Backend exécute les requêtes de l'API sur le réseau servi
+type Backend interface {
+	Manifest(ctx context.Context) (*config.Manifest, error)
+	NetworkMetrics(ctx context.Context) (*NetworkMetrics, error)
+	Nodes(ctx context.Context) ([]Node, error)
+	Node(ctx context.Context, name string) (*Node, error)
+	Alerts(ctx context.Context) ([]Alert, error)
+
+	StartScenario(ctx context.Context, scenario string) (*Scenario, error)
+	Scenarios(ctx context.Context) ([]Scenario, error)
+	Scenario(ctx context.Context, id string) (*Scenario, error)
+	StopScenario(ctx context.Context, id string) (*Scenario, error)
+
+	InjectFailure(ctx context.Context, node string, duration time.Duration) (*Failure, error)
+	Failures(ctx context.Context) ([]Failure, error)
+}
+
+// NetworkMetrics résume les métriques agrégées du dernier tour du collecteur
+type NetworkMetrics struct {
+	Network          string      `json:"network"`
+	SampledAt        time.Time   `json:"sampled_at"`
+	TotalNodes       int         `json:"total_nodes"`
+	OnlineNodes      int         `json:"online_nodes"`
+	Validators       int         `json:"validators"`
+	HeadBlock        uint64      `json:"head_block"`
+	BlockTimeSeconds float64     `json:"block_time_seconds"`
+	LatencyMs        float64     `json:"latency_ms"`
+	ConsensusStatus  string      `json:"consensus_status"`
+	MissedBlocks     int         `json:"missed_blocks"`
+	Sealers          []Validator `json:"sealers"`
+}
+
+// Validator est l'activité de scellement d'un validateur sur les derniers blocs analysés
+type Validator struct {
+	Name            string  `json:"name"`
+	Address         string  `json:"address"`
+	SealedBlocks    int     `json:"sealed_blocks"`
+	InTurnPercent   float64 `json:"in_turn_percent"`
+	MissedTurns     int     `json:"missed_turns"`
+	LastSealedBlock uint64  `json:"last_sealed_block"`
+}
+
+// Node est l'état d'un node et ses métriques au dernier tour du collecteur
+type Node struct {
+	Name          string    `json:"name"`
+	Client        string    `json:"client"`
+	Validator     bool      `json:"validator"`
+	Address       string    `json:"address"`
+	RPCPort       int       `json:"rpc_port"`
+	Container     string    `json:"container"`
+	Online        bool      `json:"online"`
+	Status        string    `json:"status"`
+	HeadBlock     uint64    `json:"head_block"`
+	Peers         int       `json:"peers"`
+	ExpectedPeers int       `json:"expected_peers"`
+	PendingTxs    int       `json:"pending_txs"`
+	QueuedTxs     int       `json:"queued_txs"`
+	CPUPercent    float64   `json:"cpu_percent"`
+	MemoryBytes   uint64    `json:"memory_bytes"`
+	LatencyMs     float64   `json:"latency_ms"`
+	LastSeen      time.Time `json:"last_seen"`
+	Failing       bool      `json:"failing"` // Une panne injectée est en cours
+}
+
+// Alert est une alerte active du réseau
+type Alert struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	Severity string    `json:"severity"`
+	Node     string    `json:"node"`
+	Message  string    `json:"message"`
+	Since    time.Time `json:"since"`
+}
+
+// Scenario est un scénario lancé par l'API
+type Scenario struct {
+	ID                string     `json:"id"`
+	Type              string     `json:"type"`
+	Status            string     `json:"status"`
+	StartedAt         time.Time  `json:"started_at"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+	TransactionHashes []string   `json:"transaction_hashes"`
+	Errors            []string   `json:"errors"`
+	FailedTraces      []string   `json:"failed_traces"` // Transactions échouées tracées dans le rapport
+}
+
+// Failure est une panne temporaire injectée sur un node
+type Failure struct {
+	ID              string     `json:"id"`
+	Node            string     `json:"node"`
+	DurationSeconds float64    `json:"duration_seconds"`
+	Status          string     `json:"status"` // running, recovered ou failed
+	Error           string     `json:"error,omitempty"`
+	StartedAt       time.Time  `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+}
+
+// ScenarioRequest est le corps de POST /api/v1/scenarios
+type ScenarioRequest struct {
+	Scenario string `json:"scenario"`
+}
+
+// FailureRequest est le corps de POST /api/v1/nodes/{name}/failure
+type FailureRequest struct {
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// Error est le corps des réponses en erreur
+type Error struct {
+	Error string `json:"error"`
+}
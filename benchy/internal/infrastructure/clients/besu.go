@@ -0,0 +1,230 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// besuImage est la version de Besu lancée par benchy
+const besuImage = "hyperledger/besu:22.10.3"
+
+// besuAdapter parle le dialecte RPC de Besu
+type besuAdapter struct {
+	rpcBase
+}
+
+func init() {
+	register(entities.ClientBesu, func(caller ports.RPCCaller) ports.ClientAdapter {
+		return &besuAdapter{rpcBase{client: entities.ClientBesu, caller: caller}}
+	})
+}
+
+// Type retourne le type de client
+func (b *besuAdapter) Type() entities.ClientType {
+	return entities.ClientBesu
+}
+
+// Image retourne l'image Docker de Besu
+func (b *besuAdapter) Image() string {
+	return besuImage
+}
+
+// RPCModules retourne les APIs JSON-RPC activées (Besu n'a pas de module personal)
+func (b *besuAdapter) RPCModules() []string {
+	return []string{"ETH", "NET", "WEB3", "ADMIN", "DEBUG", "TXPOOL", "CLIQUE", "TRACE"}
+}
+
+// Chemins des fichiers lus par Besu dans le container
+const (
+	besuConfigPath      = "/besu.toml"
+	besuNodeKeyPath     = "/nodekey"
+	besuStaticNodesPath = "/data/static-nodes.json" // Besu ne lit les nodes statiques que dans son datadir
+)
+
+// ConfigPath retourne le chemin du fichier de configuration dans le container
+func (b *besuAdapter) ConfigPath() string {
+	return besuConfigPath
+}
+
+// RenderConfig génère le fichier TOML du node : les clés sont les options de la ligne de commande
+func (b *besuAdapter) RenderConfig(spec ports.NodeLaunchSpec) ([]byte, error) {
+	modules := b.RPCModules()
+	config := section{
+		"data-path":             "/data",
+		"genesis-file":          "/besu-genesis.json",
+		"network-id":            spec.NetworkID,
+		"node-private-key-file": besuNodeKeyPath,
+		"sync-mode":             "FULL",
+		"data-storage-format":   "FOREST", // Archive, comme les autres clients
+		"min-gas-price":         0,
+		"p2p-port":              spec.P2PPort,
+		"max-peers":             25,
+		"discovery-enabled":     false,
+		"rpc-http-enabled":      true,
+		"rpc-http-host":         "0.0.0.0",
+		"rpc-http-port":         spec.RPCPort,
+		"rpc-http-api":          modules,
+		"rpc-http-cors-origins": []string{"*"},
+		"host-allowlist":        []string{"*"},
+		"rpc-ws-enabled":        true,
+		"rpc-ws-host":           "0.0.0.0",
+		"rpc-ws-port":           spec.WSPort,
+		"rpc-ws-api":            modules,
+	}
+	if spec.IsValidator {
+		config["miner-enabled"] = true
+		config["miner-coinbase"] = spec.Etherbase.Hex()
+	}
+
+	mergeConfig(config, spec.ConfigOverrides)
+	return renderTOML(config)
+}
+
+// NodeKeyPath retourne le chemin de la clé P2P dans le container
+func (b *besuAdapter) NodeKeyPath() string {
+	return besuNodeKeyPath
+}
+
+// RenderNodeKey encode la clé P2P en hexadécimal préfixé, format de node-private-key-file
+func (b *besuAdapter) RenderNodeKey(key *ecdsa.PrivateKey) []byte {
+	return []byte(hexutil.Encode(crypto.FromECDSA(key)))
+}
+
+// RenderFiles retourne la liste des nodes statiques, que Besu ne lit que depuis un fichier
+func (b *besuAdapter) RenderFiles(spec ports.NodeLaunchSpec) (map[string][]byte, error) {
+	peers := spec.StaticPeers
+	if peers == nil {
+		peers = []string{}
+	}
+	data, err := json.MarshalIndent(peers, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render Besu static nodes: %w", err)
+	}
+	return map[string][]byte{besuStaticNodesPath: append(data, '\n')}, nil
+}
+
+// SealsWithNodeKey retourne true : Besu n'a pas de keystore et signe les blocs Clique avec sa clé P2P
+func (b *besuAdapter) SealsWithNodeKey() bool {
+	return true
+}
+
+// SupportsOnDemandSealing retourne false : Besu refuse une période Clique de 0 (blocs à la demande)
+func (b *besuAdapter) SupportsOnDemandSealing() bool {
+	return false
+}
+
+// Command construit la ligne de commande Besu ; le fichier TOML porte la configuration,
+// seule la résolution DNS des enodes (options cachées) reste en flags
+func (b *besuAdapter) Command(spec ports.NodeLaunchSpec) []string {
+	cmd := []string{
+		"besu",
+		"--config-file=" + besuConfigPath,
+		// Les enodes des nodes statiques désignent les containers par leur nom
+		"--Xdns-enabled=true",
+		"--Xdns-update-enabled=true",
+	}
+	return append(cmd, spec.ExtraFlags...)
+}
+
+// NodeInfo retourne l'identité du node
+func (b *besuAdapter) NodeInfo(ctx context.Context, nodeURL string) (*ports.ClientNodeInfo, error) {
+	return b.nodeInfo(ctx, nodeURL)
+}
+
+// Peers liste les peers via admin_peers (même format que geth)
+func (b *besuAdapter) Peers(ctx context.Context, nodeURL string) ([]ports.PeerInfo, error) {
+	return b.gethPeers(ctx, nodeURL)
+}
+
+// AddPeer connecte un peer statique
+func (b *besuAdapter) AddPeer(ctx context.Context, nodeURL, enode string) error {
+	var added bool
+	if err := b.call(ctx, nodeURL, &added, "admin_addPeer", enode); err != nil {
+		return err
+	}
+	if !added {
+		return fmt.Errorf("besu refused peer %s", enode)
+	}
+	return nil
+}
+
+// RemovePeer déconnecte un peer
+func (b *besuAdapter) RemovePeer(ctx context.Context, nodeURL, enode string) error {
+	var removed bool
+	return b.call(ctx, nodeURL, &removed, "admin_removePeer", enode)
+}
+
+// TxPoolStatus lit txpool_besuStatistics ; le pool de Besu n'a pas de file queued
+func (b *besuAdapter) TxPoolStatus(ctx context.Context, nodeURL string) (*ports.TxPoolStatus, error) {
+	var raw struct {
+		LocalCount  quantity `json:"localCount"`
+		RemoteCount quantity `json:"remoteCount"`
+	}
+	if err := b.call(ctx, nodeURL, &raw, "txpool_besuStatistics"); err != nil {
+		return nil, err
+	}
+	return &ports.TxPoolStatus{Pending: int(raw.LocalCount + raw.RemoteCount)}, nil
+}
+
+// Health interroge la sonde HTTP /readiness de Besu, servie sur le port RPC
+func (b *besuAdapter) Health(ctx context.Context, nodeURL string) (*ports.NodeHealth, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nodeURL+"/readiness?minPeers=0", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("besu readiness probe failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, fmt.Errorf("besu readiness probe failed: %s", resp.Status)
+	}
+
+	syncing, err := b.syncing(ctx, nodeURL)
+	if err != nil {
+		return nil, err
+	}
+	health := &ports.NodeHealth{Healthy: resp.StatusCode == http.StatusOK && !syncing, Syncing: syncing}
+	if resp.StatusCode != http.StatusOK {
+		health.Messages = append(health.Messages, "readiness probe is down")
+	}
+	if syncing {
+		health.Messages = append(health.Messages, "node is syncing")
+	}
+	return health, nil
+}
+
+// TraceTransaction récupère les traces à plat de trace_transaction : le debug_traceTransaction
+// de Besu n'a pas de callTracer
+func (b *besuAdapter) TraceTransaction(ctx context.Context, nodeURL string, txHash common.Hash) (*entities.CallFrame, error) {
+	var traces []parityTrace
+	if err := b.call(ctx, nodeURL, &traces, "trace_transaction", txHash); err != nil {
+		return nil, err
+	}
+	return buildCallTree(traces)
+}
+
+// ProposeSigner vote l'ajout ou le retrait d'un validateur via clique_propose
+func (b *besuAdapter) ProposeSigner(ctx context.Context, nodeURL string, address common.Address, authorize bool) error {
+	return b.cliquePropose(ctx, nodeURL, address, authorize)
+}
+
+// Signers lit clique_getSigners au dernier bloc
+func (b *besuAdapter) Signers(ctx context.Context, nodeURL string) ([]common.Address, error) {
+	return b.cliqueSigners(ctx, nodeURL, "latest")
+}
+
+// SignersAt lit clique_getSigners à un bloc donné
+func (b *besuAdapter) SignersAt(ctx context.Context, nodeURL string, block uint64) ([]common.Address, error) {
+	return b.cliqueSigners(ctx, nodeURL, hexutil.EncodeUint64(block))
+}
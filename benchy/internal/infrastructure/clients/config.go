@@ -0,0 +1,166 @@
+package clients
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// section est une section de fichier de configuration client (clé -> valeur ou sous-section)
+type section = map[string]interface{}
+
+// mergeConfig applique les surcharges de l'utilisateur sur la configuration générée.
+// Les clés sont comparées sans tenir compte de la casse pour garder celle du client.
+func mergeConfig(base, overrides section) {
+	for key, value := range overrides {
+		target := key
+		for existing := range base {
+			if strings.EqualFold(existing, key) {
+				target = existing
+				break
+			}
+		}
+
+		baseSection, baseIsSection := base[target].(section)
+		overrideSection, overrideIsSection := toSection(value)
+		if baseIsSection && overrideIsSection {
+			mergeConfig(baseSection, overrideSection)
+			continue
+		}
+		if overrideIsSection {
+			value = overrideSection
+		}
+		base[target] = value
+	}
+}
+
+// toSection convertit une section décodée depuis le YAML
+func toSection(value interface{}) (section, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		converted := make(section, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = item
+		}
+		return converted, true
+	}
+	return nil, false
+}
+
+// renderTOML sérialise une configuration au format TOML lu par geth --config
+func renderTOML(config section) ([]byte, error) {
+	var b strings.Builder
+	if err := writeTOMLSection(&b, "", config); err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimLeft(b.String(), "\n")), nil
+}
+
+// writeTOMLSection écrit les valeurs d'une section puis ses sous-sections
+func writeTOMLSection(b *strings.Builder, name string, config section) error {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if name != "" {
+		fmt.Fprintf(b, "\n[%s]\n", name)
+	}
+
+	var subsections []string
+	for _, key := range keys {
+		if _, isSection := toSection(config[key]); isSection {
+			subsections = append(subsections, key)
+			continue
+		}
+		value, err := tomlValue(config[key])
+		if err != nil {
+			return fmt.Errorf("%s: %w", strings.TrimPrefix(name+"."+key, "."), err)
+		}
+		fmt.Fprintf(b, "%s = %s\n", tomlKey(key), value)
+	}
+
+	for _, key := range subsections {
+		sub, _ := toSection(config[key])
+		path := key
+		if name != "" {
+			path = name + "." + key
+		}
+		if err := writeTOMLSection(b, path, sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tomlKey cite les clés qui ne sont pas des clés nues TOML, comme "http.addr" chez Erigon
+func tomlKey(key string) string {
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return strconv.Quote(key)
+		}
+	}
+	return key
+}
+
+// tomlValue formate une valeur scalaire ou une liste TOML
+func tomlValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int64, uint64, uint32, int32, uint:
+		return fmt.Sprint(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case fmt.Stringer:
+		return strconv.Quote(v.String()), nil
+	case []string:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = strconv.Quote(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			formatted, err := tomlValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = formatted
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	}
+	return "", fmt.Errorf("unsupported value %v (%T)", value, value)
+}
+
+// chainCommands enchaîne des lignes de commande dans un shell : chaque commande ne
+// s'exécute que si la précédente a réussi, la dernière remplace le shell (exec) pour
+// recevoir les signaux du container
+func chainCommands(commands ...[]string) []string {
+	lines := make([]string, len(commands))
+	for i, command := range commands {
+		quoted := make([]string, len(command))
+		for j, arg := range command {
+			quoted[j] = shellQuote(arg)
+		}
+		lines[i] = strings.Join(quoted, " ")
+	}
+	lines[len(lines)-1] = "exec " + lines[len(lines)-1]
+	return []string{"sh", "-c", strings.Join(lines, " && ")}
+}
+
+// shellQuote protège un argument pour sh, entre apostrophes s'il contient autre chose
+// que des caractères sûrs
+func shellQuote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=,@+") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{arg: "geth", want: "geth"},
+		{arg: "--datadir=/data", want: "--datadir=/data"},
+		{arg: "enode://ab@10.0.0.1:30303", want: "enode://ab@10.0.0.1:30303"},
+		{arg: "", want: "''"},
+		{arg: "two words", want: "'two words'"},
+		{arg: "$HOME", want: "'$HOME'"},
+		{arg: "a;rm -rf /", want: "'a;rm -rf /'"},
+		{arg: "it's", want: `'it'\''s'`},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.arg); got != tt.want {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestChainCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands [][]string
+		want     []string
+	}{
+		{
+			name:     "single command is exec'd",
+			commands: [][]string{{"geth", "--datadir", "/data"}},
+			want:     []string{"sh", "-c", "exec geth --datadir /data"},
+		},
+		{
+			name: "init then run",
+			commands: [][]string{
+				{"geth", "init", "--datadir", "/data", "/genesis.json"},
+				{"geth", "--config", "/config.toml"},
+			},
+			want: []string{"sh", "-c", "geth init --datadir /data /genesis.json && exec geth --config /config.toml"},
+		},
+		{
+			name: "arguments with spaces stay single words",
+			commands: [][]string{
+				{"erigon", "init", "--datadir", "/data dir", "/genesis.json"},
+				{"erigon", "--extradata", "it's benchy"},
+			},
+			want: []string{"sh", "-c", `erigon init --datadir '/data dir' /genesis.json && exec erigon --extradata 'it'\''s benchy'`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chainCommands(tt.commands...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chainCommands = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChainCommandsRunInShell(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	args := []string{"plain", "two words", "it's", "$HOME", ""}
+	argv := chainCommands([]string{"true"}, append([]string{"printf", "[%s]"}, args...))
+	output, err := exec.Command(argv[0], argv[1:]...).Output()
+	if err != nil {
+		t.Fatalf("%q: %v", argv, err)
+	}
+	if want := "[plain][two words][it's][$HOME][]"; string(output) != want {
+		t.Errorf("output = %s, want %s", output, want)
+	}
+}
@@ -0,0 +1,184 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// erigonImage est la version d'Erigon lancée par benchy
+const erigonImage = "thorax/erigon:v2.30.0"
+
+// erigonAdapter parle le dialecte RPC d'Erigon
+type erigonAdapter struct {
+	rpcBase
+}
+
+func init() {
+	register(entities.ClientErigon, func(caller ports.RPCCaller) ports.ClientAdapter {
+		return &erigonAdapter{rpcBase{client: entities.ClientErigon, caller: caller}}
+	})
+}
+
+// Type retourne le type de client
+func (e *erigonAdapter) Type() entities.ClientType {
+	return entities.ClientErigon
+}
+
+// Image retourne l'image Docker d'Erigon
+func (e *erigonAdapter) Image() string {
+	return erigonImage
+}
+
+// RPCModules retourne les namespaces du rpcdaemon intégré
+func (e *erigonAdapter) RPCModules() []string {
+	return []string{"eth", "erigon", "net", "web3", "admin", "debug", "trace", "txpool", "clique"}
+}
+
+// Chemins des fichiers lus par Erigon dans le container
+const (
+	erigonConfigPath  = "/erigon.toml"
+	erigonNodeKeyPath = "/nodekey"
+)
+
+// ConfigPath retourne le chemin du fichier de configuration dans le container
+func (e *erigonAdapter) ConfigPath() string {
+	return erigonConfigPath
+}
+
+// RenderConfig génère le fichier TOML du node : les clés sont les flags de la ligne de commande.
+// Erigon sert le WebSocket sur le port HTTP, le port WS du node n'est donc pas utilisé.
+func (e *erigonAdapter) RenderConfig(spec ports.NodeLaunchSpec) ([]byte, error) {
+	config := section{
+		"datadir":         "/data",
+		"networkid":       spec.NetworkID,
+		"nodekey":         erigonNodeKeyPath,
+		"port":            spec.P2PPort,
+		"maxpeers":        25,
+		"nodiscover":      true,
+		"http":            true,
+		"http.addr":       "0.0.0.0",
+		"http.port":       spec.RPCPort,
+		"http.api":        strings.Join(e.RPCModules(), ","),
+		"http.corsdomain": "*",
+		"http.vhosts":     "*",
+		"ws":              true,
+	}
+	if len(spec.StaticPeers) > 0 {
+		config["staticpeers"] = strings.Join(spec.StaticPeers, ",")
+	}
+	if spec.IsValidator {
+		config["mine"] = true
+		config["miner.etherbase"] = spec.Etherbase.Hex()
+		config["miner.sigfile"] = erigonNodeKeyPath // La clé P2P est celle du compte validateur
+	}
+
+	mergeConfig(config, spec.ConfigOverrides)
+	return renderTOML(config)
+}
+
+// NodeKeyPath retourne le chemin de la clé P2P dans le container
+func (e *erigonAdapter) NodeKeyPath() string {
+	return erigonNodeKeyPath
+}
+
+// RenderNodeKey encode la clé P2P en hexadécimal, format de --nodekey et --miner.sigfile
+func (e *erigonAdapter) RenderNodeKey(key *ecdsa.PrivateKey) []byte {
+	return []byte(hex.EncodeToString(crypto.FromECDSA(key)))
+}
+
+// RenderFiles ne retourne rien : Erigon lit tout dans son fichier TOML
+func (e *erigonAdapter) RenderFiles(spec ports.NodeLaunchSpec) (map[string][]byte, error) {
+	return nil, nil
+}
+
+// SealsWithNodeKey retourne true : Erigon n'a pas de keystore, sa clé P2P sert aussi de --miner.sigfile
+func (e *erigonAdapter) SealsWithNodeKey() bool {
+	return true
+}
+
+// SupportsOnDemandSealing retourne true : Erigon scelle un bloc dès qu'une transaction arrive avec une période de 0
+func (e *erigonAdapter) SupportsOnDemandSealing() bool {
+	return true
+}
+
+// Command construit la ligne de commande Erigon ; au premier démarrage le datadir
+// est initialisé avec le genesis geth, qu'Erigon lit tel quel
+func (e *erigonAdapter) Command(spec ports.NodeLaunchSpec) []string {
+	cmd := []string{"erigon", "--config", erigonConfigPath}
+	cmd = append(cmd, spec.ExtraFlags...)
+
+	if !spec.Initialized {
+		return chainCommands([]string{"erigon", "init", "--datadir", "/data", "/genesis.json"}, cmd)
+	}
+	return cmd
+}
+
+// NodeInfo retourne l'identité du node
+func (e *erigonAdapter) NodeInfo(ctx context.Context, nodeURL string) (*ports.ClientNodeInfo, error) {
+	return e.nodeInfo(ctx, nodeURL)
+}
+
+// Peers liste les peers via admin_peers (même format que geth)
+func (e *erigonAdapter) Peers(ctx context.Context, nodeURL string) ([]ports.PeerInfo, error) {
+	return e.gethPeers(ctx, nodeURL)
+}
+
+// AddPeer n'est pas disponible : le rpcdaemon d'Erigon n'expose pas admin_addPeer
+func (e *erigonAdapter) AddPeer(ctx context.Context, nodeURL, enode string) error {
+	return fmt.Errorf("erigon does not support admin_addPeer, peers are set with static peers at launch")
+}
+
+// RemovePeer n'est pas disponible : le rpcdaemon d'Erigon n'expose pas admin_removePeer
+func (e *erigonAdapter) RemovePeer(ctx context.Context, nodeURL, enode string) error {
+	return fmt.Errorf("erigon does not support admin_removePeer")
+}
+
+// TxPoolStatus lit txpool_status (compteurs hexadécimaux)
+func (e *erigonAdapter) TxPoolStatus(ctx context.Context, nodeURL string) (*ports.TxPoolStatus, error) {
+	var raw struct {
+		Pending quantity `json:"pending"`
+		Queued  quantity `json:"queued"`
+	}
+	if err := e.call(ctx, nodeURL, &raw, "txpool_status"); err != nil {
+		return nil, err
+	}
+	return &ports.TxPoolStatus{Pending: int(raw.Pending), Queued: int(raw.Queued)}, nil
+}
+
+// Health déduit la santé de net_listening et eth_syncing, comme pour geth
+func (e *erigonAdapter) Health(ctx context.Context, nodeURL string) (*ports.NodeHealth, error) {
+	return e.listeningHealth(ctx, nodeURL)
+}
+
+// TraceTransaction rejoue la transaction avec le callTracer, au même format que celui de geth
+func (e *erigonAdapter) TraceTransaction(ctx context.Context, nodeURL string, txHash common.Hash) (*entities.CallFrame, error) {
+	var raw gethCallFrame
+	if err := e.call(ctx, nodeURL, &raw, "debug_traceTransaction", txHash, map[string]string{"tracer": "callTracer"}); err != nil {
+		return nil, err
+	}
+	return raw.toCallFrame(), nil
+}
+
+// ProposeSigner n'est pas disponible : le rpcdaemon d'Erigon n'expose pas clique_propose
+func (e *erigonAdapter) ProposeSigner(ctx context.Context, nodeURL string, address common.Address, authorize bool) error {
+	return fmt.Errorf("erigon does not support clique_propose, vote from another validator")
+}
+
+// Signers lit clique_getSigners au dernier bloc
+func (e *erigonAdapter) Signers(ctx context.Context, nodeURL string) ([]common.Address, error) {
+	return e.cliqueSigners(ctx, nodeURL, "latest")
+}
+
+// SignersAt lit clique_getSigners à un bloc donné
+func (e *erigonAdapter) SignersAt(ctx context.Context, nodeURL string, block uint64) ([]common.Address, error) {
+	return e.cliqueSigners(ctx, nodeURL, hexutil.EncodeUint64(block))
+}
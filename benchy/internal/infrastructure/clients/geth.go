@@ -0,0 +1,215 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// gethImage est la version de geth lancée par benchy
+const gethImage = "ethereum/client-go:v1.10.26"
+
+// gethAdapter parle le dialecte RPC de geth
+type gethAdapter struct {
+	rpcBase
+}
+
+func init() {
+	register(entities.ClientGeth, func(caller ports.RPCCaller) ports.ClientAdapter {
+		return &gethAdapter{rpcBase{client: entities.ClientGeth, caller: caller}}
+	})
+}
+
+// Type retourne le type de client
+func (g *gethAdapter) Type() entities.ClientType {
+	return entities.ClientGeth
+}
+
+// Image retourne l'image Docker de geth
+func (g *gethAdapter) Image() string {
+	return gethImage
+}
+
+// RPCModules retourne les namespaces exposés en HTTP et WebSocket
+func (g *gethAdapter) RPCModules() []string {
+	return []string{"eth", "net", "web3", "personal", "miner", "admin", "debug", "txpool", "clique"}
+}
+
+// gethConfigPath est le chemin du fichier TOML dans le container
+const gethConfigPath = "/config.toml"
+
+// ConfigPath retourne le chemin du fichier de configuration dans le container
+func (g *gethAdapter) ConfigPath() string {
+	return gethConfigPath
+}
+
+// RenderConfig génère le fichier TOML du node, surcharges de l'utilisateur incluses
+func (g *gethAdapter) RenderConfig(spec ports.NodeLaunchSpec) ([]byte, error) {
+	modules := g.RPCModules()
+	config := section{
+		"Eth": section{
+			"NetworkId": spec.NetworkID,
+			"SyncMode":  "full",
+			"NoPruning": true, // gcmode archive
+		},
+		"Node": section{
+			"DataDir":               "/data",
+			"KeyStoreDir":           "/keystore",
+			"InsecureUnlockAllowed": true,
+			"HTTPHost":              "0.0.0.0",
+			"HTTPPort":              spec.RPCPort,
+			"HTTPCors":              []string{"*"},
+			"HTTPVirtualHosts":      []string{"*"},
+			"HTTPModules":           modules,
+			"WSHost":                "0.0.0.0",
+			"WSPort":                spec.WSPort,
+			"WSOrigins":             []string{"*"},
+			"WSModules":             modules,
+			"P2P": section{
+				"MaxPeers":    25,
+				"NoDiscovery": true,
+				"ListenAddr":  fmt.Sprintf(":%d", spec.P2PPort),
+			},
+		},
+	}
+	if len(spec.StaticPeers) > 0 {
+		config["Node"].(section)["P2P"].(section)["StaticNodes"] = spec.StaticPeers
+	}
+	if spec.IsValidator {
+		config["Eth"].(section)["Miner"] = section{"Etherbase": spec.Etherbase.Hex()}
+	}
+
+	mergeConfig(config, spec.ConfigOverrides)
+	return renderTOML(config)
+}
+
+// gethNodeKeyPath est le chemin de la clé P2P dans le container
+const gethNodeKeyPath = "/nodekey"
+
+// NodeKeyPath retourne le chemin de la clé P2P dans le container
+func (g *gethAdapter) NodeKeyPath() string {
+	return gethNodeKeyPath
+}
+
+// RenderNodeKey encode la clé P2P en hexadécimal, comme geth l'écrit lui-même
+func (g *gethAdapter) RenderNodeKey(key *ecdsa.PrivateKey) []byte {
+	return []byte(hex.EncodeToString(crypto.FromECDSA(key)))
+}
+
+// RenderFiles ne retourne rien : geth lit tout dans son fichier TOML
+func (g *gethAdapter) RenderFiles(spec ports.NodeLaunchSpec) (map[string][]byte, error) {
+	return nil, nil
+}
+
+// SealsWithNodeKey retourne false : geth scelle avec le compte déverrouillé de son keystore
+func (g *gethAdapter) SealsWithNodeKey() bool {
+	return false
+}
+
+// SupportsOnDemandSealing retourne true : geth scelle un bloc dès qu'une transaction arrive avec une période de 0
+func (g *gethAdapter) SupportsOnDemandSealing() bool {
+	return true
+}
+
+// Command construit la ligne de commande geth ; le reste de la configuration est
+// dans le fichier TOML, geth n'acceptant qu'en flags le minage, le déverrouillage et la clé P2P
+func (g *gethAdapter) Command(spec ports.NodeLaunchSpec) []string {
+	cmd := []string{
+		"geth",
+		"--config", gethConfigPath,
+		"--verbosity", "3",
+		"--nat", "extip:127.0.0.1",
+		"--nodekey", gethNodeKeyPath,
+		// Compte du node déverrouillé depuis son keystore V3 pour signer et sceller
+		"--unlock", spec.Etherbase.Hex(),
+		"--password", passwordFile,
+	}
+
+	if spec.IsValidator {
+		cmd = append(cmd, "--mine", "--miner.threads", "1")
+	}
+
+	cmd = append(cmd, spec.ExtraFlags...)
+
+	// Premier démarrage : initialiser le datadir avec le genesis avant de lancer le node
+	if !spec.Initialized {
+		return chainCommands([]string{"geth", "init", "--datadir", "/data", "/genesis.json"}, cmd)
+	}
+	return cmd
+}
+
+// NodeInfo retourne l'identité du node
+func (g *gethAdapter) NodeInfo(ctx context.Context, nodeURL string) (*ports.ClientNodeInfo, error) {
+	return g.nodeInfo(ctx, nodeURL)
+}
+
+// Peers liste les peers via admin_peers
+func (g *gethAdapter) Peers(ctx context.Context, nodeURL string) ([]ports.PeerInfo, error) {
+	return g.gethPeers(ctx, nodeURL)
+}
+
+// AddPeer connecte un peer statique
+func (g *gethAdapter) AddPeer(ctx context.Context, nodeURL, enode string) error {
+	var added bool
+	if err := g.call(ctx, nodeURL, &added, "admin_addPeer", enode); err != nil {
+		return err
+	}
+	if !added {
+		return fmt.Errorf("geth refused peer %s", enode)
+	}
+	return nil
+}
+
+// RemovePeer déconnecte un peer
+func (g *gethAdapter) RemovePeer(ctx context.Context, nodeURL, enode string) error {
+	var removed bool
+	return g.call(ctx, nodeURL, &removed, "admin_removePeer", enode)
+}
+
+// TxPoolStatus lit txpool_status (compteurs hexadécimaux)
+func (g *gethAdapter) TxPoolStatus(ctx context.Context, nodeURL string) (*ports.TxPoolStatus, error) {
+	var raw struct {
+		Pending quantity `json:"pending"`
+		Queued  quantity `json:"queued"`
+	}
+	if err := g.call(ctx, nodeURL, &raw, "txpool_status"); err != nil {
+		return nil, err
+	}
+	return &ports.TxPoolStatus{Pending: int(raw.Pending), Queued: int(raw.Queued)}, nil
+}
+
+// Health déduit la santé de net_listening et eth_syncing, geth n'ayant pas de module health
+func (g *gethAdapter) Health(ctx context.Context, nodeURL string) (*ports.NodeHealth, error) {
+	return g.listeningHealth(ctx, nodeURL)
+}
+
+// TraceTransaction rejoue la transaction avec le callTracer natif de geth
+func (g *gethAdapter) TraceTransaction(ctx context.Context, nodeURL string, txHash common.Hash) (*entities.CallFrame, error) {
+	var raw gethCallFrame
+	if err := g.call(ctx, nodeURL, &raw, "debug_traceTransaction", txHash, map[string]string{"tracer": "callTracer"}); err != nil {
+		return nil, err
+	}
+	return raw.toCallFrame(), nil
+}
+
+// ProposeSigner vote l'ajout ou le retrait d'un validateur via clique_propose
+func (g *gethAdapter) ProposeSigner(ctx context.Context, nodeURL string, address common.Address, authorize bool) error {
+	return g.cliquePropose(ctx, nodeURL, address, authorize)
+}
+
+// Signers lit clique_getSigners au dernier bloc
+func (g *gethAdapter) Signers(ctx context.Context, nodeURL string) ([]common.Address, error) {
+	return g.cliqueSigners(ctx, nodeURL, "latest")
+}
+
+// SignersAt lit clique_getSigners à un bloc donné
+func (g *gethAdapter) SignersAt(ctx context.Context, nodeURL string, block uint64) ([]common.Address, error) {
+	return g.cliqueSigners(ctx, nodeURL, hexutil.EncodeUint64(block))
+}
@@ -0,0 +1,240 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// nethermindImage est la version de Nethermind lancée par benchy
+const nethermindImage = "nethermind/nethermind:1.14.7"
+
+// nethermindAdapter parle le dialecte RPC de Nethermind
+type nethermindAdapter struct {
+	rpcBase
+}
+
+func init() {
+	register(entities.ClientNethermind, func(caller ports.RPCCaller) ports.ClientAdapter {
+		return &nethermindAdapter{rpcBase{client: entities.ClientNethermind, caller: caller}}
+	})
+}
+
+// Type retourne le type de client
+func (n *nethermindAdapter) Type() entities.ClientType {
+	return entities.ClientNethermind
+}
+
+// Image retourne l'image Docker de Nethermind
+func (n *nethermindAdapter) Image() string {
+	return nethermindImage
+}
+
+// RPCModules retourne les modules JSON-RPC activés (Nethermind n'a pas de module personal)
+func (n *nethermindAdapter) RPCModules() []string {
+	return []string{"Eth", "Subscribe", "Trace", "TxPool", "Web3", "Proof", "Net", "Parity", "Health", "Rpc", "Admin", "Debug", "Clique"}
+}
+
+// nethermindConfigPath est le chemin du fichier de configuration dans le container
+const nethermindConfigPath = "/nethermind.cfg"
+
+// ConfigPath retourne le chemin du fichier de configuration dans le container
+func (n *nethermindAdapter) ConfigPath() string {
+	return nethermindConfigPath
+}
+
+// RenderConfig génère le fichier JSON du node, qui remplace le preset mainnet :
+// chainspec benchy, synchronisation complète depuis le genesis, aucun service public
+func (n *nethermindAdapter) RenderConfig(spec ports.NodeLaunchSpec) ([]byte, error) {
+	config := section{
+		"Init": section{
+			"ChainSpecPath": "/chainspec.json",
+			"BaseDbPath":    "/data/nethermind_db",
+			"LogFileName":   "benchy.logs.txt",
+			"StoreReceipts": true,
+			"IsMining":      spec.IsValidator,
+		},
+		"Sync": section{
+			"FastSync":   false,
+			"FastBlocks": false,
+			"SnapSync":   false,
+		},
+		"Network": section{
+			"DiscoveryPort": spec.P2PPort,
+			"P2PPort":       spec.P2PPort,
+		},
+		"Discovery": section{
+			"Bootnodes": "",
+		},
+		"JsonRpc": section{
+			"Enabled":        true,
+			"Host":           "0.0.0.0",
+			"Port":           spec.RPCPort,
+			"WebSocketsPort": spec.WSPort,
+			"EnabledModules": n.RPCModules(),
+		},
+		"KeyStore": section{
+			"KeyStoreDirectory": "/keystore",
+			"PasswordFiles":     []string{passwordFile},
+			"UnlockAccounts":    []string{spec.Etherbase.Hex()},
+			"EnodeKeyFile":      nethermindNodeKeyPath,
+		},
+		"Pruning":  section{"Mode": "None"},
+		"EthStats": section{"Enabled": false},
+		"Metrics":  section{"Enabled": false},
+	}
+	if len(spec.StaticPeers) > 0 {
+		config["Network"].(section)["StaticPeers"] = strings.Join(spec.StaticPeers, ",")
+	}
+	if spec.IsValidator {
+		config["KeyStore"].(section)["BlockAuthorAccount"] = spec.Etherbase.Hex()
+	}
+
+	mergeConfig(config, spec.ConfigOverrides)
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render Nethermind config: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// nethermindNodeKeyPath est le chemin de la clé P2P dans le container
+const nethermindNodeKeyPath = "/node.key.plain"
+
+// NodeKeyPath retourne le chemin de la clé P2P dans le container
+func (n *nethermindAdapter) NodeKeyPath() string {
+	return nethermindNodeKeyPath
+}
+
+// RenderNodeKey retourne la clé P2P brute (32 octets), format de KeyStore.EnodeKeyFile
+func (n *nethermindAdapter) RenderNodeKey(key *ecdsa.PrivateKey) []byte {
+	return crypto.FromECDSA(key)
+}
+
+// RenderFiles ne retourne rien : Nethermind lit tout dans son fichier JSON
+func (n *nethermindAdapter) RenderFiles(spec ports.NodeLaunchSpec) (map[string][]byte, error) {
+	return nil, nil
+}
+
+// SealsWithNodeKey retourne false : Nethermind scelle avec le compte BlockAuthorAccount de son keystore
+func (n *nethermindAdapter) SealsWithNodeKey() bool {
+	return false
+}
+
+// SupportsOnDemandSealing retourne true : Nethermind scelle un bloc dès qu'une transaction arrive avec une période de 0
+func (n *nethermindAdapter) SupportsOnDemandSealing() bool {
+	return true
+}
+
+// Command construit la ligne de commande Nethermind ; toute la configuration est dans
+// le fichier JSON et le datadir est initialisé depuis la chainspec générée à partir du genesis geth
+func (n *nethermindAdapter) Command(spec ports.NodeLaunchSpec) []string {
+	cmd := []string{
+		"./Nethermind.Runner",
+		"--config", nethermindConfigPath,
+		"--datadir", "/data",
+	}
+	return append(cmd, spec.ExtraFlags...)
+}
+
+// NodeInfo retourne l'identité du node
+func (n *nethermindAdapter) NodeInfo(ctx context.Context, nodeURL string) (*ports.ClientNodeInfo, error) {
+	return n.nodeInfo(ctx, nodeURL)
+}
+
+// Peers liste les peers via admin_peers (format à plat propre à Nethermind)
+func (n *nethermindAdapter) Peers(ctx context.Context, nodeURL string) ([]ports.PeerInfo, error) {
+	var raw []struct {
+		Enode    string `json:"enode"`
+		ClientID string `json:"clientId"`
+		Address  string `json:"address"`
+	}
+	if err := n.call(ctx, nodeURL, &raw, "admin_peers", false); err != nil {
+		return nil, err
+	}
+
+	peers := make([]ports.PeerInfo, len(raw))
+	for i, peer := range raw {
+		peers[i] = ports.PeerInfo{
+			Enode:         peer.Enode,
+			Name:          peer.ClientID,
+			RemoteAddress: peer.Address,
+		}
+	}
+	return peers, nil
+}
+
+// AddPeer connecte un peer et l'enregistre comme node statique
+func (n *nethermindAdapter) AddPeer(ctx context.Context, nodeURL, enode string) error {
+	var added string
+	return n.call(ctx, nodeURL, &added, "admin_addPeer", enode, true)
+}
+
+// RemovePeer déconnecte un peer et le retire des nodes statiques
+func (n *nethermindAdapter) RemovePeer(ctx context.Context, nodeURL, enode string) error {
+	var removed string
+	return n.call(ctx, nodeURL, &removed, "admin_removePeer", enode, true)
+}
+
+// TxPoolStatus lit txpool_status (compteurs décimaux)
+func (n *nethermindAdapter) TxPoolStatus(ctx context.Context, nodeURL string) (*ports.TxPoolStatus, error) {
+	var raw struct {
+		Pending quantity `json:"pending"`
+		Queued  quantity `json:"queued"`
+	}
+	if err := n.call(ctx, nodeURL, &raw, "txpool_status"); err != nil {
+		return nil, err
+	}
+	return &ports.TxPoolStatus{Pending: int(raw.Pending), Queued: int(raw.Queued)}, nil
+}
+
+// Health interroge le module health de Nethermind
+func (n *nethermindAdapter) Health(ctx context.Context, nodeURL string) (*ports.NodeHealth, error) {
+	var raw struct {
+		Healthy  bool     `json:"healthy"`
+		Messages []string `json:"messages"`
+	}
+	if err := n.call(ctx, nodeURL, &raw, "health_nodeStatus"); err != nil {
+		return nil, err
+	}
+	syncing, err := n.syncing(ctx, nodeURL)
+	if err != nil {
+		return nil, err
+	}
+	return &ports.NodeHealth{Healthy: raw.Healthy, Syncing: syncing, Messages: raw.Messages}, nil
+}
+
+// TraceTransaction récupère les traces à plat de trace_transaction et reconstruit l'arbre
+func (n *nethermindAdapter) TraceTransaction(ctx context.Context, nodeURL string, txHash common.Hash) (*entities.CallFrame, error) {
+	var traces []parityTrace
+	if err := n.call(ctx, nodeURL, &traces, "trace_transaction", txHash); err != nil {
+		return nil, err
+	}
+	return buildCallTree(traces)
+}
+
+// ProposeSigner vote l'ajout ou le retrait d'un validateur via clique_propose
+func (n *nethermindAdapter) ProposeSigner(ctx context.Context, nodeURL string, address common.Address, authorize bool) error {
+	return n.cliquePropose(ctx, nodeURL, address, authorize)
+}
+
+// Signers lit clique_getSigners, qui ne prend pas de bloc en paramètre
+func (n *nethermindAdapter) Signers(ctx context.Context, nodeURL string) ([]common.Address, error) {
+	return n.cliqueSigners(ctx, nodeURL)
+}
+
+// SignersAt lit clique_getSignersAtNumber, l'équivalent Nethermind de clique_getSigners à un bloc
+func (n *nethermindAdapter) SignersAt(ctx context.Context, nodeURL string, block uint64) ([]common.Address, error) {
+	var signers []common.Address
+	if err := n.call(ctx, nodeURL, &signers, "clique_getSignersAtNumber", block); err != nil {
+		return nil, err
+	}
+	return signers, nil
+}
@@ -0,0 +1,44 @@
+package clients
+
+import (
+	"bytes"
+	"testing"
+
+	"benchy/internal/domain/entities"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testNodeKeyHex est une clé P2P de test, en hexadécimal sans préfixe
+const testNodeKeyHex = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestRenderNodeKey(t *testing.T) {
+	key, err := crypto.HexToECDSA(testNodeKeyHex)
+	if err != nil {
+		t.Fatalf("HexToECDSA: %v", err)
+	}
+
+	tests := []struct {
+		client   entities.ClientType
+		want     []byte
+		wantPath string
+	}{
+		{client: entities.ClientGeth, want: []byte(testNodeKeyHex), wantPath: gethNodeKeyPath},
+		{client: entities.ClientErigon, want: []byte(testNodeKeyHex), wantPath: erigonNodeKeyPath},
+		{client: entities.ClientBesu, want: []byte("0x" + testNodeKeyHex), wantPath: besuNodeKeyPath},
+		{client: entities.ClientNethermind, want: crypto.FromECDSA(key), wantPath: nethermindNodeKeyPath},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.client), func(t *testing.T) {
+			adapter, err := NewProvider(nil).Adapter(tt.client)
+			if err != nil {
+				t.Fatalf("Adapter: %v", err)
+			}
+			if got := adapter.RenderNodeKey(key); !bytes.Equal(got, tt.want) {
+				t.Errorf("RenderNodeKey = %q, want %q", got, tt.want)
+			}
+			if got := adapter.NodeKeyPath(); got != tt.wantPath {
+				t.Errorf("NodeKeyPath = %s, want %s", got, tt.wantPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// passwordFile est le chemin, dans le container, du mot de passe des keystores du réseau
+const passwordFile = "/password.txt"
+
+// factories associe chaque type de client à son constructeur d'adapter
+var factories = make(map[entities.ClientType]func(caller ports.RPCCaller) ports.ClientAdapter)
+
+// register déclare un adapter ; chaque client s'enregistre depuis son propre fichier
+func register(client entities.ClientType, factory func(caller ports.RPCCaller) ports.ClientAdapter) {
+	factories[client] = factory
+}
+
+// Provider fournit l'adapter correspondant au client d'un node
+type Provider struct {
+	caller ports.RPCCaller
+}
+
+var _ ports.ClientAdapterProvider = (*Provider)(nil)
+
+// NewProvider crée un provider dont les adapters passent par caller pour le RPC.
+// caller peut être nil si seules les informations de lancement sont utilisées.
+func NewProvider(caller ports.RPCCaller) *Provider {
+	return &Provider{caller: caller}
+}
+
+// Adapter retourne l'adapter d'un type de client
+func (p *Provider) Adapter(client entities.ClientType) (ports.ClientAdapter, error) {
+	factory, exists := factories[client]
+	if !exists {
+		return nil, fmt.Errorf("unsupported client '%s' (supported: %s)", client, strings.Join(Supported(), ", "))
+	}
+	return factory(p.caller), nil
+}
+
+// Supported liste les clients pour lesquels un adapter existe
+func Supported() []string {
+	names := make([]string, 0, len(factories))
+	for client := range factories {
+		names = append(names, string(client))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// rpcBase regroupe les appels identiques d'un client à l'autre
+type rpcBase struct {
+	client entities.ClientType
+	caller ports.RPCCaller
+}
+
+// call exécute un appel RPC, en échouant proprement si aucun caller n'est configuré
+func (b rpcBase) call(ctx context.Context, nodeURL string, result interface{}, method string, args ...interface{}) error {
+	if b.caller == nil {
+		return fmt.Errorf("no RPC caller configured for %s adapter", b.client)
+	}
+	if err := b.caller.Call(ctx, nodeURL, result, method, args...); err != nil {
+		return fmt.Errorf("%s %s failed: %w", b.client, method, err)
+	}
+	return nil
+}
+
+// nodeInfo lit admin_nodeInfo et web3_clientVersion (même format sur geth et Nethermind)
+func (b rpcBase) nodeInfo(ctx context.Context, nodeURL string) (*ports.ClientNodeInfo, error) {
+	var raw struct {
+		Enode      string `json:"enode"`
+		ID         string `json:"id"`
+		IP         string `json:"ip"`
+		ListenAddr string `json:"listenAddr"`
+	}
+	if err := b.call(ctx, nodeURL, &raw, "admin_nodeInfo"); err != nil {
+		return nil, err
+	}
+
+	info := &ports.ClientNodeInfo{
+		Client:     b.client,
+		Enode:      raw.Enode,
+		ID:         raw.ID,
+		IP:         raw.IP,
+		ListenAddr: raw.ListenAddr,
+	}
+	if err := b.call(ctx, nodeURL, &info.Version, "web3_clientVersion"); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// syncing indique si le node rattrape encore la chaîne (eth_syncing)
+func (b rpcBase) syncing(ctx context.Context, nodeURL string) (bool, error) {
+	var raw json.RawMessage
+	if err := b.call(ctx, nodeURL, &raw, "eth_syncing"); err != nil {
+		return false, err
+	}
+	return string(raw) != "false", nil
+}
+
+// gethPeers lit admin_peers au format de geth, repris par Erigon
+func (b rpcBase) gethPeers(ctx context.Context, nodeURL string) ([]ports.PeerInfo, error) {
+	var raw []struct {
+		Enode   string `json:"enode"`
+		Name    string `json:"name"`
+		Network struct {
+			RemoteAddress string `json:"remoteAddress"`
+			Inbound       bool   `json:"inbound"`
+		} `json:"network"`
+	}
+	if err := b.call(ctx, nodeURL, &raw, "admin_peers"); err != nil {
+		return nil, err
+	}
+
+	peers := make([]ports.PeerInfo, len(raw))
+	for i, peer := range raw {
+		peers[i] = ports.PeerInfo{
+			Enode:         peer.Enode,
+			Name:          peer.Name,
+			RemoteAddress: peer.Network.RemoteAddress,
+			Inbound:       peer.Network.Inbound,
+		}
+	}
+	return peers, nil
+}
+
+// listeningHealth déduit la santé de net_listening et eth_syncing, pour les clients sans module health
+func (b rpcBase) listeningHealth(ctx context.Context, nodeURL string) (*ports.NodeHealth, error) {
+	var listening bool
+	if err := b.call(ctx, nodeURL, &listening, "net_listening"); err != nil {
+		return nil, err
+	}
+	syncing, err := b.syncing(ctx, nodeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &ports.NodeHealth{Healthy: listening && !syncing, Syncing: syncing}
+	if !listening {
+		health.Messages = append(health.Messages, "p2p listener is down")
+	}
+	if syncing {
+		health.Messages = append(health.Messages, "node is syncing")
+	}
+	return health, nil
+}
+
+// quantity accepte un entier JSON ou une quantité hexadécimale ("0x1a")
+type quantity int
+
+// UnmarshalJSON décode les deux représentations
+func (q *quantity) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	value, err := strconv.ParseInt(text, 0, 64)
+	if err != nil {
+		return fmt.Errorf("invalid quantity %s: %w", data, err)
+	}
+	*q = quantity(value)
+	return nil
+}
+
+// cliquePropose vote l'ajout (authorize) ou le retrait d'un validateur, au prochain bloc scellé par le node
+func (b rpcBase) cliquePropose(ctx context.Context, nodeURL string, address common.Address, authorize bool) error {
+	var raw json.RawMessage // null pour geth, true pour Besu et Nethermind
+	return b.call(ctx, nodeURL, &raw, "clique_propose", address, authorize)
+}
+
+// cliqueSigners lit les validateurs Clique courants
+func (b rpcBase) cliqueSigners(ctx context.Context, nodeURL string, args ...interface{}) ([]common.Address, error) {
+	var signers []common.Address
+	if err := b.call(ctx, nodeURL, &signers, "clique_getSigners", args...); err != nil {
+		return nil, err
+	}
+	return signers, nil
+}
@@ -0,0 +1,135 @@
+package clients
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"benchy/internal/domain/entities"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// gethCallFrame est le format du callTracer de geth (arbre imbriqué)
+type gethCallFrame struct {
+	Type    string           `json:"type"`
+	From    common.Address   `json:"from"`
+	To      common.Address   `json:"to"`
+	Value   *hexutil.Big     `json:"value"`
+	Gas     hexutil.Uint64   `json:"gas"`
+	GasUsed hexutil.Uint64   `json:"gasUsed"`
+	Input   hexutil.Bytes    `json:"input"`
+	Output  hexutil.Bytes    `json:"output"`
+	Error   string           `json:"error"`
+	Calls   []*gethCallFrame `json:"calls"`
+}
+
+// toCallFrame convertit récursivement un frame du callTracer
+func (f *gethCallFrame) toCallFrame() *entities.CallFrame {
+	frame := &entities.CallFrame{
+		Type:    f.Type,
+		From:    f.From,
+		To:      f.To,
+		Value:   (*big.Int)(f.Value),
+		Gas:     uint64(f.Gas),
+		GasUsed: uint64(f.GasUsed),
+		Input:   f.Input,
+		Output:  f.Output,
+		Error:   f.Error,
+	}
+	if frame.Failed() {
+		frame.RevertReason = revertReason(frame.Output)
+	}
+	for _, call := range f.Calls {
+		frame.Calls = append(frame.Calls, call.toCallFrame())
+	}
+	return frame
+}
+
+// parityTrace est le format à plat de trace_transaction (Nethermind, OpenEthereum)
+type parityTrace struct {
+	Type   string `json:"type"` // call, create, suicide
+	Action struct {
+		CallType string         `json:"callType"`
+		From     common.Address `json:"from"`
+		To       common.Address `json:"to"`
+		Value    *hexutil.Big   `json:"value"`
+		Gas      hexutil.Uint64 `json:"gas"`
+		Input    hexutil.Bytes  `json:"input"`
+		Init     hexutil.Bytes  `json:"init"`
+	} `json:"action"`
+	Result *struct {
+		GasUsed hexutil.Uint64 `json:"gasUsed"`
+		Output  hexutil.Bytes  `json:"output"`
+		Address common.Address `json:"address"`
+	} `json:"result"`
+	Error        string `json:"error"`
+	TraceAddress []int  `json:"traceAddress"`
+}
+
+// buildCallTree reconstruit l'arbre d'appels à partir des traceAddress
+func buildCallTree(traces []parityTrace) (*entities.CallFrame, error) {
+	if len(traces) == 0 {
+		return nil, fmt.Errorf("empty trace")
+	}
+
+	frames := make(map[string]*entities.CallFrame, len(traces))
+	var root *entities.CallFrame
+	for _, trace := range traces {
+		frame := &entities.CallFrame{
+			Type:  strings.ToUpper(trace.Action.CallType),
+			From:  trace.Action.From,
+			To:    trace.Action.To,
+			Value: (*big.Int)(trace.Action.Value),
+			Gas:   uint64(trace.Action.Gas),
+			Input: trace.Action.Input,
+			Error: trace.Error,
+		}
+		if trace.Type != "call" {
+			frame.Type = strings.ToUpper(trace.Type)
+			frame.Input = trace.Action.Init
+		}
+		if trace.Result != nil {
+			frame.GasUsed = uint64(trace.Result.GasUsed)
+			frame.Output = trace.Result.Output
+			if trace.Type == "create" {
+				frame.To = trace.Result.Address
+			}
+		}
+		if frame.Failed() {
+			frame.RevertReason = revertReason(frame.Output)
+		}
+
+		key := traceKey(trace.TraceAddress)
+		frames[key] = frame
+		if len(trace.TraceAddress) == 0 {
+			root = frame
+			continue
+		}
+		parent, exists := frames[traceKey(trace.TraceAddress[:len(trace.TraceAddress)-1])]
+		if !exists {
+			return nil, fmt.Errorf("orphan trace at %v", trace.TraceAddress)
+		}
+		parent.Calls = append(parent.Calls, frame)
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("trace has no root call")
+	}
+	return root, nil
+}
+
+// traceKey sérialise une traceAddress pour indexer les frames
+func traceKey(address []int) string {
+	return fmt.Sprint(address)
+}
+
+// revertReason décode le message Error(string) renvoyé par un revert
+func revertReason(output []byte) string {
+	reason, err := abi.UnpackRevert(output)
+	if err != nil {
+		return ""
+	}
+	return reason
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// NewBesuGenesis convertit un genesis Clique geth au format de Besu : mêmes forks
+// et allocations, mais paramètres Clique renommés et base fee explicite
+func NewBesuGenesis(genesis *core.Genesis) (map[string]interface{}, error) {
+	if genesis.Config == nil || genesis.Config.Clique == nil {
+		return nil, fmt.Errorf("genesis is not a Clique genesis")
+	}
+
+	data, err := json.Marshal(genesis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis: %w", err)
+	}
+	var besuGenesis map[string]interface{}
+	if err := json.Unmarshal(data, &besuGenesis); err != nil {
+		return nil, fmt.Errorf("failed to convert genesis: %w", err)
+	}
+
+	chainConfig, ok := besuGenesis["config"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("genesis has no chain config")
+	}
+	chainConfig["clique"] = map[string]interface{}{
+		"blockperiodseconds": genesis.Config.Clique.Period,
+		"epochlength":        genesis.Config.Clique.Epoch,
+	}
+
+	// Besu refuse les champs nuls que geth écrit pour un genesis neuf
+	for key, value := range besuGenesis {
+		if value == nil {
+			delete(besuGenesis, key)
+		}
+	}
+	if genesis.Config.IsLondon(common.Big0) {
+		// Même base fee initiale que geth pour obtenir le même bloc genesis
+		baseFee := genesis.BaseFee
+		if baseFee == nil {
+			baseFee = new(big.Int).SetUint64(params.InitialBaseFee)
+		}
+		besuGenesis["baseFeePerGas"] = (*hexutil.Big)(baseFee)
+	}
+	return besuGenesis, nil
+}
+
+// SaveBesuGenesisToFile convertit le genesis au format Besu et l'écrit
+func (g *GenesisGenerator) SaveBesuGenesisToFile(genesis *core.Genesis, filePath string) error {
+	besuGenesis, err := NewBesuGenesis(genesis)
+	if err != nil {
+		return fmt.Errorf("failed to convert genesis for Besu: %w", err)
+	}
+	return writeJSONFile(besuGenesis, filePath)
+}
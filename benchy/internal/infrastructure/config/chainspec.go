@@ -0,0 +1,176 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// chainspecName est le nom de la chaîne dans la chainspec
+const chainspecName = "benchy"
+
+// Chainspec est la chainspec Nethermind/Parity équivalente au genesis geth
+type Chainspec struct {
+	Name     string                              `json:"name"`
+	Engine   chainspecEngine                     `json:"engine"`
+	Params   map[string]interface{}              `json:"params"`
+	Genesis  chainspecGenesis                    `json:"genesis"`
+	Accounts map[common.Address]chainspecAccount `json:"accounts"`
+}
+
+type chainspecEngine struct {
+	Clique struct {
+		Params struct {
+			Period      uint64       `json:"period"`
+			Epoch       uint64       `json:"epoch"`
+			BlockReward *hexutil.Big `json:"blockReward"`
+		} `json:"params"`
+	} `json:"clique"`
+}
+
+type chainspecGenesis struct {
+	Seal struct {
+		Ethereum struct {
+			Nonce   hexutil.Bytes `json:"nonce"`
+			MixHash hexutil.Bytes `json:"mixHash"`
+		} `json:"ethereum"`
+	} `json:"seal"`
+	Difficulty    *hexutil.Big   `json:"difficulty"`
+	Author        common.Address `json:"author"`
+	Timestamp     hexutil.Uint64 `json:"timestamp"`
+	ParentHash    common.Hash    `json:"parentHash"`
+	ExtraData     hexutil.Bytes  `json:"extraData"`
+	GasLimit      hexutil.Uint64 `json:"gasLimit"`
+	BaseFeePerGas *hexutil.Big   `json:"baseFeePerGas,omitempty"`
+}
+
+type chainspecAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Nonce   hexutil.Uint64              `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// chainspecTransitions associe chaque fork geth aux transitions EIP de la chainspec.
+// Muir, Arrow et Gray Glacier ne repoussent que la bombe de difficulté d'ethash : rien à traduire sous Clique.
+var chainspecTransitions = []struct {
+	block func(c *params.ChainConfig) *big.Int
+	eips  []string
+}{
+	{func(c *params.ChainConfig) *big.Int { return c.EIP150Block }, []string{"eip150"}},
+	{func(c *params.ChainConfig) *big.Int { return c.EIP155Block }, []string{"eip155"}},
+	{func(c *params.ChainConfig) *big.Int { return c.EIP158Block }, []string{"eip160", "eip161abc", "eip161d", "maxCodeSize"}},
+	{func(c *params.ChainConfig) *big.Int { return c.ByzantiumBlock }, []string{"eip140", "eip211", "eip214", "eip658"}},
+	{func(c *params.ChainConfig) *big.Int { return c.ConstantinopleBlock }, []string{"eip145", "eip1014", "eip1052", "eip1283"}},
+	{func(c *params.ChainConfig) *big.Int { return c.PetersburgBlock }, []string{"eip1283Disable"}},
+	{func(c *params.ChainConfig) *big.Int { return c.IstanbulBlock }, []string{"eip152", "eip1108", "eip1344", "eip1884", "eip2028", "eip2200"}},
+	{func(c *params.ChainConfig) *big.Int { return c.BerlinBlock }, []string{"eip2565", "eip2929", "eip2930"}},
+	{func(c *params.ChainConfig) *big.Int { return c.LondonBlock }, []string{"eip1559", "eip3198", "eip3529", "eip3541"}},
+}
+
+// NewChainspec convertit un genesis Clique geth en chainspec Nethermind
+func NewChainspec(genesis *core.Genesis) (*Chainspec, error) {
+	config := genesis.Config
+	if config == nil || config.Clique == nil {
+		return nil, fmt.Errorf("genesis is not a Clique genesis")
+	}
+
+	spec := &Chainspec{
+		Name:     chainspecName,
+		Accounts: make(map[common.Address]chainspecAccount),
+	}
+
+	// Moteur Clique
+	spec.Engine.Clique.Params.Period = config.Clique.Period
+	spec.Engine.Clique.Params.Epoch = config.Clique.Epoch
+	spec.Engine.Clique.Params.BlockReward = (*hexutil.Big)(new(big.Int))
+
+	// Paramètres de chaîne et transitions des forks
+	chainID := (*hexutil.Big)(config.ChainID)
+	spec.Params = map[string]interface{}{
+		"chainID":              chainID,
+		"networkID":            chainID,
+		"gasLimitBoundDivisor": hexutil.Uint64(params.GasLimitBoundDivisor),
+		"minGasLimit":          hexutil.Uint64(params.MinGasLimit),
+		"maximumExtraDataSize": hexutil.Uint64(0xffff), // L'extraData Clique contient les signataires
+		"accountStartNonce":    hexutil.Uint64(0),
+		"maxCodeSize":          hexutil.Uint64(params.MaxCodeSize),
+		"eip3607Transition":    hexutil.Uint64(0),
+	}
+	for _, transition := range chainspecTransitions {
+		block := transition.block(config)
+		if block == nil {
+			continue
+		}
+		for _, eip := range transition.eips {
+			spec.Params[eip+"Transition"] = (*hexutil.Big)(block)
+		}
+	}
+
+	// Bloc genesis
+	spec.Genesis.Seal.Ethereum.Nonce = common.LeftPadBytes(new(big.Int).SetUint64(genesis.Nonce).Bytes(), 8)
+	spec.Genesis.Seal.Ethereum.MixHash = genesis.Mixhash.Bytes()
+	spec.Genesis.Difficulty = (*hexutil.Big)(genesis.Difficulty)
+	spec.Genesis.Author = genesis.Coinbase
+	spec.Genesis.Timestamp = hexutil.Uint64(genesis.Timestamp)
+	spec.Genesis.ParentHash = genesis.ParentHash
+	spec.Genesis.ExtraData = genesis.ExtraData
+	spec.Genesis.GasLimit = hexutil.Uint64(genesis.GasLimit)
+	if config.IsLondon(common.Big0) {
+		// Même base fee initiale que geth pour obtenir le même bloc genesis
+		baseFee := genesis.BaseFee
+		if baseFee == nil {
+			baseFee = new(big.Int).SetUint64(params.InitialBaseFee)
+		}
+		spec.Genesis.BaseFeePerGas = (*hexutil.Big)(baseFee)
+	}
+
+	// Comptes
+	for address, account := range genesis.Alloc {
+		balance := account.Balance
+		if balance == nil {
+			balance = new(big.Int)
+		}
+		spec.Accounts[address] = chainspecAccount{
+			Balance: (*hexutil.Big)(balance),
+			Nonce:   hexutil.Uint64(account.Nonce),
+			Code:    account.Code,
+			Storage: account.Storage,
+		}
+	}
+
+	return spec, nil
+}
+
+// SaveChainspecToFile convertit le genesis en chainspec Nethermind et l'écrit
+func (g *GenesisGenerator) SaveChainspecToFile(genesis *core.Genesis, filePath string) error {
+	spec, err := NewChainspec(genesis)
+	if err != nil {
+		return fmt.Errorf("failed to convert genesis to chainspec: %w", err)
+	}
+	return writeJSONFile(spec, filePath)
+}
+
+// writeJSONFile écrit une valeur en JSON indenté, en créant le répertoire si nécessaire
+func writeJSONFile(value interface{}, filePath string) error {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(filePath), err)
+	}
+	if err := os.WriteFile(filePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// cliqueGenesis construit un genesis Clique dont London est activé au bloc london
+func cliqueGenesis(london *big.Int) *core.Genesis {
+	signer := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	extraData := append(make([]byte, 32), signer.Bytes()...)
+	extraData = append(extraData, make([]byte, 65)...)
+	return &core.Genesis{
+		Config: &params.ChainConfig{
+			ChainID:             big.NewInt(1337),
+			HomesteadBlock:      big.NewInt(0),
+			EIP150Block:         big.NewInt(0),
+			EIP155Block:         big.NewInt(0),
+			EIP158Block:         big.NewInt(0),
+			ByzantiumBlock:      big.NewInt(0),
+			ConstantinopleBlock: big.NewInt(0),
+			PetersburgBlock:     big.NewInt(0),
+			IstanbulBlock:       big.NewInt(0),
+			BerlinBlock:         big.NewInt(0),
+			LondonBlock:         london,
+			Clique:              &params.CliqueConfig{Period: 5, Epoch: 30000},
+		},
+		Nonce:      0,
+		Timestamp:  0x6000,
+		ExtraData:  extraData,
+		GasLimit:   30_000_000,
+		Difficulty: big.NewInt(1),
+		Alloc: core.GenesisAlloc{
+			signer: {Balance: big.NewInt(1e18)},
+		},
+	}
+}
+
+func TestNewChainspec(t *testing.T) {
+	tests := []struct {
+		name        string
+		london      *big.Int
+		wantLondon  *big.Int // nil : aucune transition London
+		wantBaseFee *big.Int // nil : pas de baseFeePerGas dans le genesis
+	}{
+		{
+			name:        "London at genesis",
+			london:      big.NewInt(0),
+			wantLondon:  big.NewInt(0),
+			wantBaseFee: big.NewInt(params.InitialBaseFee),
+		},
+		{
+			name:       "London at block 100",
+			london:     big.NewInt(100),
+			wantLondon: big.NewInt(100),
+		},
+		{
+			name: "London disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			genesis := cliqueGenesis(tt.london)
+			spec, err := NewChainspec(genesis)
+			if err != nil {
+				t.Fatalf("NewChainspec: %v", err)
+			}
+
+			for _, eip := range []string{"eip1559", "eip3198", "eip3529", "eip3541"} {
+				value, exists := spec.Params[eip+"Transition"]
+				if tt.wantLondon == nil {
+					if exists {
+						t.Errorf("%sTransition = %v, want absent", eip, value)
+					}
+					continue
+				}
+				block, ok := value.(*hexutil.Big)
+				if !ok || (*big.Int)(block).Cmp(tt.wantLondon) != 0 {
+					t.Errorf("%sTransition = %v, want %v", eip, value, tt.wantLondon)
+				}
+			}
+			if block, ok := spec.Params["eip2929Transition"].(*hexutil.Big); !ok || block.ToInt().Sign() != 0 {
+				t.Errorf("eip2929Transition = %v, want 0", spec.Params["eip2929Transition"])
+			}
+
+			switch {
+			case tt.wantBaseFee == nil && spec.Genesis.BaseFeePerGas != nil:
+				t.Errorf("baseFeePerGas = %v, want absent", spec.Genesis.BaseFeePerGas)
+			case tt.wantBaseFee != nil && (spec.Genesis.BaseFeePerGas == nil || spec.Genesis.BaseFeePerGas.ToInt().Cmp(tt.wantBaseFee) != 0):
+				t.Errorf("baseFeePerGas = %v, want %v", spec.Genesis.BaseFeePerGas, tt.wantBaseFee)
+			}
+
+			if spec.Engine.Clique.Params.Period != 5 || spec.Engine.Clique.Params.Epoch != 30000 {
+				t.Errorf("clique params = %+v, want period 5 epoch 30000", spec.Engine.Clique.Params)
+			}
+			if got := spec.Genesis.ExtraData; string(got) != string(genesis.ExtraData) {
+				t.Errorf("extraData = %x, want %x", got, genesis.ExtraData)
+			}
+			if got := uint64(spec.Genesis.GasLimit); got != genesis.GasLimit {
+				t.Errorf("gasLimit = %d, want %d", got, genesis.GasLimit)
+			}
+			if got := uint64(spec.Genesis.Timestamp); got != genesis.Timestamp {
+				t.Errorf("timestamp = %d, want %d", got, genesis.Timestamp)
+			}
+			if got := spec.Genesis.Seal.Ethereum.Nonce; len(got) != 8 {
+				t.Errorf("seal nonce = %x, want 8 bytes", got)
+			}
+			for address, account := range genesis.Alloc {
+				if got := spec.Accounts[address].Balance.ToInt(); got.Cmp(account.Balance) != 0 {
+					t.Errorf("balance of %s = %v, want %v", address, got, account.Balance)
+				}
+			}
+		})
+	}
+}
+
+func TestNewChainspecRejectsNonClique(t *testing.T) {
+	genesis := cliqueGenesis(big.NewInt(0))
+	genesis.Config.Clique = nil
+	if _, err := NewChainspec(genesis); err == nil {
+		t.Fatal("NewChainspec accepted a genesis without Clique")
+	}
+}
@@ -16,31 +16,33 @@ import (
 
 // GenesisGenerator gère la génération de la configuration genesis
 type GenesisGenerator struct {
-	chainID     *big.Int
-	period      uint64 // Block time en secondes
-	epoch       uint64 // Epoch length pour Clique
+	settings    *GenesisConfig
 	validators  []common.Address
 	allocations map[common.Address]*big.Int
 }
 
-// NewGenesisGenerator crée un nouveau générateur de genesis
+// NewGenesisGenerator crée un générateur de genesis avec la configuration par défaut
 func NewGenesisGenerator() *GenesisGenerator {
+	return NewGenesisGeneratorWithConfig(DefaultGenesisConfig())
+}
+
+// NewGenesisGeneratorWithConfig crée un générateur de genesis à partir de la configuration
+func NewGenesisGeneratorWithConfig(settings *GenesisConfig) *GenesisGenerator {
 	return &GenesisGenerator{
-		chainID:     big.NewInt(1337),
-		period:      5,  // 5 secondes par bloc
-		epoch:       30000,
+		settings:    settings,
 		allocations: make(map[common.Address]*big.Int),
 	}
 }
 
-// AddValidator ajoute un validateur au genesis
+// AddValidator ajoute un validateur au genesis, financé avec validator_balance
 func (g *GenesisGenerator) AddValidator(address common.Address) {
 	g.validators = append(g.validators, address)
-	
-	// Donner 1000 ETH à chaque validateur
-	initialBalance := new(big.Int)
-	initialBalance.SetString("1000000000000000000000", 10) // 1000 ETH en wei
-	g.allocations[address] = initialBalance
+	g.allocations[address] = etherToWei(g.settings.ValidatorBalance)
+}
+
+// AddNode finance un node non-validateur avec node_balance
+func (g *GenesisGenerator) AddNode(address common.Address) {
+	g.allocations[address] = etherToWei(g.settings.NodeBalance)
 }
 
 // AddAllocation ajoute une allocation d'ETH pour une adresse
@@ -55,22 +57,9 @@ func (g *GenesisGenerator) GenerateGenesis() (*core.Genesis, error) {
 	}
 	
 	// Créer la configuration Clique
-	config := &params.ChainConfig{
-		ChainID:                 g.chainID,
-		HomesteadBlock:          big.NewInt(0),
-		EIP150Block:             big.NewInt(0),
-		EIP155Block:             big.NewInt(0),
-		EIP158Block:             big.NewInt(0),
-		ByzantiumBlock:          big.NewInt(0),
-		ConstantinopleBlock:     big.NewInt(0),
-		PetersburgBlock:         big.NewInt(0),
-		IstanbulBlock:           big.NewInt(0),
-		BerlinBlock:             big.NewInt(0),
-		LondonBlock:             big.NewInt(0),
-		Clique: &params.CliqueConfig{
-			Period: g.period,
-			Epoch:  g.epoch,
-		},
+	config, err := g.settings.ChainConfig()
+	if err != nil {
+		return nil, err
 	}
 	
 	// Créer les allocations pour le genesis
@@ -80,6 +69,15 @@ func (g *GenesisGenerator) GenerateGenesis() (*core.Genesis, error) {
 			Balance: balance,
 		}
 	}
+
+	// Allocations du fichier alloc_file (contrats prédéployés...), prioritaires
+	extra, err := g.settings.LoadAlloc()
+	if err != nil {
+		return nil, err
+	}
+	for address, account := range extra {
+		alloc[address] = account
+	}
 	
 	// Créer l'extraData pour Clique (contient les validateurs)
 	extraData := make([]byte, 32) // 32 bytes de padding
@@ -93,7 +91,7 @@ func (g *GenesisGenerator) GenerateGenesis() (*core.Genesis, error) {
 		Nonce:      0,
 		Timestamp:  0,
 		ExtraData:  extraData,
-		GasLimit:   8000000,
+		GasLimit:   g.settings.GasLimit,
 		Difficulty: big.NewInt(1),
 		Mixhash:    common.Hash{},
 		Coinbase:   common.Address{},
@@ -125,6 +123,27 @@ func (g *GenesisGenerator) SaveGenesisToFile(genesis *core.Genesis, filePath str
 	return nil
 }
 
+// LoadGenesisFromFile relit un fichier genesis au format geth
+func LoadGenesisFromFile(filePath string) (*core.Genesis, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis file: %w", err)
+	}
+	genesis := new(core.Genesis)
+	if err := json.Unmarshal(data, genesis); err != nil {
+		return nil, fmt.Errorf("failed to parse genesis file %s: %w", filePath, err)
+	}
+	if genesis.Config == nil {
+		return nil, fmt.Errorf("genesis file %s has no chain config", filePath)
+	}
+	return genesis, nil
+}
+
+// etherToWei convertit un montant en ETH en wei
+func etherToWei(ether int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(ether), big.NewInt(params.Ether))
+}
+
 // KeyPair représente une paire de clé privée/publique
 type KeyPair struct {
 	PrivateKey *ecdsa.PrivateKey
@@ -148,53 +167,3 @@ func GenerateKeyPair() (*KeyPair, error) {
 		Address:    address,
 	}, nil
 }
-
-// SaveKeyPairToFile sauvegarde la clé privée dans un fichier
-func (kp *KeyPair) SaveKeyPairToFile(keyDir string, name string) error {
-	// Créer le répertoire si nécessaire
-	if err := os.MkdirAll(keyDir, 0755); err != nil {
-		return fmt.Errorf("failed to create key directory: %w", err)
-	}
-	
-	// Sauvegarder la clé privée
-	privateKeyBytes := crypto.FromECDSA(kp.PrivateKey)
-	privateKeyPath := filepath.Join(keyDir, fmt.Sprintf("%s-private.key", name))
-	
-	if err := os.WriteFile(privateKeyPath, privateKeyBytes, 0600); err != nil {
-		return fmt.Errorf("failed to save private key: %w", err)
-	}
-	
-	// Sauvegarder l'adresse
-	addressPath := filepath.Join(keyDir, fmt.Sprintf("%s-address.txt", name))
-	if err := os.WriteFile(addressPath, []byte(kp.Address.Hex()), 0644); err != nil {
-		return fmt.Errorf("failed to save address: %w", err)
-	}
-	
-	return nil
-}
-
-// LoadKeyPairFromFile charge une paire de clés depuis un fichier
-func LoadKeyPairFromFile(keyDir string, name string) (*KeyPair, error) {
-	privateKeyPath := filepath.Join(keyDir, fmt.Sprintf("%s-private.key", name))
-	
-	// Lire la clé privée
-	privateKeyBytes, err := os.ReadFile(privateKeyPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read private key: %w", err)
-	}
-	
-	// Convertir en clé ECDSA
-	privateKey, err := crypto.ToECDSA(privateKeyBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
-	}
-	
-	publicKey := &privateKey.PublicKey
-	address := crypto.PubkeyToAddress(*publicKey)
-	
-	return &KeyPair{
-		PrivateKey: privateKey,
-		PublicKey:  publicKey,
-		Address:    address,
-	}, nil
-}
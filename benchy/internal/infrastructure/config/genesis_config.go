@@ -0,0 +1,240 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/spf13/viper"
+)
+
+// GenesisConfig décrit le genesis du réseau (section genesis de .benchy.yaml)
+type GenesisConfig struct {
+	ChainID          uint64            `mapstructure:"chain_id"`
+	Period           uint64            `mapstructure:"period"` // Block time Clique en secondes, 0 = à la demande
+	Epoch            uint64            `mapstructure:"epoch"`
+	GasLimit         uint64            `mapstructure:"gas_limit"`
+	ValidatorBalance int64             `mapstructure:"validator_balance"` // En ETH
+	NodeBalance      int64             `mapstructure:"node_balance"`      // En ETH
+	AllocFile        string            `mapstructure:"alloc_file"`        // Allocations au format genesis de geth
+	Forks            map[string]uint64 `mapstructure:"forks"`             // Bloc d'activation par fork
+}
+
+// fork associe un nom de fork au champ correspondant de la configuration de chaîne
+type fork struct {
+	name  string
+	block func(c *params.ChainConfig) **big.Int
+}
+
+// supportedForks liste, dans l'ordre, les forks gérés par geth 1.10.26 et Nethermind 1.14.7.
+// Les forks jusqu'à London sont actifs dès le genesis sauf configuration contraire.
+var supportedForks = []fork{
+	{"homestead", func(c *params.ChainConfig) **big.Int { return &c.HomesteadBlock }},
+	{"eip150", func(c *params.ChainConfig) **big.Int { return &c.EIP150Block }},
+	{"eip155", func(c *params.ChainConfig) **big.Int { return &c.EIP155Block }},
+	{"eip158", func(c *params.ChainConfig) **big.Int { return &c.EIP158Block }},
+	{"byzantium", func(c *params.ChainConfig) **big.Int { return &c.ByzantiumBlock }},
+	{"constantinople", func(c *params.ChainConfig) **big.Int { return &c.ConstantinopleBlock }},
+	{"petersburg", func(c *params.ChainConfig) **big.Int { return &c.PetersburgBlock }},
+	{"istanbul", func(c *params.ChainConfig) **big.Int { return &c.IstanbulBlock }},
+	{"muir_glacier", func(c *params.ChainConfig) **big.Int { return &c.MuirGlacierBlock }},
+	{"berlin", func(c *params.ChainConfig) **big.Int { return &c.BerlinBlock }},
+	{"london", func(c *params.ChainConfig) **big.Int { return &c.LondonBlock }},
+	{"arrow_glacier", func(c *params.ChainConfig) **big.Int { return &c.ArrowGlacierBlock }},
+	{"gray_glacier", func(c *params.ChainConfig) **big.Int { return &c.GrayGlacierBlock }},
+}
+
+// genesisForks sont les forks actifs au bloc 0 par défaut
+const genesisForks = "london"
+
+// DefaultGenesisConfig retourne le genesis utilisé sans configuration
+func DefaultGenesisConfig() *GenesisConfig {
+	return &GenesisConfig{
+		ChainID:          1337,
+		Period:           5,
+		Epoch:            30000,
+		GasLimit:         8000000,
+		ValidatorBalance: 1000,
+		NodeBalance:      10,
+	}
+}
+
+// LoadGenesisConfig lit la section genesis de la configuration viper ;
+// les valeurs absentes gardent leur valeur par défaut
+func LoadGenesisConfig() (*GenesisConfig, error) {
+	genesis, err := readGenesisConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := genesis.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid genesis in %s: %w", viper.ConfigFileUsed(), err)
+	}
+	return genesis, nil
+}
+
+// readGenesisConfig lit la section genesis sans la valider
+func readGenesisConfig() (*GenesisConfig, error) {
+	genesis := DefaultGenesisConfig()
+	if !viper.IsSet("genesis") {
+		return genesis, nil
+	}
+
+	if err := viper.UnmarshalKey("genesis", genesis); err != nil {
+		return nil, fmt.Errorf("failed to read genesis configuration: %w", err)
+	}
+	if genesis.AllocFile != "" && !filepath.IsAbs(genesis.AllocFile) && viper.ConfigFileUsed() != "" {
+		genesis.AllocFile = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), genesis.AllocFile)
+	}
+	return genesis, nil
+}
+
+// Validate vérifie la cohérence de la configuration du genesis et signale tous ses problèmes à la fois
+func (g *GenesisConfig) Validate() error {
+	report := &ValidationReport{}
+	g.check(report)
+	return report.Err()
+}
+
+// publicChainIDs sont des chain IDs de réseaux publics : les réutiliser exposerait au rejeu
+var publicChainIDs = map[uint64]string{1: "mainnet", 5: "goerli", 11155111: "sepolia", 137: "polygon", 56: "bsc"}
+
+// check ajoute au rapport les problèmes de chaîne, de forks et d'allocations
+func (g *GenesisConfig) check(report *ValidationReport) {
+	if g.ChainID == 0 {
+		report.Errorf("chain_id must be positive")
+	} else if network, public := publicChainIDs[g.ChainID]; public {
+		report.Warnf("chain_id %d is the one of %s, transactions could be replayed there", g.ChainID, network)
+	}
+	if g.Epoch == 0 {
+		report.Errorf("epoch must be positive")
+	}
+	if g.GasLimit < params.MinGasLimit {
+		report.Errorf("gas_limit must be at least %d", params.MinGasLimit)
+	}
+	if g.ValidatorBalance < 0 || g.NodeBalance < 0 {
+		report.Errorf("balances must be positive")
+	}
+	if _, err := g.ChainConfig(); err != nil {
+		report.Errorf("%v", err)
+	}
+	if _, err := g.LoadAlloc(); err != nil {
+		report.Errorf("%v", err)
+	}
+}
+
+// ChainConfig construit la configuration de chaîne Clique avec les forks configurés
+func (g *GenesisConfig) ChainConfig() (*params.ChainConfig, error) {
+	chainConfig := &params.ChainConfig{
+		ChainID: new(big.Int).SetUint64(g.ChainID),
+		Clique: &params.CliqueConfig{
+			Period: g.Period,
+			Epoch:  g.Epoch,
+		},
+	}
+
+	active := true
+	for _, f := range supportedForks {
+		if active {
+			*f.block(chainConfig) = big.NewInt(0)
+		}
+		if f.name == genesisForks {
+			active = false
+		}
+	}
+
+	for name, block := range g.Forks {
+		f, ok := lookupFork(name)
+		if !ok {
+			return nil, fmt.Errorf("fork '%s' is not supported by the clients (known forks: %s)", name, strings.Join(forkNames(), ", "))
+		}
+		*f.block(chainConfig) = new(big.Int).SetUint64(block)
+	}
+
+	if err := chainConfig.CheckConfigForkOrder(); err != nil {
+		return nil, fmt.Errorf("invalid forks: %w", err)
+	}
+	return chainConfig, nil
+}
+
+// LoadAlloc lit les allocations supplémentaires (solde, code, storage, nonce) du fichier alloc_file
+func (g *GenesisConfig) LoadAlloc() (core.GenesisAlloc, error) {
+	if g.AllocFile == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(g.AllocFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alloc file: %w", err)
+	}
+	// Le solde est optionnel pour un contrat prédéployé, alors que geth l'exige
+	var accounts map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("failed to parse alloc file %s: %w", g.AllocFile, err)
+	}
+	for _, account := range accounts {
+		if _, ok := account["balance"]; !ok {
+			account["balance"] = json.RawMessage(`"0x0"`)
+		}
+	}
+	if data, err = json.Marshal(accounts); err != nil {
+		return nil, fmt.Errorf("failed to parse alloc file %s: %w", g.AllocFile, err)
+	}
+
+	var alloc core.GenesisAlloc
+	if err := json.Unmarshal(data, &alloc); err != nil {
+		return nil, fmt.Errorf("failed to parse alloc file %s: %w", g.AllocFile, err)
+	}
+	return alloc, nil
+}
+
+// ChainParams retourne les paramètres de consensus pour l'entité Network
+func (g *GenesisConfig) ChainParams() entities.ChainParams {
+	return entities.ChainParams{
+		ChainID:     new(big.Int).SetUint64(g.ChainID),
+		BlockTime:   time.Duration(g.Period) * time.Second,
+		EpochLength: g.Epoch,
+	}
+}
+
+// Summary décrit le genesis pour l'affichage
+func (g *GenesisConfig) Summary() string {
+	return fmt.Sprintf("Genesis: chain ID %d, %ds blocks, epoch %d, gas limit %d", g.ChainID, g.Period, g.Epoch, g.GasLimit)
+}
+
+// lookupFork retrouve un fork par son nom
+func lookupFork(name string) (fork, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, f := range supportedForks {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return fork{}, false
+}
+
+// ActiveForks liste les forks programmés d'une configuration de chaîne, avec leur bloc d'activation
+func ActiveForks(chainConfig *params.ChainConfig) []string {
+	var forks []string
+	for _, f := range supportedForks {
+		if block := *f.block(chainConfig); block != nil {
+			forks = append(forks, fmt.Sprintf("%s@%d", f.name, block))
+		}
+	}
+	return forks
+}
+
+// forkNames retourne les noms des forks supportés
+func forkNames() []string {
+	names := make([]string, len(supportedForks))
+	for i, f := range supportedForks {
+		names[i] = f.name
+	}
+	return names
+}
@@ -0,0 +1,250 @@
+package config
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/viper"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Chemins BIP-44 par défaut : les nodes sur le compte 0, les comptes de test sur le compte 1
+// et les clés P2P des nodes sur le compte 2
+const (
+	defaultNodesPath    = "m/44'/60'/0'/0"
+	defaultAccountsPath = "m/44'/60'/1'/0"
+	defaultNodeKeysPath = "m/44'/60'/2'/0"
+)
+
+// defaultAccountBalance est le solde en ETH des comptes de test au genesis
+const defaultAccountBalance = 100
+
+// KeysConfig décrit l'origine des clés (section keys de .benchy.yaml)
+type KeysConfig struct {
+	Mnemonic       string `mapstructure:"mnemonic"`
+	Passphrase     string `mapstructure:"passphrase"` // Passphrase BIP-39 optionnelle
+	Seed           string `mapstructure:"seed"`       // Seed hexadécimale, à la place du mnémonique
+	Path           string `mapstructure:"path"`
+	AccountsPath   string `mapstructure:"accounts_path"`
+	NodeKeysPath   string `mapstructure:"nodekeys_path"` // Clés P2P (enode) des nodes
+	Accounts       int    `mapstructure:"accounts"`
+	AccountBalance int64  `mapstructure:"account_balance"` // En ETH
+}
+
+// LoadKeysConfig lit la section keys de la configuration viper
+func LoadKeysConfig() (*KeysConfig, error) {
+	keys, err := readKeysConfig()
+	if err != nil {
+		return nil, err
+	}
+	if keys.Accounts < 0 {
+		return nil, fmt.Errorf("invalid keys configuration: accounts must be positive")
+	}
+	if keys.Mnemonic != "" && keys.Seed != "" {
+		return nil, fmt.Errorf("invalid keys configuration: set either mnemonic or seed, not both")
+	}
+	return keys, nil
+}
+
+// readKeysConfig lit la section keys et complète les valeurs par défaut, sans la valider
+func readKeysConfig() (*KeysConfig, error) {
+	keys := &KeysConfig{}
+	if viper.IsSet("keys") {
+		if err := viper.UnmarshalKey("keys", keys); err != nil {
+			return nil, fmt.Errorf("failed to read keys configuration: %w", err)
+		}
+	}
+
+	if keys.Path == "" {
+		keys.Path = defaultNodesPath
+	}
+	if keys.AccountsPath == "" {
+		keys.AccountsPath = defaultAccountsPath
+	}
+	if keys.NodeKeysPath == "" {
+		keys.NodeKeysPath = defaultNodeKeysPath
+	}
+	if keys.AccountBalance == 0 {
+		keys.AccountBalance = defaultAccountBalance
+	}
+	return keys, nil
+}
+
+// check ajoute au rapport les problèmes d'origine des clés et de chemins de dérivation
+func (k *KeysConfig) check(report *ValidationReport) {
+	if k.Accounts < 0 {
+		report.Errorf("accounts must be positive")
+	}
+	if k.AccountBalance < 0 {
+		report.Errorf("account_balance must be positive")
+	}
+	if k.Mnemonic != "" && k.Seed != "" {
+		report.Errorf("set either mnemonic or seed, not both")
+		return
+	}
+	if !k.Deterministic() {
+		return
+	}
+	if _, err := NewKeyDeriver(k); err != nil {
+		report.Errorf("%v", err)
+	}
+
+	paths := map[string]string{}
+	for _, path := range []struct{ key, value string }{
+		{"path", k.Path},
+		{"accounts_path", k.AccountsPath},
+		{"nodekeys_path", k.NodeKeysPath},
+	} {
+		if _, err := accounts.ParseDerivationPath(path.value + "/0"); err != nil {
+			report.Errorf("%s: invalid derivation path %s", path.key, path.value)
+			continue
+		}
+		if other, used := paths[path.value]; used {
+			report.Errorf("%s and %s are both %s: their keys would collide", other, path.key, path.value)
+		}
+		paths[path.value] = path.key
+	}
+}
+
+// Deterministic indique si les clés sont dérivées d'un mnémonique ou d'une seed
+func (k *KeysConfig) Deterministic() bool {
+	return k.Mnemonic != "" || k.Seed != ""
+}
+
+// Summary décrit l'origine des clés pour l'affichage
+func (k *KeysConfig) Summary() string {
+	origin := "random"
+	switch {
+	case k.Mnemonic != "":
+		origin = fmt.Sprintf("derived from mnemonic (%s/i)", k.Path)
+	case k.Seed != "":
+		origin = fmt.Sprintf("derived from seed (%s/i)", k.Path)
+	}
+	if k.Accounts > 0 {
+		return fmt.Sprintf("Keys: %s, %d test accounts", origin, k.Accounts)
+	}
+	return "Keys: " + origin
+}
+
+// KeyDeriver dérive des clés le long d'un chemin BIP-44 depuis une seed BIP-39
+type KeyDeriver struct {
+	master *extendedKey
+}
+
+// NewKeyDeriver crée le dériveur de la configuration ; nil si les clés sont aléatoires
+func NewKeyDeriver(keys *KeysConfig) (*KeyDeriver, error) {
+	var seed []byte
+	switch {
+	case keys.Mnemonic != "":
+		mnemonic := strings.Join(strings.Fields(keys.Mnemonic), " ")
+		var err error
+		if seed, err = bip39.NewSeedWithErrorChecking(mnemonic, keys.Passphrase); err != nil {
+			return nil, fmt.Errorf("invalid mnemonic: %w", err)
+		}
+	case keys.Seed != "":
+		var err error
+		if seed, err = hex.DecodeString(strings.TrimPrefix(keys.Seed, "0x")); err != nil {
+			return nil, fmt.Errorf("invalid seed: %w", err)
+		}
+		if len(seed) < 16 || len(seed) > 64 {
+			return nil, fmt.Errorf("invalid seed: must be 16 to 64 bytes long")
+		}
+	default:
+		return nil, nil
+	}
+
+	master, err := newMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyDeriver{master: master}, nil
+}
+
+// Derive retourne la clé d'index index sous le chemin de base basePath
+func (d *KeyDeriver) Derive(basePath string, index int) (*KeyPair, error) {
+	path, err := accounts.ParseDerivationPath(fmt.Sprintf("%s/%d", basePath, index))
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path %s: %w", basePath, err)
+	}
+
+	key := d.master
+	for _, component := range path {
+		if key, err = key.child(component); err != nil {
+			return nil, fmt.Errorf("failed to derive %s: %w", path, err)
+		}
+	}
+
+	privateKey, err := crypto.ToECDSA(key.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive %s: %w", path, err)
+	}
+	return &KeyPair{
+		PrivateKey: privateKey,
+		PublicKey:  &privateKey.PublicKey,
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}, nil
+}
+
+// extendedKey est une clé privée étendue BIP-32
+type extendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+// newMasterKey calcule la clé maître BIP-32 d'une seed
+func newMasterKey(seed []byte) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	if !validPrivateKey(sum[:32]) {
+		return nil, fmt.Errorf("invalid seed: unusable master key")
+	}
+	return &extendedKey{key: sum[:32], chainCode: sum[32:]}, nil
+}
+
+// child dérive la clé enfant d'index index (durcie si index >= 2^31)
+func (k *extendedKey) child(index uint32) (*extendedKey, error) {
+	data := make([]byte, 0, 37)
+	if index >= 0x80000000 {
+		data = append(data, 0)
+		data = append(data, k.key...)
+	} else {
+		privateKey, err := crypto.ToECDSA(k.key)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, crypto.CompressPubkey(&privateKey.PublicKey)...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	if !validPrivateKey(sum[:32]) {
+		return nil, fmt.Errorf("unusable child key at index %d", index)
+	}
+	curveOrder := crypto.S256().Params().N
+	childKey := new(big.Int).SetBytes(sum[:32])
+	childKey.Add(childKey, new(big.Int).SetBytes(k.key))
+	childKey.Mod(childKey, curveOrder)
+	if childKey.Sign() == 0 {
+		return nil, fmt.Errorf("unusable child key at index %d", index)
+	}
+
+	return &extendedKey{key: childKey.FillBytes(make([]byte, 32)), chainCode: sum[32:]}, nil
+}
+
+// validPrivateKey vérifie qu'un scalaire est dans ]0, n[
+func validPrivateKey(key []byte) bool {
+	value := new(big.Int).SetBytes(key)
+	return value.Sign() > 0 && value.Cmp(crypto.S256().Params().N) < 0
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Mnémonique de test de Hardhat et Foundry, dont les comptes sont connus
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestKeyDeriverDerive(t *testing.T) {
+	deriver, err := NewKeyDeriver(&KeysConfig{Mnemonic: testMnemonic})
+	if err != nil {
+		t.Fatalf("NewKeyDeriver: %v", err)
+	}
+
+	tests := []struct {
+		path  string
+		index int
+		want  common.Address
+	}{
+		{defaultNodesPath, 0, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")},
+		{defaultNodesPath, 1, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")},
+		{defaultNodesPath, 2, common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC")},
+	}
+	for _, tt := range tests {
+		key, err := deriver.Derive(tt.path, tt.index)
+		if err != nil {
+			t.Fatalf("Derive(%s/%d): %v", tt.path, tt.index, err)
+		}
+		if key.Address != tt.want {
+			t.Errorf("Derive(%s/%d) = %s, want %s", tt.path, tt.index, key.Address.Hex(), tt.want.Hex())
+		}
+	}
+}
+
+func TestNewKeyDeriver(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    KeysConfig
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "random keys", keys: KeysConfig{}, wantNil: true},
+		{name: "mnemonic", keys: KeysConfig{Mnemonic: testMnemonic}},
+		{name: "mnemonic with extra spaces", keys: KeysConfig{Mnemonic: "  test test test test test test\ttest test test test test junk "}},
+		{name: "bad checksum", keys: KeysConfig{Mnemonic: "test test test test test test test test test test test test"}, wantErr: true},
+		{name: "seed", keys: KeysConfig{Seed: "0x000102030405060708090a0b0c0d0e0f"}},
+		{name: "short seed", keys: KeysConfig{Seed: "0x0001"}, wantErr: true},
+		{name: "non hex seed", keys: KeysConfig{Seed: "0xzz"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deriver, err := NewKeyDeriver(&tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewKeyDeriver error = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (deriver == nil) != tt.wantNil {
+				t.Errorf("NewKeyDeriver = %v, want nil %v", deriver, tt.wantNil)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// passwordFileName est le fichier du mot de passe des keystores du réseau
+const passwordFileName = "password.txt"
+
+// Paramètres scrypt "light" : les keystores d'un réseau de test sont relus par
+// chaque commande, le coût standard (~1s par clé) serait trop pénalisant
+const (
+	keystoreScryptN = keystore.LightScryptN
+	keystoreScryptP = keystore.LightScryptP
+)
+
+// PasswordPath retourne le chemin du fichier de mot de passe du réseau
+func PasswordPath(baseDir string) string {
+	return filepath.Join(baseDir, passwordFileName)
+}
+
+// LoadOrCreatePassword lit le mot de passe du réseau, ou en génère un aléatoire
+func LoadOrCreatePassword(baseDir string) (string, error) {
+	path := PasswordPath(baseDir)
+	if data, err := os.ReadFile(path); err == nil {
+		return strings.TrimRight(string(data), "\r\n"), nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read keystore password: %w", err)
+	}
+
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate keystore password: %w", err)
+	}
+	password := hex.EncodeToString(secret)
+
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create base directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(password+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to save keystore password: %w", err)
+	}
+	return password, nil
+}
+
+// LoadPassword lit le mot de passe d'un réseau existant
+func LoadPassword(baseDir string) (string, error) {
+	data, err := os.ReadFile(PasswordPath(baseDir))
+	if err != nil {
+		return "", fmt.Errorf("failed to read keystore password: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// SaveKeystore chiffre la clé au format Web3 Secret Storage (V3) dans keyDir,
+// sous le nom de fichier utilisé par geth
+func (kp *KeyPair) SaveKeystore(keyDir string, password string) error {
+	if err := os.MkdirAll(keyDir, 0700); err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("failed to generate key id: %w", err)
+	}
+	key := &keystore.Key{Id: id, Address: kp.Address, PrivateKey: kp.PrivateKey}
+
+	keyJSON, err := keystore.EncryptKey(key, password, keystoreScryptN, keystoreScryptP)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt key: %w", err)
+	}
+
+	previous, _ := filepath.Glob(keystorePattern(keyDir, kp.Address))
+
+	// Écrire le nouveau fichier avant de toucher à l'ancien : une écriture ratée ne doit
+	// pas perdre la seule copie d'une clé générée aléatoirement
+	path := filepath.Join(keyDir, keystoreFileName(kp.Address))
+	tmp, err := os.CreateTemp(keyDir, ".keystore-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to save keystore: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(keyJSON); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save keystore: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save keystore: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save keystore: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save keystore: %w", err)
+	}
+
+	// Un seul keystore par adresse : retirer les précédents une fois le nouveau en place
+	for _, existing := range previous {
+		if existing != path {
+			os.Remove(existing)
+		}
+	}
+	return nil
+}
+
+// LoadKeystore déchiffre le keystore V3 d'une adresse depuis keyDir
+func LoadKeystore(keyDir string, address common.Address, password string) (*KeyPair, error) {
+	path, err := findKeystoreFile(keyDir, address)
+	if err != nil {
+		return nil, err
+	}
+
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore of %s: %w", address.Hex(), err)
+	}
+	if key.Address != address {
+		return nil, fmt.Errorf("keystore %s does not hold the key of %s", filepath.Base(path), address.Hex())
+	}
+
+	return &KeyPair{
+		PrivateKey: key.PrivateKey,
+		PublicKey:  &key.PrivateKey.PublicKey,
+		Address:    key.Address,
+	}, nil
+}
+
+// keystoreFileName reprend la convention de geth : UTC--<date>--<adresse>
+func keystoreFileName(address common.Address) string {
+	timestamp := time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z")
+	return fmt.Sprintf("UTC--%s--%s", timestamp, hex.EncodeToString(address[:]))
+}
+
+// keystorePattern désigne les keystores d'une adresse dans keyDir
+func keystorePattern(keyDir string, address common.Address) string {
+	return filepath.Join(keyDir, "UTC--*--"+hex.EncodeToString(address[:]))
+}
+
+// findKeystoreFile retrouve le keystore d'une adresse dans keyDir
+func findKeystoreFile(keyDir string, address common.Address) (string, error) {
+	matches, err := filepath.Glob(keystorePattern(keyDir, address))
+	if err != nil || len(matches) == 0 {
+		return "", fmt.Errorf("no keystore for %s in %s", address.Hex(), keyDir)
+	}
+	return matches[len(matches)-1], nil
+}
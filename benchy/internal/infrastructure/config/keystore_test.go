@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	keyPair, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+	keyDir := filepath.Join(t.TempDir(), "keystore")
+	if err := keyPair.SaveKeystore(keyDir, "secret"); err != nil {
+		t.Fatalf("SaveKeystore: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		address  common.Address
+		password string
+		wantErr  string
+	}{
+		{name: "right password", address: keyPair.Address, password: "secret"},
+		{name: "wrong password", address: keyPair.Address, password: "guess", wantErr: "failed to decrypt keystore"},
+		{name: "unknown address", address: common.HexToAddress("0xaa"), password: "secret", wantErr: "no keystore for"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := LoadKeystore(keyDir, tt.address, tt.password)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadKeystore error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadKeystore: %v", err)
+			}
+			if loaded.Address != keyPair.Address || !loaded.PrivateKey.Equal(keyPair.PrivateKey) {
+				t.Errorf("loaded key of %s, want %s", loaded.Address.Hex(), keyPair.Address.Hex())
+			}
+		})
+	}
+}
+
+func TestSaveKeystoreReplacesPreviousFile(t *testing.T) {
+	keyPair, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+	keyDir := t.TempDir()
+	for _, password := range []string{"first", "second"} {
+		if err := keyPair.SaveKeystore(keyDir, password); err != nil {
+			t.Fatalf("SaveKeystore: %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(keyDir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name()
+		}
+		t.Fatalf("keystore directory holds %v, want a single keystore", names)
+	}
+	if !strings.HasPrefix(entries[0].Name(), "UTC--") {
+		t.Errorf("keystore %s does not follow the geth naming", entries[0].Name())
+	}
+	if _, err := LoadKeystore(keyDir, keyPair.Address, "second"); err != nil {
+		t.Errorf("LoadKeystore with the last password: %v", err)
+	}
+}
+
+func TestLoadKeystoreRejectsForeignKey(t *testing.T) {
+	owner, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+	other, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair: %v", err)
+	}
+	keyDir := t.TempDir()
+	if err := other.SaveKeystore(keyDir, "secret"); err != nil {
+		t.Fatalf("SaveKeystore: %v", err)
+	}
+	// Le keystore de other renommé comme s'il contenait la clé de owner
+	path, err := findKeystoreFile(keyDir, other.Address)
+	if err != nil {
+		t.Fatalf("findKeystoreFile: %v", err)
+	}
+	if err := os.Rename(path, filepath.Join(keyDir, keystoreFileName(owner.Address))); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+
+	if _, err := LoadKeystore(keyDir, owner.Address, "secret"); err == nil || !strings.Contains(err.Error(), "does not hold the key of") {
+		t.Errorf("LoadKeystore error = %v, want a foreign key error", err)
+	}
+}
+
+func TestLoadOrCreatePassword(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), "benchy")
+	if _, err := LoadPassword(baseDir); err == nil {
+		t.Fatal("LoadPassword succeeded before the password was created")
+	}
+
+	created, err := LoadOrCreatePassword(baseDir)
+	if err != nil {
+		t.Fatalf("LoadOrCreatePassword: %v", err)
+	}
+	if len(created) != 32 {
+		t.Errorf("password %q, want 32 hexadecimal characters", created)
+	}
+	info, err := os.Stat(PasswordPath(baseDir))
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("password file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	for _, load := range []func(string) (string, error){LoadOrCreatePassword, LoadPassword} {
+		if password, err := load(baseDir); err != nil || password != created {
+			t.Errorf("password reloaded as %q (%v), want %q", password, err, created)
+		}
+	}
+}
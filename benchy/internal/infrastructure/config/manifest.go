@@ -0,0 +1,142 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ManifestVersion est la version courante du format du manifest
+const ManifestVersion = 2
+
+// manifestFileName est le nom du manifest dans le répertoire de base
+const manifestFileName = "network.json"
+
+// ErrNoManifest indique qu'aucun réseau n'a encore été lancé
+var ErrNoManifest = errors.New("no network manifest found, run 'benchy launch-network' first")
+
+// Manifest décrit le réseau lancé : il est écrit par launch-network et relu
+// par toutes les autres commandes au lieu de régénérer les clés
+type Manifest struct {
+	Version     int               `json:"version"`
+	Network     string            `json:"network"`
+	ChainID     uint64            `json:"chain_id"`
+	Period      uint64            `json:"period"`
+	Epoch       uint64            `json:"epoch"`
+	GenesisHash common.Hash       `json:"genesis_hash"`
+	GenesisFile string            `json:"genesis_file,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Nodes       []ManifestNode    `json:"nodes"`
+	Accounts    []ManifestAccount `json:"accounts,omitempty"`
+}
+
+// ManifestNode décrit un node du réseau lancé
+type ManifestNode struct {
+	Name         string                 `json:"name"`
+	Client       entities.ClientType    `json:"client"`
+	IsValidator  bool                   `json:"is_validator"`
+	Port         int                    `json:"port"`
+	RPCPort      int                    `json:"rpc_port"`
+	WSPort       int                    `json:"ws_port"`
+	Address      common.Address         `json:"address"`
+	Image        string                 `json:"image,omitempty"`
+	ExtraFlags   []string               `json:"extra_flags,omitempty"`
+	ClientConfig map[string]interface{} `json:"client_config,omitempty"`
+	ContainerID  string                 `json:"container_id,omitempty"`
+	DataDir      string                 `json:"data_dir"`
+	KeystoreDir  string                 `json:"keystore_dir"`
+	KeyPath      string                 `json:"key_path,omitempty"`
+	Enode        string                 `json:"enode"`
+	NodeKeyFile  string                 `json:"nodekey_file"`
+}
+
+// ManifestAccount décrit un compte de test financé au genesis
+type ManifestAccount struct {
+	Name        string         `json:"name"`
+	Address     common.Address `json:"address"`
+	KeystoreDir string         `json:"keystore_dir"`
+	KeyPath     string         `json:"key_path,omitempty"`
+}
+
+// ManifestPath retourne le chemin du manifest
+func ManifestPath(baseDir string) string {
+	return filepath.Join(baseDir, manifestFileName)
+}
+
+// LoadManifest lit le manifest du réseau ; ErrNoManifest s'il n'existe pas
+func LoadManifest(baseDir string) (*Manifest, error) {
+	data, err := os.ReadFile(ManifestPath(baseDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoManifest
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse network manifest: %w", err)
+	}
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported network manifest version %d (expected %d), relaunch the network", manifest.Version, ManifestVersion)
+	}
+	return &manifest, nil
+}
+
+// Save écrit le manifest de façon atomique
+func (m *Manifest) Save(baseDir string) error {
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return fmt.Errorf("failed to create base directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal network manifest: %w", err)
+	}
+
+	path := ManifestPath(baseDir)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write network manifest: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write network manifest: %w", err)
+	}
+	return nil
+}
+
+// Node retourne un node du manifest par son nom
+func (m *Manifest) Node(name string) (*ManifestNode, bool) {
+	for i := range m.Nodes {
+		if m.Nodes[i].Name == name {
+			return &m.Nodes[i], true
+		}
+	}
+	return nil, false
+}
+
+// Account retourne un compte de test du manifest par son nom
+func (m *Manifest) Account(name string) (*ManifestAccount, bool) {
+	for i := range m.Accounts {
+		if m.Accounts[i].Name == name {
+			return &m.Accounts[i], true
+		}
+	}
+	return nil, false
+}
+
+// ChainParams retourne les paramètres de consensus du réseau lancé
+func (m *Manifest) ChainParams() entities.ChainParams {
+	return entities.ChainParams{
+		ChainID:     new(big.Int).SetUint64(m.ChainID),
+		BlockTime:   time.Duration(m.Period) * time.Second,
+		EpochLength: m.Epoch,
+	}
+}
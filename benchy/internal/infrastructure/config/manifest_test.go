@@ -0,0 +1,203 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestManifestSaveLoad(t *testing.T) {
+	baseDir := filepath.Join(t.TempDir(), "benchy")
+	saved := &Manifest{
+		Version:     ManifestVersion,
+		Network:     "lab",
+		ChainID:     1338,
+		Period:      5,
+		Epoch:       30000,
+		GenesisHash: common.HexToHash("0x01"),
+		CreatedAt:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Nodes: []ManifestNode{
+			{Name: "alice", Client: entities.ClientGeth, IsValidator: true, Port: 30303, RPCPort: 8545, WSPort: 9545},
+			{Name: "bob", Client: entities.ClientBesu, Port: 30304, RPCPort: 8546, WSPort: 9546},
+		},
+		Accounts: []ManifestAccount{{Name: "account-0", Address: common.HexToAddress("0xaa")}},
+	}
+	if err := saved.Save(baseDir); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(ManifestPath(baseDir) + ".tmp"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("temporary manifest left behind: %v", err)
+	}
+
+	loaded, err := LoadManifest(baseDir)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if loaded.Network != saved.Network || loaded.ChainID != saved.ChainID || loaded.GenesisHash != saved.GenesisHash || !loaded.CreatedAt.Equal(saved.CreatedAt) {
+		t.Errorf("loaded %+v, want %+v", loaded, saved)
+	}
+	if node, ok := loaded.Node("bob"); !ok || node.Client != entities.ClientBesu || node.RPCPort != 8546 {
+		t.Errorf("Node(bob) = %+v, %v", node, ok)
+	}
+	if _, ok := loaded.Node("carol"); ok {
+		t.Errorf("Node(carol) found in a manifest without carol")
+	}
+	if account, ok := loaded.Account("account-0"); !ok || account.Address != saved.Accounts[0].Address {
+		t.Errorf("Account(account-0) = %+v, %v", account, ok)
+	}
+	if params := loaded.ChainParams(); params.ChainID.Uint64() != 1338 || params.BlockTime != 5*time.Second || params.EpochLength != 30000 {
+		t.Errorf("ChainParams = %+v", params)
+	}
+}
+
+func TestLoadManifestErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string // Vide : pas de manifest
+		wantErr string
+	}{
+		{name: "no manifest", wantErr: ErrNoManifest.Error()},
+		{name: "corrupt manifest", content: "{", wantErr: "failed to parse network manifest"},
+		{name: "previous version", content: `{"version": 1}`, wantErr: "unsupported network manifest version 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir := t.TempDir()
+			if tt.content != "" {
+				if err := os.WriteFile(ManifestPath(baseDir), []byte(tt.content), 0644); err != nil {
+					t.Fatalf("failed to write manifest: %v", err)
+				}
+			}
+			_, err := LoadManifest(baseDir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadManifest error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// reuseConfig déclare deux nodes et un compte de test aux clés aléatoires
+const reuseConfig = `keys:
+  accounts: 1
+topology:
+  nodes:
+    - name: alice
+      client: geth
+      validator: true
+    - name: bob
+      client: nethermind
+`
+
+// launchManifest génère les nodes de config, sauvegarde leurs clés et leur manifest
+// comme un lancement, et retourne le manifest relu
+func launchManifest(t *testing.T, baseDir, config string) *Manifest {
+	t.Helper()
+	useConfig(t, config)
+	ncm := NewNodeConfigManager(baseDir)
+	if err := ncm.GenerateNodes(); err != nil {
+		t.Fatalf("GenerateNodes: %v", err)
+	}
+	if err := ncm.SaveAllConfigurations(); err != nil {
+		t.Fatalf("SaveAllConfigurations: %v", err)
+	}
+	genesis, err := ncm.GenerateGenesisWithNodes()
+	if err != nil {
+		t.Fatalf("GenerateGenesisWithNodes: %v", err)
+	}
+	if err := ncm.SaveManifest(DefaultNetwork, genesis, ""); err != nil {
+		t.Fatalf("SaveManifest: %v", err)
+	}
+	manifest, err := LoadManifest(baseDir)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	return manifest
+}
+
+func TestReuseManifestKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string // Configuration du relancement
+		wantReuse bool
+	}{
+		{name: "same topology", config: reuseConfig, wantReuse: true},
+		{name: "renamed node", config: strings.Replace(reuseConfig, "name: bob", "name: bea", 1)},
+		{name: "other client", config: strings.Replace(reuseConfig, "client: nethermind", "client: besu", 1)},
+		{name: "node promoted to validator", config: reuseConfig + "      validator: true\n"},
+		{name: "more test accounts", config: strings.Replace(reuseConfig, "accounts: 1", "accounts: 2", 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			baseDir := t.TempDir()
+			manifest := launchManifest(t, baseDir, reuseConfig)
+
+			useConfig(t, tt.config)
+			ncm := NewNodeConfigManager(baseDir)
+			if err := ncm.GenerateNodes(); err != nil {
+				t.Fatalf("GenerateNodes: %v", err)
+			}
+			generated := ncm.GetAllNodes()[0].KeyPair.Address
+
+			reused, err := ncm.ReuseManifestKeys(manifest)
+			if err != nil {
+				t.Fatalf("ReuseManifestKeys: %v", err)
+			}
+			if reused != tt.wantReuse {
+				t.Fatalf("ReuseManifestKeys = %v, want %v", reused, tt.wantReuse)
+			}
+
+			nodes := ncm.GetAllNodes()
+			if !tt.wantReuse {
+				if nodes[0].KeyPair.Address != generated {
+					t.Errorf("keys of %s replaced although the topology changed", nodes[0].Name)
+				}
+				return
+			}
+			for i, node := range nodes {
+				previous := manifest.Nodes[i]
+				if node.KeyPair.Address != previous.Address {
+					t.Errorf("%s address = %s, want %s", node.Name, node.KeyPair.Address.Hex(), previous.Address.Hex())
+				}
+				if node.Enode != previous.Enode {
+					t.Errorf("%s enode = %s, want %s", node.Name, node.Enode, previous.Enode)
+				}
+			}
+			if peers := nodes[1].StaticPeers; len(peers) != 1 || peers[0] != manifest.Nodes[0].Enode {
+				t.Errorf("static peers of %s = %v, want the reused enode of %s", nodes[1].Name, peers, nodes[0].Name)
+			}
+			if account := ncm.GetTestAccounts()[0]; account.KeyPair.Address != manifest.Accounts[0].Address {
+				t.Errorf("%s address = %s, want %s", account.Name, account.KeyPair.Address.Hex(), manifest.Accounts[0].Address.Hex())
+			}
+		})
+	}
+}
+
+func TestReuseManifestKeysMissingKeystore(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	baseDir := t.TempDir()
+	manifest := launchManifest(t, baseDir, reuseConfig)
+	if err := os.RemoveAll(manifest.Nodes[1].KeystoreDir); err != nil {
+		t.Fatalf("failed to remove keystore: %v", err)
+	}
+
+	ncm := NewNodeConfigManager(baseDir)
+	if err := ncm.GenerateNodes(); err != nil {
+		t.Fatalf("GenerateNodes: %v", err)
+	}
+	generated := ncm.GetAllNodes()[0].KeyPair.Address
+	if _, err := ncm.ReuseManifestKeys(manifest); err == nil {
+		t.Fatal("ReuseManifestKeys succeeded without the keystore of bob")
+	}
+	if ncm.GetAllNodes()[0].KeyPair.Address != generated {
+		t.Error("keys of alice replaced although the keys of bob could not be loaded")
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Valeurs par défaut de la section monitoring
+const (
+	defaultSampleInterval    = 5 * time.Second
+	defaultHistoryRetention  = 24 * time.Hour
+	defaultHistoryResolution = time.Minute
+)
+
+// MonitoringConfig règle l'échantillonnage des nodes et l'historique de leurs métriques
+type MonitoringConfig struct {
+	Interval   time.Duration `mapstructure:"interval"`   // Intervalle du collecteur
+	Retention  time.Duration `mapstructure:"retention"`  // Durée conservée sur disque
+	Resolution time.Duration `mapstructure:"resolution"` // Pas des échantillons anciens, moyennés
+}
+
+// DefaultMonitoringConfig retourne la configuration utilisée sans section monitoring
+func DefaultMonitoringConfig() *MonitoringConfig {
+	return &MonitoringConfig{
+		Interval:   defaultSampleInterval,
+		Retention:  defaultHistoryRetention,
+		Resolution: defaultHistoryResolution,
+	}
+}
+
+// LoadMonitoringConfig lit et valide la section monitoring de la configuration
+func LoadMonitoringConfig() (*MonitoringConfig, error) {
+	monitoring, err := readMonitoringConfig()
+	if err != nil {
+		return nil, err
+	}
+	report := &ValidationReport{}
+	monitoring.check(report)
+	if err := report.Err(); err != nil {
+		return nil, fmt.Errorf("invalid monitoring in %s: %w", viper.ConfigFileUsed(), err)
+	}
+	return monitoring, nil
+}
+
+// readMonitoringConfig lit la section monitoring sans la valider
+func readMonitoringConfig() (*MonitoringConfig, error) {
+	monitoring := DefaultMonitoringConfig()
+	if !viper.IsSet("monitoring") {
+		return monitoring, nil
+	}
+	if err := viper.UnmarshalKey("monitoring", monitoring); err != nil {
+		return nil, fmt.Errorf("failed to read monitoring configuration: %w", err)
+	}
+	return monitoring, nil
+}
+
+// check ajoute au rapport les durées incohérentes
+func (m *MonitoringConfig) check(report *ValidationReport) {
+	if m.Interval <= 0 {
+		report.Errorf("interval must be positive")
+	}
+	if m.Resolution <= 0 {
+		report.Errorf("resolution must be positive")
+	} else if m.Interval > m.Resolution {
+		report.Errorf("resolution (%s) must not be shorter than interval (%s)", m.Resolution, m.Interval)
+	}
+	if m.Retention < m.Resolution {
+		report.Errorf("retention (%s) must be at least one resolution step (%s)", m.Retention, m.Resolution)
+	}
+}
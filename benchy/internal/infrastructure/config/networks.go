@@ -0,0 +1,266 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"github.com/spf13/viper"
+)
+
+// DefaultNetwork est le réseau utilisé sans --network ; son état reste directement dans ~/.benchy
+const DefaultNetwork = "benchy"
+
+// portOffsetStep sépare les plages de ports de deux réseaux voisins
+const portOffsetStep = 100
+
+// networksFileName liste les réseaux nommés et l'emplacement qui leur est réservé
+const networksFileName = "networks.json"
+
+// Verrou de networks.json : deux lancements simultanés ne doivent pas réserver le même emplacement
+const (
+	networksLockTimeout = 5 * time.Second
+	networksLockStale   = 30 * time.Second
+)
+
+// networkNamePattern garde des noms utilisables pour les containers et réseaux Docker
+var networkNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// NetworkProfile décrit où vit un réseau sur l'hôte : état, réseau Docker, containers et ports.
+// Chaque réseau nommé reçoit un emplacement (slot) qui décale ses ports et son chain ID.
+type NetworkProfile struct {
+	Name            string
+	Slot            int
+	Root            string // ~/.benchy, commun à tous les réseaux
+	BaseDir         string
+	DockerNetwork   string
+	ContainerPrefix string
+	PortOffset      int
+	CreatedAt       time.Time
+	Registered      bool // Emplacement déjà réservé dans networks.json
+}
+
+// networkEntry est l'enregistrement d'un réseau nommé dans networks.json
+type networkEntry struct {
+	Slot      int       `json:"slot"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CurrentNetwork retourne le réseau choisi par --network (ou BENCHY_NETWORK)
+func CurrentNetwork() (*NetworkProfile, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	name := viper.GetString("network")
+	if name == "" {
+		name = DefaultNetwork
+	}
+	return LoadNetworkProfile(filepath.Join(home, ".benchy"), name)
+}
+
+// LoadNetworkProfile retourne le profil d'un réseau ; un réseau inconnu reçoit le
+// premier emplacement libre, réservé seulement au lancement par Register
+func LoadNetworkProfile(root, name string) (*NetworkProfile, error) {
+	if !networkNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid network name '%s': use lowercase letters, digits, '-', '_' or '.'", name)
+	}
+	if name == DefaultNetwork {
+		return &NetworkProfile{
+			Name:            DefaultNetwork,
+			Root:            root,
+			BaseDir:         root,
+			DockerNetwork:   "benchy-network",
+			ContainerPrefix: "benchy-",
+			Registered:      true,
+		}, nil
+	}
+
+	entries, err := loadNetworkEntries(root)
+	if err != nil {
+		return nil, err
+	}
+	entry, registered := entries[name]
+	if !registered {
+		entry = networkEntry{Slot: nextFreeSlot(entries)}
+	}
+	return newNetworkProfile(root, name, entry, registered), nil
+}
+
+// newNetworkProfile construit le profil d'un réseau nommé depuis son emplacement
+func newNetworkProfile(root, name string, entry networkEntry, registered bool) *NetworkProfile {
+	return &NetworkProfile{
+		Name:            name,
+		Slot:            entry.Slot,
+		Root:            root,
+		BaseDir:         filepath.Join(root, "networks", name),
+		DockerNetwork:   fmt.Sprintf("benchy-%s-network", name),
+		ContainerPrefix: fmt.Sprintf("benchy-%s-", name),
+		PortOffset:      entry.Slot * portOffsetStep,
+		CreatedAt:       entry.CreatedAt,
+		Registered:      registered,
+	}
+}
+
+// Register réserve l'emplacement du réseau dans networks.json
+func (p *NetworkProfile) Register() error {
+	if p.Registered {
+		return nil
+	}
+	if err := os.MkdirAll(p.Root, 0755); err != nil {
+		return fmt.Errorf("failed to create base directory: %w", err)
+	}
+	unlock, err := lockNetworks(filepath.Join(p.Root, networksFileName+".lock"))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := loadNetworkEntries(p.Root)
+	if err != nil {
+		return err
+	}
+	if entry, exists := entries[p.Name]; exists {
+		// Un autre lancement a réservé ce réseau entre-temps
+		*p = *newNetworkProfile(p.Root, p.Name, entry, true)
+		return nil
+	}
+	// Un autre lancement a pu réserver cet emplacement entre-temps
+	for _, entry := range entries {
+		if entry.Slot == p.Slot {
+			p.Slot = nextFreeSlot(entries)
+			p.PortOffset = p.Slot * portOffsetStep
+			break
+		}
+	}
+
+	p.CreatedAt = time.Now().UTC()
+	entries[p.Name] = networkEntry{Slot: p.Slot, CreatedAt: p.CreatedAt}
+	if err := saveNetworkEntries(p.Root, entries); err != nil {
+		return err
+	}
+	p.Registered = true
+	return nil
+}
+
+// lockNetworks prend un verrou exclusif en créant path et retourne la fonction qui le libère
+func lockNetworks(path string) (func(), error) {
+	deadline := time.Now().Add(networksLockTimeout)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock networks file: %w", err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > networksLockStale {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to lock networks file: %s is held by another process", path)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// ContainerName retourne le nom du container d'un node, qui sert aussi de nom d'hôte
+func (p *NetworkProfile) ContainerName(node string) string {
+	return p.ContainerPrefix + node
+}
+
+// Layout retourne le nom, le réseau Docker et le préfixe des containers du réseau
+func (p *NetworkProfile) Layout() entities.NetworkLayout {
+	return entities.NetworkLayout{
+		Name:            p.Name,
+		DockerNetwork:   p.DockerNetwork,
+		ContainerPrefix: p.ContainerPrefix,
+	}
+}
+
+// Summary décrit le réseau pour l'affichage
+func (p *NetworkProfile) Summary() string {
+	if p.PortOffset == 0 {
+		return fmt.Sprintf("Network: %s (state in %s)", p.Name, p.BaseDir)
+	}
+	return fmt.Sprintf("Network: %s (state in %s, ports +%d)", p.Name, p.BaseDir, p.PortOffset)
+}
+
+// ListNetworks retourne le réseau par défaut et les réseaux nommés, par emplacement
+func ListNetworks(root string) ([]*NetworkProfile, error) {
+	entries, err := loadNetworkEntries(root)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultNetwork, err := LoadNetworkProfile(root, DefaultNetwork)
+	if err != nil {
+		return nil, err
+	}
+	networks := []*NetworkProfile{defaultNetwork}
+	for name, entry := range entries {
+		networks = append(networks, newNetworkProfile(root, name, entry, true))
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Slot < networks[j].Slot
+	})
+	return networks, nil
+}
+
+// nextFreeSlot retourne le plus petit emplacement libre ; 0 est celui du réseau par défaut
+func nextFreeSlot(entries map[string]networkEntry) int {
+	used := make(map[int]bool, len(entries))
+	for _, entry := range entries {
+		used[entry.Slot] = true
+	}
+	slot := 1
+	for used[slot] {
+		slot++
+	}
+	return slot
+}
+
+// loadNetworkEntries lit networks.json, vide s'il n'existe pas encore
+func loadNetworkEntries(root string) (map[string]networkEntry, error) {
+	entries := make(map[string]networkEntry)
+	data, err := os.ReadFile(filepath.Join(root, networksFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read networks file: %w", err)
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse networks file: %w", err)
+	}
+	return entries, nil
+}
+
+// saveNetworkEntries écrit networks.json de façon atomique
+func saveNetworkEntries(root string, entries map[string]networkEntry) error {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return fmt.Errorf("failed to create base directory: %w", err)
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal networks file: %w", err)
+	}
+
+	path := filepath.Join(root, networksFileName)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write networks file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write networks file: %w", err)
+	}
+	return nil
+}
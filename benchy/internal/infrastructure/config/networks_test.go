@@ -0,0 +1,215 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNextFreeSlot(t *testing.T) {
+	tests := []struct {
+		name  string
+		slots []int
+		want  int
+	}{
+		{name: "no named network", want: 1},
+		{name: "consecutive slots", slots: []int{1, 2, 3}, want: 4},
+		{name: "freed slot is reused", slots: []int{1, 3}, want: 2},
+		{name: "slot 0 stays with the default network", slots: []int{2}, want: 1},
+	}
+	for _, tt := range tests {
+		entries := make(map[string]networkEntry)
+		for i, slot := range tt.slots {
+			entries[fmt.Sprintf("net%d", i)] = networkEntry{Slot: slot}
+		}
+		if got := nextFreeSlot(entries); got != tt.want {
+			t.Errorf("%s: nextFreeSlot = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadNetworkProfile(t *testing.T) {
+	root := t.TempDir()
+	saved := map[string]networkEntry{
+		"lab":     {Slot: 1, CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"staging": {Slot: 3},
+	}
+	if err := saveNetworkEntries(root, saved); err != nil {
+		t.Fatalf("saveNetworkEntries: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		network        string
+		wantErr        bool
+		wantSlot       int
+		wantRegistered bool
+		wantBaseDir    string
+		wantDocker     string
+		wantPrefix     string
+	}{
+		{
+			name: "default network", network: DefaultNetwork, wantRegistered: true,
+			wantBaseDir: root, wantDocker: "benchy-network", wantPrefix: "benchy-",
+		},
+		{
+			name: "registered network keeps its slot", network: "lab", wantSlot: 1, wantRegistered: true,
+			wantBaseDir: filepath.Join(root, "networks", "lab"), wantDocker: "benchy-lab-network", wantPrefix: "benchy-lab-",
+		},
+		{
+			name: "new network gets the first free slot", network: "perf", wantSlot: 2,
+			wantBaseDir: filepath.Join(root, "networks", "perf"), wantDocker: "benchy-perf-network", wantPrefix: "benchy-perf-",
+		},
+		{name: "uppercase name", network: "Lab", wantErr: true},
+		{name: "path in name", network: "../lab", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := LoadNetworkProfile(root, tt.network)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadNetworkProfile error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if profile.Slot != tt.wantSlot || profile.PortOffset != tt.wantSlot*portOffsetStep || profile.Registered != tt.wantRegistered {
+				t.Errorf("slot %d, offset %d, registered %v; want slot %d, registered %v",
+					profile.Slot, profile.PortOffset, profile.Registered, tt.wantSlot, tt.wantRegistered)
+			}
+			if profile.BaseDir != tt.wantBaseDir || profile.DockerNetwork != tt.wantDocker || profile.ContainerPrefix != tt.wantPrefix {
+				t.Errorf("profile = %+v", profile)
+			}
+			if layout := profile.Layout(); layout.Name != tt.network || layout.DockerNetwork != tt.wantDocker || layout.ContainerName("alice") != tt.wantPrefix+"alice" {
+				t.Errorf("layout = %+v", layout)
+			}
+		})
+	}
+}
+
+func TestRegisterConcurrentNetworks(t *testing.T) {
+	root := t.TempDir()
+	names := []string{"a", "b", "c", "d", "e", "f"}
+
+	// Tous les profils sont chargés avant la première réservation : ils visent le même emplacement
+	profiles := make([]*NetworkProfile, len(names))
+	for i, name := range names {
+		profile, err := LoadNetworkProfile(root, name)
+		if err != nil {
+			t.Fatalf("LoadNetworkProfile: %v", err)
+		}
+		profiles[i] = profile
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, len(profiles))
+	for i, profile := range profiles {
+		wg.Add(1)
+		go func(i int, profile *NetworkProfile) {
+			defer wg.Done()
+			<-start
+			errs[i] = profile.Register()
+		}(i, profile)
+	}
+	close(start)
+	wg.Wait()
+
+	slots := make(map[int]string)
+	for i, profile := range profiles {
+		if errs[i] != nil {
+			t.Fatalf("Register %s: %v", profile.Name, errs[i])
+		}
+		if owner, taken := slots[profile.Slot]; taken {
+			t.Errorf("slot %d given to both %s and %s", profile.Slot, owner, profile.Name)
+		}
+		slots[profile.Slot] = profile.Name
+		if profile.PortOffset != profile.Slot*portOffsetStep {
+			t.Errorf("%s port offset %d does not follow slot %d", profile.Name, profile.PortOffset, profile.Slot)
+		}
+	}
+
+	entries, err := loadNetworkEntries(root)
+	if err != nil {
+		t.Fatalf("loadNetworkEntries: %v", err)
+	}
+	if len(entries) != len(names) {
+		t.Errorf("networks file holds %d networks, want %d", len(entries), len(names))
+	}
+	for _, profile := range profiles {
+		if entries[profile.Name].Slot != profile.Slot {
+			t.Errorf("%s saved with slot %d, want %d", profile.Name, entries[profile.Name].Slot, profile.Slot)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, networksFileName+".lock")); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
+
+func TestRegisterSameNetworkTwice(t *testing.T) {
+	root := t.TempDir()
+	first, err := LoadNetworkProfile(root, "lab")
+	if err != nil {
+		t.Fatalf("LoadNetworkProfile: %v", err)
+	}
+	second, err := LoadNetworkProfile(root, "lab")
+	if err != nil {
+		t.Fatalf("LoadNetworkProfile: %v", err)
+	}
+	other, err := LoadNetworkProfile(root, "other")
+	if err != nil {
+		t.Fatalf("LoadNetworkProfile: %v", err)
+	}
+
+	for _, profile := range []*NetworkProfile{first, other, second} {
+		if err := profile.Register(); err != nil {
+			t.Fatalf("Register %s: %v", profile.Name, err)
+		}
+	}
+	if second.Slot != first.Slot || !second.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("lab registered twice: slots %d and %d", first.Slot, second.Slot)
+	}
+	if other.Slot == first.Slot {
+		t.Errorf("other shares slot %d with lab", other.Slot)
+	}
+}
+
+func TestLockNetworksRemovesStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), networksFileName+".lock")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("failed to write lock: %v", err)
+	}
+	stale := time.Now().Add(-2 * networksLockStale)
+	if err := os.Chtimes(path, stale, stale); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	unlock, err := lockNetworks(path)
+	if err != nil {
+		t.Fatalf("lockNetworks: %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("lock still present after unlock: %v", err)
+	}
+}
+
+func TestListNetworks(t *testing.T) {
+	root := t.TempDir()
+	if err := saveNetworkEntries(root, map[string]networkEntry{"zeta": {Slot: 1}, "alpha": {Slot: 2}}); err != nil {
+		t.Fatalf("saveNetworkEntries: %v", err)
+	}
+	networks, err := ListNetworks(root)
+	if err != nil {
+		t.Fatalf("ListNetworks: %v", err)
+	}
+	var names []string
+	for _, network := range networks {
+		names = append(names, network.Name)
+	}
+	if want := []string{DefaultNetwork, "zeta", "alpha"}; fmt.Sprint(names) != fmt.Sprint(want) {
+		t.Errorf("ListNetworks = %v, want %v (by slot)", names, want)
+	}
+}
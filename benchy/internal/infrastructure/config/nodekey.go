@@ -0,0 +1,49 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// nodeKeyFileName est le nom de la clé P2P dans le répertoire d'un node, distinct
+// des copies au format de chaque client écrites à côté
+const nodeKeyFileName = "enode.key"
+
+// EnodeURL construit l'URL enode d'un node à partir de sa clé P2P
+func EnodeURL(key *ecdsa.PublicKey, host string, port int) string {
+	return fmt.Sprintf("enode://%x@%s:%d", crypto.FromECDSAPub(key)[1:], host, port)
+}
+
+// EnodeID extrait l'identifiant (clé publique hexadécimale) d'une URL enode
+func EnodeID(enode string) string {
+	id := strings.TrimPrefix(enode, "enode://")
+	if i := strings.IndexByte(id, '@'); i >= 0 {
+		id = id[:i]
+	}
+	return strings.ToLower(id)
+}
+
+// saveNodeKey écrit la clé P2P d'un node en hexadécimal, lisible seulement par l'utilisateur
+func saveNodeKey(path string, key *ecdsa.PrivateKey) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create node directory: %w", err)
+	}
+	if err := crypto.SaveECDSA(path, key); err != nil {
+		return fmt.Errorf("failed to write node key: %w", err)
+	}
+	return nil
+}
+
+// loadNodeKey relit la clé P2P d'un node
+func loadNodeKey(path string) (*ecdsa.PrivateKey, error) {
+	key, err := crypto.LoadECDSA(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node key %s: %w", path, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Clé privée 1 : sa clé publique est le point générateur de secp256k1
+const (
+	generatorKeyHex = "0000000000000000000000000000000000000000000000000000000000000001"
+	generatorPubHex = "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+)
+
+func TestEnodeURL(t *testing.T) {
+	key, err := crypto.HexToECDSA(generatorKeyHex)
+	if err != nil {
+		t.Fatalf("HexToECDSA: %v", err)
+	}
+
+	tests := []struct {
+		host string
+		port int
+		want string
+	}{
+		{host: "benchy-alice", port: 30303, want: "enode://" + generatorPubHex + "@benchy-alice:30303"},
+		{host: "benchy-lab-bob", port: 30404, want: "enode://" + generatorPubHex + "@benchy-lab-bob:30404"},
+	}
+	for _, tt := range tests {
+		if got := EnodeURL(&key.PublicKey, tt.host, tt.port); got != tt.want {
+			t.Errorf("EnodeURL(%s, %d) = %s, want %s", tt.host, tt.port, got, tt.want)
+		}
+	}
+}
+
+func TestEnodeID(t *testing.T) {
+	tests := []struct {
+		enode string
+		want  string
+	}{
+		{enode: "enode://ABCDEF@benchy-alice:30303", want: "abcdef"},
+		{enode: "enode://abcdef@10.0.0.1:30303?discport=0", want: "abcdef"},
+		{enode: "abcdef", want: "abcdef"},
+		{enode: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := EnodeID(tt.enode); got != tt.want {
+			t.Errorf("EnodeID(%q) = %q, want %q", tt.enode, got, tt.want)
+		}
+	}
+}
+
+func TestNodeKeySaveLoad(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "nodes", "alice", nodeKeyFileName)
+	if err := saveNodeKey(path, key); err != nil {
+		t.Fatalf("saveNodeKey: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("node key file = %v (%v), want mode 0600", info, err)
+	}
+
+	loaded, err := loadNodeKey(path)
+	if err != nil {
+		t.Fatalf("loadNodeKey: %v", err)
+	}
+	if !loaded.Equal(key) {
+		t.Error("loaded node key differs from the saved one")
+	}
+	if _, err := loadNodeKey(path + ".missing"); err == nil {
+		t.Error("loadNodeKey succeeded without a node key file")
+	}
+}
+
+func TestUseAccountKeyAsNodeKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	useConfig(t, mnemonicConfig)
+	ncm := NewNodeConfigManager(t.TempDir())
+	if err := ncm.GenerateNodes(); err != nil {
+		t.Fatalf("GenerateNodes: %v", err)
+	}
+	nodes := ncm.GetAllNodes()
+	bob := nodes[1]
+	if bob.NodeKey.Equal(bob.KeyPair.PrivateKey) {
+		t.Fatal("node key and account key are already the same")
+	}
+
+	ncm.UseAccountKeyAsNodeKey(bob.Name)
+	if !bob.NodeKey.Equal(bob.KeyPair.PrivateKey) {
+		t.Error("node key of bob is not its account key")
+	}
+	if want := EnodeURL(bob.KeyPair.PublicKey, bob.Container, bob.Port); bob.Enode != want {
+		t.Errorf("enode of bob = %s, want %s", bob.Enode, want)
+	}
+	for _, node := range nodes[2:] {
+		if node.StaticPeers[1] != bob.Enode {
+			t.Errorf("%s static peer = %s, want the new enode of bob", node.Name, node.StaticPeers[1])
+		}
+	}
+}
@@ -1,19 +1,28 @@
 package config
 
 import (
+	"crypto/ecdsa"
 	"fmt"
-	"math/big"
 	"github.com/ethereum/go-ethereum/core"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"benchy/internal/domain/entities"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
 )
 
 // NodeConfigManager gère la configuration des nodes
 type NodeConfigManager struct {
-	baseDir string
-	nodes   []*NodeConfig
+	baseDir  string
+	nodes    []*NodeConfig
+	accounts []*TestAccount
+	topology *Topology
+	keys     *KeysConfig
+	genesis  *GenesisConfig
+	network  *NetworkProfile
 }
 
 // NodeConfig représente la configuration complète d'un node
@@ -27,6 +36,32 @@ type NodeConfig struct {
 	KeyPair     *KeyPair
 	DataDir     string
 	KeystoreDir string
+	ContainerID string
+	KeyPath     string // Chemin BIP-44 de la clé, vide si elle est aléatoire
+	Container   string // Nom du container, qui sert aussi de nom d'hôte
+
+	// Identité P2P, connue avant le lancement
+	NodeKey     *ecdsa.PrivateKey
+	NodeKeyFile string
+	Enode       string
+	StaticPeers []string // Enodes des nodes lancés avant celui-ci
+
+	// Options du container
+	Image       string // Vide = image par défaut du client
+	CPUs        float64
+	MemoryBytes int64
+	ExtraFlags  []string
+
+	// Surcharges du fichier de configuration du client
+	ClientConfig map[string]interface{}
+}
+
+// TestAccount est un compte de test financé au genesis, sans node associé
+type TestAccount struct {
+	Name        string
+	KeyPair     *KeyPair
+	KeyPath     string
+	KeystoreDir string
 }
 
 // NewNodeConfigManager crée un nouveau gestionnaire de configuration
@@ -37,48 +72,313 @@ func NewNodeConfigManager(baseDir string) *NodeConfigManager {
 	}
 }
 
-// GenerateDefaultNodes génère la configuration des 5 nodes par défaut
-func (ncm *NodeConfigManager) GenerateDefaultNodes() error {
-	defaultNodes := []struct {
-		name        string
-		isValidator bool
-		client      entities.ClientType
-		port        int
-		rpcPort     int
-	}{
-		{"alice", true, entities.ClientGeth, 30303, 8545},
-		{"bob", true, entities.ClientGeth, 30304, 8546},
-		{"cassandra", true, entities.ClientNethermind, 30305, 8547},
-		{"driss", false, entities.ClientGeth, 30306, 8548},
-		{"elena", false, entities.ClientNethermind, 30307, 8549},
+// BaseDir retourne le répertoire de base de benchy
+func (ncm *NodeConfigManager) BaseDir() string {
+	return ncm.baseDir
+}
+
+// Network retourne le réseau choisi par --network
+func (ncm *NodeConfigManager) Network() (*NetworkProfile, error) {
+	if ncm.network == nil {
+		network, err := CurrentNetwork()
+		if err != nil {
+			return nil, err
+		}
+		ncm.network = network
 	}
+	return ncm.network, nil
+}
 
-	for _, nodeInfo := range defaultNodes {
-		// Générer la paire de clés
-		keyPair, err := GenerateKeyPair()
+// Topology retourne la topologie déclarée dans .benchy.yaml, ou celle par défaut,
+// avec les ports décalés selon le réseau
+func (ncm *NodeConfigManager) Topology() (*Topology, error) {
+	if ncm.topology == nil {
+		network, err := ncm.Network()
+		if err != nil {
+			return nil, err
+		}
+		topology, err := LoadTopology()
+		if err != nil {
+			return nil, err
+		}
+		topology.shiftPorts(network.PortOffset)
+		ncm.topology = topology
+	}
+	return ncm.topology, nil
+}
+
+// Keys retourne la configuration des clés déclarée dans .benchy.yaml
+func (ncm *NodeConfigManager) Keys() (*KeysConfig, error) {
+	if ncm.keys == nil {
+		keys, err := LoadKeysConfig()
 		if err != nil {
-			return fmt.Errorf("failed to generate key pair for %s: %w", nodeInfo.name, err)
+			return nil, err
+		}
+		ncm.keys = keys
+	}
+	return ncm.keys, nil
+}
+
+// Genesis retourne la configuration du genesis déclarée dans .benchy.yaml ;
+// sans chain_id explicite, chaque réseau nommé a son propre chain ID
+func (ncm *NodeConfigManager) Genesis() (*GenesisConfig, error) {
+	if ncm.genesis == nil {
+		network, err := ncm.Network()
+		if err != nil {
+			return nil, err
+		}
+		genesis, err := LoadGenesisConfig()
+		if err != nil {
+			return nil, err
+		}
+		if !viper.IsSet("genesis.chain_id") {
+			genesis.ChainID += uint64(network.Slot)
+		}
+		ncm.genesis = genesis
+	}
+	return ncm.genesis, nil
+}
+
+// GenerateNodes génère la configuration et les clés de chaque node de la topologie,
+// ainsi que les comptes de test
+func (ncm *NodeConfigManager) GenerateNodes() error {
+	topology, err := ncm.Topology()
+	if err != nil {
+		return err
+	}
+	keys, err := ncm.Keys()
+	if err != nil {
+		return err
+	}
+	deriver, err := NewKeyDeriver(keys)
+	if err != nil {
+		return err
+	}
+	network, err := ncm.Network()
+	if err != nil {
+		return err
+	}
+
+	ncm.nodes = make([]*NodeConfig, 0, len(topology.Nodes))
+	for i, spec := range topology.Nodes {
+		// Dériver ou générer la paire de clés
+		keyPair, keyPath, err := nextKeyPair(deriver, keys.Path, i)
+		if err != nil {
+			return fmt.Errorf("failed to generate key pair for %s: %w", spec.Name, err)
+		}
+		nodeKey, _, err := nextKeyPair(deriver, keys.NodeKeysPath, i)
+		if err != nil {
+			return fmt.Errorf("failed to generate node key for %s: %w", spec.Name, err)
 		}
 
 		// Créer la configuration du node
-		nodeConfig := &NodeConfig{
-			Name:        nodeInfo.name,
-			IsValidator: nodeInfo.isValidator,
-			Client:      nodeInfo.client,
-			Port:        nodeInfo.port,
-			RPCPort:     nodeInfo.rpcPort,
-			WSPort:      nodeInfo.rpcPort + 1000, // WebSocket port = RPC port + 1000
-			KeyPair:     keyPair,
-			DataDir:     filepath.Join(ncm.baseDir, "nodes", nodeInfo.name, "data"),
-			KeystoreDir: filepath.Join(ncm.baseDir, "nodes", nodeInfo.name, "keystore"),
+		nodeConfig, err := ncm.newNodeConfig(spec, network, keyPair, keyPath, nodeKey)
+		if err != nil {
+			return err
 		}
 
 		ncm.nodes = append(ncm.nodes, nodeConfig)
 	}
+	ncm.linkStaticPeers()
+
+	ncm.accounts = make([]*TestAccount, 0, keys.Accounts)
+	for i := 0; i < keys.Accounts; i++ {
+		name := fmt.Sprintf("account-%d", i)
+		keyPair, keyPath, err := nextKeyPair(deriver, keys.AccountsPath, i)
+		if err != nil {
+			return fmt.Errorf("failed to generate key pair for %s: %w", name, err)
+		}
+		ncm.accounts = append(ncm.accounts, &TestAccount{
+			Name:        name,
+			KeyPair:     keyPair,
+			KeyPath:     keyPath,
+			KeystoreDir: filepath.Join(ncm.baseDir, "accounts", "keystore"),
+		})
+	}
+
+	return nil
+}
+
+// newNodeConfig construit la configuration d'un node de la topologie à partir de ses clés
+func (ncm *NodeConfigManager) newNodeConfig(spec NodeSpec, network *NetworkProfile, keyPair *KeyPair, keyPath string, nodeKey *KeyPair) (*NodeConfig, error) {
+	memory, err := spec.MemoryBytes()
+	if err != nil {
+		return nil, fmt.Errorf("node %s: %w", spec.Name, err)
+	}
+
+	return &NodeConfig{
+		Name:         spec.Name,
+		IsValidator:  spec.Validator,
+		Client:       spec.Client,
+		Port:         spec.Port,
+		RPCPort:      spec.RPCPort,
+		WSPort:       spec.WSPort,
+		Image:        spec.Image,
+		CPUs:         spec.Resources.CPUs,
+		MemoryBytes:  memory,
+		ExtraFlags:   spec.ExtraFlags,
+		ClientConfig: spec.ClientConfig,
+		KeyPair:      keyPair,
+		KeyPath:      keyPath,
+		Container:    network.ContainerName(spec.Name),
+		NodeKey:      nodeKey.PrivateKey,
+		NodeKeyFile:  filepath.Join(ncm.baseDir, "nodes", spec.Name, nodeKeyFileName),
+		Enode:        EnodeURL(&nodeKey.PrivateKey.PublicKey, network.ContainerName(spec.Name), spec.Port),
+		DataDir:      filepath.Join(ncm.baseDir, "nodes", spec.Name, "data"),
+		KeystoreDir:  filepath.Join(ncm.baseDir, "nodes", spec.Name, "keystore"),
+	}, nil
+}
+
+// nextKeyPair dérive la clé index sous basePath, ou en génère une aléatoire sans dériveur
+func nextKeyPair(deriver *KeyDeriver, basePath string, index int) (*KeyPair, string, error) {
+	if deriver == nil {
+		keyPair, err := GenerateKeyPair()
+		return keyPair, "", err
+	}
+	keyPair, err := deriver.Derive(basePath, index)
+	return keyPair, fmt.Sprintf("%s/%d", basePath, index), err
+}
+
+// linkStaticPeers donne à chaque node les enodes des nodes lancés avant lui :
+// leurs containers existent déjà, donc leur nom d'hôte se résout au démarrage,
+// et chaque connexion servant dans les deux sens, le réseau forme un maillage complet
+func (ncm *NodeConfigManager) linkStaticPeers() {
+	for i, node := range ncm.nodes {
+		node.StaticPeers = make([]string, 0, i)
+		for _, previous := range ncm.nodes[:i] {
+			node.StaticPeers = append(node.StaticPeers, previous.Enode)
+		}
+	}
+}
+
+// UseAccountKeyAsNodeKey fait de la clé du compte d'un node sa clé P2P, pour les clients
+// qui scellent les blocs avec leur clé P2P ; son enode et les nodes statiques suivent
+func (ncm *NodeConfigManager) UseAccountKeyAsNodeKey(name string) {
+	node := ncm.GetNodeByName(name)
+	if node == nil {
+		return
+	}
+	node.NodeKey = node.KeyPair.PrivateKey
+	node.Enode = EnodeURL(node.KeyPair.PublicKey, node.Container, node.Port)
+	ncm.linkStaticPeers()
+}
+
+// AddNode ajoute un node au réseau lancé (chargé par LoadExistingConfigurations) : ses ports
+// sont les premiers libres, ses clés suivent celles des nodes existants et ses nodes statiques
+// sont tous les autres nodes, déjà démarrés
+func (ncm *NodeConfigManager) AddNode(spec NodeSpec) (*NodeConfig, error) {
+	spec.Name = strings.ToLower(strings.TrimSpace(spec.Name))
+	if !nodeNamePattern.MatchString(spec.Name) {
+		return nil, fmt.Errorf("node name '%s' is not a valid container name (letters, digits, '-', '_' or '.')", spec.Name)
+	}
+	if ncm.GetNodeByName(spec.Name) != nil {
+		return nil, fmt.Errorf("node '%s' already exists", spec.Name)
+	}
+	if !spec.Client.IsKnown() {
+		return nil, fmt.Errorf("unknown client '%s' (supported: %s)", spec.Client, clientNames())
+	}
+
+	used := make(map[int]bool)
+	lastPort, lastRPCPort, index := 0, 0, len(ncm.nodes)
+	for _, node := range ncm.nodes {
+		used[node.Port], used[node.RPCPort], used[node.WSPort] = true, true, true
+		if node.Port > lastPort {
+			lastPort = node.Port
+		}
+		if node.RPCPort > lastRPCPort {
+			lastRPCPort = node.RPCPort
+		}
+		// Les clés dérivées d'un node retiré ne sont pas réutilisées
+		if i := strings.LastIndexByte(node.KeyPath, '/'); i >= 0 {
+			if n, err := strconv.Atoi(node.KeyPath[i+1:]); err == nil && n >= index {
+				index = n + 1
+			}
+		}
+	}
+	if spec.Port == 0 {
+		spec.Port = nextFreePort(used, lastPort+1)
+	}
+	used[spec.Port] = true
+	if spec.RPCPort == 0 {
+		spec.RPCPort = nextFreePort(used, lastRPCPort+1)
+	}
+	used[spec.RPCPort] = true
+	if spec.WSPort == 0 {
+		spec.WSPort = nextFreePort(used, spec.RPCPort+wsPortShift)
+	}
+	for _, port := range []int{spec.Port, spec.RPCPort, spec.WSPort} {
+		if port < 1 || port > 65535 {
+			return nil, fmt.Errorf("port %d is out of range 1-65535", port)
+		}
+	}
+
+	keys, err := ncm.Keys()
+	if err != nil {
+		return nil, err
+	}
+	deriver, err := NewKeyDeriver(keys)
+	if err != nil {
+		return nil, err
+	}
+	network, err := ncm.Network()
+	if err != nil {
+		return nil, err
+	}
+	keyPair, keyPath, err := nextKeyPair(deriver, keys.Path, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key pair for %s: %w", spec.Name, err)
+	}
+	nodeKey, _, err := nextKeyPair(deriver, keys.NodeKeysPath, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate node key for %s: %w", spec.Name, err)
+	}
+
+	node, err := ncm.newNodeConfig(spec, network, keyPair, keyPath, nodeKey)
+	if err != nil {
+		return nil, err
+	}
+	ncm.nodes = append(ncm.nodes, node)
+	ncm.linkStaticPeers()
+	return node, nil
+}
+
+// nextFreePort retourne le premier port libre à partir de port
+func nextFreePort(used map[int]bool, port int) int {
+	for used[port] {
+		port++
+	}
+	return port
+}
+
+// RemoveNode retire un node de la configuration du réseau lancé
+func (ncm *NodeConfigManager) RemoveNode(name string) (*NodeConfig, error) {
+	for i, node := range ncm.nodes {
+		if node.Name == name {
+			ncm.nodes = append(ncm.nodes[:i], ncm.nodes[i+1:]...)
+			ncm.linkStaticPeers()
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown node '%s'", name)
+}
 
+// SaveNodeConfiguration sauvegarde les clés d'un seul node, ajouté au réseau lancé
+func (ncm *NodeConfigManager) SaveNodeConfiguration(name string) error {
+	node := ncm.GetNodeByName(name)
+	if node == nil {
+		return fmt.Errorf("unknown node '%s'", name)
+	}
+	if err := ncm.saveNodeConfiguration(node); err != nil {
+		return fmt.Errorf("failed to save configuration for %s: %w", name, err)
+	}
 	return nil
 }
 
+// GetTestAccounts retourne les comptes de test générés
+func (ncm *NodeConfigManager) GetTestAccounts() []*TestAccount {
+	return ncm.accounts
+}
+
 // SaveAllConfigurations sauvegarde toutes les configurations
 func (ncm *NodeConfigManager) SaveAllConfigurations() error {
 	for _, node := range ncm.nodes {
@@ -86,18 +386,44 @@ func (ncm *NodeConfigManager) SaveAllConfigurations() error {
 			return fmt.Errorf("failed to save configuration for %s: %w", node.Name, err)
 		}
 	}
+
+	if len(ncm.accounts) == 0 {
+		return nil
+	}
+	password, err := LoadOrCreatePassword(ncm.baseDir)
+	if err != nil {
+		return err
+	}
+	for _, account := range ncm.accounts {
+		if err := account.KeyPair.SaveKeystore(account.KeystoreDir, password); err != nil {
+			return fmt.Errorf("failed to save key pair of %s: %w", account.Name, err)
+		}
+	}
 	return nil
 }
 
 // saveNodeConfiguration sauvegarde la configuration d'un node
 func (ncm *NodeConfigManager) saveNodeConfiguration(node *NodeConfig) error {
-	
-	// Sauvegarder la paire de clés
-	if err := node.KeyPair.SaveKeyPairToFile(node.KeystoreDir, node.Name); err != nil {
+	password, err := LoadOrCreatePassword(ncm.baseDir)
+	if err != nil {
+		return err
+	}
+
+	// Sauvegarder la paire de clés chiffrée
+	if err := node.KeyPair.SaveKeystore(node.KeystoreDir, password); err != nil {
 		return fmt.Errorf("failed to save key pair: %w", err)
 	}
 
-	return nil
+	// Sauvegarder la clé P2P : l'enode du manifest doit rester valide
+	return saveNodeKey(node.NodeKeyFile, node.NodeKey)
+}
+
+// PasswordFile retourne le fichier de mot de passe des keystores, créé si besoin
+func (ncm *NodeConfigManager) PasswordFile() (string, error) {
+	if _, err := LoadOrCreatePassword(ncm.baseDir); err != nil {
+		return "", err
+	}
+	return PasswordPath(ncm.baseDir), nil
 }
 
 // GetValidators retourne les adresses des validateurs
@@ -120,6 +446,107 @@ func (ncm *NodeConfigManager) GetAllAddresses() []common.Address {
 	return addresses
 }
 
+// LoadAddressBook associe chaque adresse du réseau lancé au nom de son node
+func (ncm *NodeConfigManager) LoadAddressBook() map[common.Address]string {
+	book := make(map[common.Address]string)
+
+	manifest, err := LoadManifest(ncm.baseDir)
+	if err != nil {
+		return book
+	}
+	for _, node := range manifest.Nodes {
+		book[node.Address] = node.Name
+	}
+	for _, account := range manifest.Accounts {
+		book[account.Address] = account.Name
+	}
+	return book
+}
+
+// LoadNodeKeyPair charge la paire de clés d'un node ou d'un compte de test du réseau lancé
+func (ncm *NodeConfigManager) LoadNodeKeyPair(name string) (*KeyPair, error) {
+	manifest, err := LoadManifest(ncm.baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var keystoreDir string
+	var address common.Address
+	if node, exists := manifest.Node(name); exists {
+		keystoreDir, address = node.KeystoreDir, node.Address
+	} else if account, exists := manifest.Account(name); exists {
+		keystoreDir, address = account.KeystoreDir, account.Address
+	} else {
+		return nil, fmt.Errorf("unknown node '%s'", name)
+	}
+
+	password, err := LoadPassword(ncm.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	return LoadKeystore(keystoreDir, address, password)
+}
+
+// NodeNames retourne les noms des nodes, depuis le manifest s'il existe
+// et sinon depuis la topologie
+func (ncm *NodeConfigManager) NodeNames() ([]string, error) {
+	if manifest, err := LoadManifest(ncm.baseDir); err == nil {
+		names := make([]string, len(manifest.Nodes))
+		for i, node := range manifest.Nodes {
+			names[i] = node.Name
+		}
+		return names, nil
+	}
+
+	topology, err := ncm.Topology()
+	if err != nil {
+		return nil, err
+	}
+	return topology.Names(), nil
+}
+
+// NodeRPCPort retourne le port RPC d'un node, depuis le manifest s'il existe
+// et sinon depuis la topologie
+func (ncm *NodeConfigManager) NodeRPCPort(name string) (int, bool) {
+	if manifest, err := LoadManifest(ncm.baseDir); err == nil {
+		if node, exists := manifest.Node(name); exists {
+			return node.RPCPort, true
+		}
+		return 0, false
+	}
+
+	topology, err := ncm.Topology()
+	if err != nil {
+		return 0, false
+	}
+	node, exists := topology.Node(name)
+	if !exists {
+		return 0, false
+	}
+	return node.RPCPort, true
+}
+
+// NodeClient retourne le client Ethereum d'un node, depuis le manifest s'il existe
+// et sinon depuis la topologie
+func (ncm *NodeConfigManager) NodeClient(name string) (entities.ClientType, bool) {
+	if manifest, err := LoadManifest(ncm.baseDir); err == nil {
+		if node, exists := manifest.Node(name); exists {
+			return node.Client, true
+		}
+		return "", false
+	}
+
+	topology, err := ncm.Topology()
+	if err != nil {
+		return "", false
+	}
+	node, exists := topology.Node(name)
+	if !exists {
+		return "", false
+	}
+	return node.Client, true
+}
+
 // GetNodeByName retourne la configuration d'un node par son nom
 func (ncm *NodeConfigManager) GetNodeByName(name string) *NodeConfig {
 	for _, node := range ncm.nodes {
@@ -137,7 +564,11 @@ func (ncm *NodeConfigManager) GetAllNodes() []*NodeConfig {
 
 // GenerateGenesisWithNodes génère le genesis avec les nodes configurés
 func (ncm *NodeConfigManager) GenerateGenesisWithNodes() (*core.Genesis, error) {
-	generator := NewGenesisGenerator()
+	settings, err := ncm.Genesis()
+	if err != nil {
+		return nil, err
+	}
+	generator := NewGenesisGeneratorWithConfig(settings)
 	
 	// Ajouter tous les validateurs
 	for _, node := range ncm.nodes {
@@ -147,21 +578,191 @@ func (ncm *NodeConfigManager) GenerateGenesisWithNodes() (*core.Genesis, error)
 	}
 	
 	// Ajouter une petite allocation pour les nodes non-validateurs
-	smallBalance := new(big.Int)
-	smallBalance.SetString("10000000000000000000", 10) // 10 ETH en wei
-	
 	for _, node := range ncm.nodes {
 		if !node.IsValidator {
-			generator.AddAllocation(node.KeyPair.Address, smallBalance)
+			generator.AddNode(node.KeyPair.Address)
+		}
+	}
+
+	// Financer les comptes de test
+	if len(ncm.accounts) > 0 {
+		keys, err := ncm.Keys()
+		if err != nil {
+			return nil, err
+		}
+		balance := etherToWei(keys.AccountBalance)
+		for _, account := range ncm.accounts {
+			generator.AddAllocation(account.KeyPair.Address, balance)
 		}
 	}
 	
 	return generator.GenerateGenesis()
 }
 
-// LoadExistingConfigurations charge les configurations existantes
-func (ncm *NodeConfigManager) LoadExistingConfigurations() error {
-	// Pour l'instant, on génère toujours de nouvelles configurations
-	// TODO: Implémenter le chargement depuis des fichiers existants
-	return ncm.GenerateDefaultNodes()
+// SetContainerID enregistre le container qui fait tourner un node
+func (ncm *NodeConfigManager) SetContainerID(name, containerID string) {
+	if node := ncm.GetNodeByName(name); node != nil {
+		node.ContainerID = containerID
+	}
+}
+
+// SaveManifest écrit le manifest du réseau lancé avec les nodes configurés ; le hash du
+// bloc genesis attendu sert à vérifier que chaque node a été initialisé avec le même genesis
+func (ncm *NodeConfigManager) SaveManifest(networkName string, genesis *core.Genesis, genesisFile string) error {
+	manifest := &Manifest{
+		Version:     ManifestVersion,
+		Network:     networkName,
+		ChainID:     genesis.Config.ChainID.Uint64(),
+		Period:      genesis.Config.Clique.Period,
+		Epoch:       genesis.Config.Clique.Epoch,
+		GenesisHash: genesis.ToBlock().Hash(),
+		GenesisFile: genesisFile,
+		CreatedAt:   time.Now().UTC(),
+	}
+	manifest.Nodes = ncm.manifestNodes()
+	for _, account := range ncm.accounts {
+		manifest.Accounts = append(manifest.Accounts, ManifestAccount{
+			Name:        account.Name,
+			Address:     account.KeyPair.Address,
+			KeystoreDir: account.KeystoreDir,
+			KeyPath:     account.KeyPath,
+		})
+	}
+	return manifest.Save(ncm.baseDir)
+}
+
+// UpdateManifest réécrit les nodes du manifest après l'ajout ou le retrait d'un node
+func (ncm *NodeConfigManager) UpdateManifest(manifest *Manifest) error {
+	manifest.Nodes = ncm.manifestNodes()
+	return manifest.Save(ncm.baseDir)
+}
+
+// manifestNodes décrit les nodes configurés pour le manifest
+func (ncm *NodeConfigManager) manifestNodes() []ManifestNode {
+	nodes := make([]ManifestNode, 0, len(ncm.nodes))
+	for _, node := range ncm.nodes {
+		nodes = append(nodes, ManifestNode{
+			Name:         node.Name,
+			Client:       node.Client,
+			IsValidator:  node.IsValidator,
+			Port:         node.Port,
+			RPCPort:      node.RPCPort,
+			WSPort:       node.WSPort,
+			Address:      node.KeyPair.Address,
+			Image:        node.Image,
+			ExtraFlags:   node.ExtraFlags,
+			ClientConfig: node.ClientConfig,
+			ContainerID:  node.ContainerID,
+			DataDir:      node.DataDir,
+			KeystoreDir:  node.KeystoreDir,
+			KeyPath:      node.KeyPath,
+			Enode:        node.Enode,
+			NodeKeyFile:  node.NodeKeyFile,
+		})
+	}
+	return nodes
+}
+
+// LoadExistingConfigurations recharge les nodes et leurs clés depuis le manifest
+func (ncm *NodeConfigManager) LoadExistingConfigurations() (*Manifest, error) {
+	manifest, err := LoadManifest(ncm.baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := LoadPassword(ncm.baseDir)
+	if err != nil {
+		return nil, err
+	}
+	network, err := ncm.Network()
+	if err != nil {
+		return nil, err
+	}
+
+	ncm.nodes = make([]*NodeConfig, 0, len(manifest.Nodes))
+	for _, node := range manifest.Nodes {
+		keyPair, err := LoadKeystore(node.KeystoreDir, node.Address, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load keys of %s: %w", node.Name, err)
+		}
+		nodeKey, err := loadNodeKey(node.NodeKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load keys of %s: %w", node.Name, err)
+		}
+
+		ncm.nodes = append(ncm.nodes, &NodeConfig{
+			Name:         node.Name,
+			IsValidator:  node.IsValidator,
+			Client:       node.Client,
+			Port:         node.Port,
+			RPCPort:      node.RPCPort,
+			WSPort:       node.WSPort,
+			KeyPair:      keyPair,
+			DataDir:      node.DataDir,
+			KeystoreDir:  node.KeystoreDir,
+			KeyPath:      node.KeyPath,
+			Container:    network.ContainerName(node.Name),
+			NodeKey:      nodeKey,
+			NodeKeyFile:  node.NodeKeyFile,
+			Enode:        node.Enode,
+			ContainerID:  node.ContainerID,
+			Image:        node.Image,
+			ExtraFlags:   node.ExtraFlags,
+			ClientConfig: node.ClientConfig,
+		})
+	}
+	ncm.linkStaticPeers()
+	return manifest, nil
+}
+
+// ReuseManifestKeys remplace les clés des nodes générés par celles du réseau déjà lancé,
+// pour qu'un relancement retrouve les mêmes adresses, enodes et genesis. Les clés ne sont
+// reprises que si la topologie décrit les mêmes nodes (noms, clients, rôles) et le même
+// nombre de comptes de test ; retourne false sinon, sans rien modifier.
+func (ncm *NodeConfigManager) ReuseManifestKeys(manifest *Manifest) (bool, error) {
+	if len(manifest.Nodes) != len(ncm.nodes) || len(manifest.Accounts) != len(ncm.accounts) {
+		return false, nil
+	}
+	for i, node := range ncm.nodes {
+		previous := manifest.Nodes[i]
+		if previous.Name != node.Name || previous.Client != node.Client || previous.IsValidator != node.IsValidator {
+			return false, nil
+		}
+	}
+
+	password, err := LoadPassword(ncm.baseDir)
+	if err != nil {
+		return false, err
+	}
+
+	// Charger toutes les clés avant d'en remplacer une seule
+	keyPairs := make([]*KeyPair, len(ncm.nodes))
+	nodeKeys := make([]*ecdsa.PrivateKey, len(ncm.nodes))
+	for i, previous := range manifest.Nodes {
+		if keyPairs[i], err = LoadKeystore(previous.KeystoreDir, previous.Address, password); err != nil {
+			return false, fmt.Errorf("failed to load keys of %s: %w", previous.Name, err)
+		}
+		if nodeKeys[i], err = loadNodeKey(previous.NodeKeyFile); err != nil {
+			return false, fmt.Errorf("failed to load keys of %s: %w", previous.Name, err)
+		}
+	}
+	accountKeys := make([]*KeyPair, len(ncm.accounts))
+	for i, previous := range manifest.Accounts {
+		if accountKeys[i], err = LoadKeystore(previous.KeystoreDir, previous.Address, password); err != nil {
+			return false, fmt.Errorf("failed to load keys of %s: %w", previous.Name, err)
+		}
+	}
+
+	for i, node := range ncm.nodes {
+		node.KeyPair = keyPairs[i]
+		node.KeyPath = manifest.Nodes[i].KeyPath
+		node.NodeKey = nodeKeys[i]
+		node.Enode = EnodeURL(&nodeKeys[i].PublicKey, node.Container, node.Port)
+	}
+	for i, account := range ncm.accounts {
+		account.KeyPair = accountKeys[i]
+		account.KeyPath = manifest.Accounts[i].KeyPath
+	}
+	ncm.linkStaticPeers()
+	return true, nil
 }
@@ -0,0 +1,318 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"benchy/internal/domain/entities"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// nodeNamePattern garde des noms utilisables dans les noms de containers et d'hôtes
+var nodeNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// Ports attribués aux nodes qui n'en déclarent pas
+const (
+	basePort    = 30303
+	baseRPCPort = 8545
+	wsPortShift = 1000 // WebSocket port = RPC port + 1000
+)
+
+// Topology décrit les nodes du réseau (section topology de .benchy.yaml)
+type Topology struct {
+	Nodes []NodeSpec `mapstructure:"nodes"`
+}
+
+// NodeSpec décrit un node de la topologie
+type NodeSpec struct {
+	Name       string              `mapstructure:"name"`
+	Client     entities.ClientType `mapstructure:"client"`
+	Validator  bool                `mapstructure:"validator"`
+	Port       int                 `mapstructure:"port"`
+	RPCPort    int                 `mapstructure:"rpc_port"`
+	WSPort     int                 `mapstructure:"ws_port"`
+	Image      string              `mapstructure:"image"` // Remplace l'image par défaut du client
+	Resources  Resources           `mapstructure:"resources"`
+	ExtraFlags []string            `mapstructure:"extra_flags"`
+
+	// Surcharges du fichier de configuration du client, lues sans passer par viper
+	// qui mettrait les clés en minuscules
+	ClientConfig map[string]interface{} `mapstructure:"-"`
+}
+
+// Resources limite les ressources du container d'un node
+type Resources struct {
+	CPUs   float64 `mapstructure:"cpus"`
+	Memory string  `mapstructure:"memory"` // 512m, 2g...
+}
+
+// DefaultTopology retourne le réseau de 5 nodes utilisé sans configuration
+func DefaultTopology() *Topology {
+	topology := &Topology{Nodes: []NodeSpec{
+		{Name: "alice", Client: entities.ClientGeth, Validator: true},
+		{Name: "bob", Client: entities.ClientGeth, Validator: true},
+		{Name: "cassandra", Client: entities.ClientNethermind, Validator: true},
+		{Name: "driss", Client: entities.ClientGeth},
+		{Name: "elena", Client: entities.ClientNethermind},
+	}}
+	topology.assignPorts()
+	return topology
+}
+
+// LoadTopology lit la topologie depuis la configuration viper, ou retourne
+// la topologie par défaut si aucune n'est déclarée
+func LoadTopology() (*Topology, error) {
+	topology, err := readTopology()
+	if err != nil {
+		return nil, err
+	}
+	if err := topology.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid topology in %s: %w", viper.ConfigFileUsed(), err)
+	}
+	return topology, nil
+}
+
+// readTopology lit la topologie et attribue les ports manquants, sans la valider
+func readTopology() (*Topology, error) {
+	if !viper.IsSet("topology.nodes") {
+		return DefaultTopology(), nil
+	}
+
+	var topology Topology
+	if err := viper.UnmarshalKey("topology", &topology); err != nil {
+		return nil, fmt.Errorf("failed to read topology: %w", err)
+	}
+	topology.assignPorts()
+	if err := topology.loadClientConfigs(viper.ConfigFileUsed()); err != nil {
+		return nil, err
+	}
+	return &topology, nil
+}
+
+// loadClientConfigs relit les sections client_config du fichier en conservant la casse des clés
+func (t *Topology) loadClientConfigs(configFile string) error {
+	if configFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", configFile, err)
+	}
+
+	var raw struct {
+		Topology struct {
+			Nodes []struct {
+				ClientConfig map[string]interface{} `yaml:"client_config"`
+			} `yaml:"nodes"`
+		} `yaml:"topology"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", configFile, err)
+	}
+	for i, node := range raw.Topology.Nodes {
+		if i < len(t.Nodes) {
+			t.Nodes[i].ClientConfig = node.ClientConfig
+		}
+	}
+	return nil
+}
+
+// assignPorts normalise les noms et attribue les ports manquants selon la position du node
+func (t *Topology) assignPorts() {
+	for i := range t.Nodes {
+		node := &t.Nodes[i]
+		node.Name = strings.ToLower(strings.TrimSpace(node.Name))
+		if node.Port == 0 {
+			node.Port = basePort + i
+		}
+		if node.RPCPort == 0 {
+			node.RPCPort = baseRPCPort + i
+		}
+		if node.WSPort == 0 {
+			node.WSPort = node.RPCPort + wsPortShift
+		}
+	}
+}
+
+// shiftPorts décale tous les ports pour qu'ils ne chevauchent pas ceux d'un autre réseau
+func (t *Topology) shiftPorts(offset int) {
+	for i := range t.Nodes {
+		t.Nodes[i].Port += offset
+		t.Nodes[i].RPCPort += offset
+		t.Nodes[i].WSPort += offset
+	}
+}
+
+// Validate vérifie la cohérence de la topologie et signale tous ses problèmes à la fois
+func (t *Topology) Validate() error {
+	report := &ValidationReport{}
+	t.check(report)
+	return report.Err()
+}
+
+// check ajoute au rapport les problèmes de noms, de clients, de ports et de validateurs
+func (t *Topology) check(report *ValidationReport) {
+	if len(t.Nodes) == 0 {
+		report.Errorf("no nodes declared")
+		return
+	}
+
+	names := make(map[string]bool)
+	ports := make(map[int]string)
+	validators := 0
+	for i, node := range t.Nodes {
+		label := node.Name
+		switch {
+		case node.Name == "":
+			label = fmt.Sprintf("#%d", i+1)
+			report.Errorf("node %s has no name", label)
+		case !nodeNamePattern.MatchString(node.Name):
+			report.Errorf("node name '%s' is not a valid container name (letters, digits, '-', '_' or '.')", node.Name)
+		case names[node.Name]:
+			report.Errorf("duplicate node name '%s'", node.Name)
+		}
+		names[node.Name] = true
+
+		if node.Client == "" {
+			report.Errorf("node %s has no client", label)
+		} else if !node.Client.IsKnown() {
+			report.Errorf("node %s has unknown client '%s' (supported: %s)", label, node.Client, clientNames())
+		}
+		if node.Validator {
+			validators++
+		}
+		if _, err := node.MemoryBytes(); err != nil {
+			report.Errorf("node %s: %v", label, err)
+		}
+		if node.Resources.CPUs < 0 {
+			report.Errorf("node %s: cpus must be positive", label)
+		}
+
+		for _, port := range []int{node.Port, node.RPCPort, node.WSPort} {
+			if port < 1 || port > 65535 {
+				report.Errorf("node %s: port %d is out of range 1-65535", label, port)
+				continue
+			}
+			if port < 1024 {
+				report.Warnf("node %s: port %d is privileged and may need root", label, port)
+			}
+			if owner, used := ports[port]; used {
+				report.Errorf("port %d is used by both %s and %s", port, owner, label)
+			}
+			ports[port] = label
+		}
+	}
+
+	checkQuorum(report, validators)
+}
+
+// checkQuorum vérifie que Clique peut sceller : il faut floor(n/2)+1 validateurs en ligne
+func checkQuorum(report *ValidationReport, validators int) {
+	if validators == 0 {
+		report.Errorf("at least one validator is required")
+		return
+	}
+	quorum := validators/2 + 1
+	switch tolerance := validators - quorum; {
+	case validators == 1:
+		report.Warnf("a single validator seals every block: the network halts if it stops")
+	case tolerance == 0:
+		report.Warnf("%d validators need %d online to seal: the network halts if any validator stops, use at least 3", validators, quorum)
+	}
+}
+
+// clientNames liste les clients supportés pour les messages d'erreur
+func clientNames() string {
+	names := make([]string, len(entities.ClientTypes))
+	for i, client := range entities.ClientTypes {
+		names[i] = string(client)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Node retourne un node de la topologie par son nom
+func (t *Topology) Node(name string) (*NodeSpec, bool) {
+	for i := range t.Nodes {
+		if t.Nodes[i].Name == name {
+			return &t.Nodes[i], true
+		}
+	}
+	return nil, false
+}
+
+// Names retourne les noms des nodes
+func (t *Topology) Names() []string {
+	names := make([]string, len(t.Nodes))
+	for i, node := range t.Nodes {
+		names[i] = node.Name
+	}
+	return names
+}
+
+// Validators retourne les noms des validateurs
+func (t *Topology) Validators() []string {
+	var names []string
+	for _, node := range t.Nodes {
+		if node.Validator {
+			names = append(names, node.Name)
+		}
+	}
+	return names
+}
+
+// Clients retourne les clients utilisés, dans l'ordre d'apparition
+func (t *Topology) Clients() []entities.ClientType {
+	seen := make(map[entities.ClientType]bool)
+	var clients []entities.ClientType
+	for _, node := range t.Nodes {
+		if !seen[node.Client] {
+			seen[node.Client] = true
+			clients = append(clients, node.Client)
+		}
+	}
+	return clients
+}
+
+// Summary décrit la topologie pour l'affichage
+func (t *Topology) Summary() []string {
+	clients := make([]string, 0)
+	for _, client := range t.Clients() {
+		clients = append(clients, string(client))
+	}
+	return []string{
+		fmt.Sprintf("%d nodes: %s", len(t.Nodes), strings.Join(t.Names(), ", ")),
+		fmt.Sprintf("%d validators: %s", len(t.Validators()), strings.Join(t.Validators(), ", ")),
+		fmt.Sprintf("Clients: %s", strings.Join(clients, " + ")),
+	}
+}
+
+// MemoryBytes convertit la limite mémoire (512m, 2g, 1024k ou octets) en octets, 0 = illimitée
+func (n *NodeSpec) MemoryBytes() (int64, error) {
+	memory := strings.ToLower(strings.TrimSpace(n.Resources.Memory))
+	if memory == "" {
+		return 0, nil
+	}
+
+	multiplier := int64(1)
+	switch memory[len(memory)-1] {
+	case 'k':
+		multiplier = 1 << 10
+	case 'm':
+		multiplier = 1 << 20
+	case 'g':
+		multiplier = 1 << 30
+	}
+	if multiplier > 1 {
+		memory = memory[:len(memory)-1]
+	}
+
+	value, err := strconv.ParseInt(memory, 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid memory limit '%s'", n.Resources.Memory)
+	}
+	return value * multiplier, nil
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"benchy/internal/domain/entities"
+	"github.com/spf13/viper"
+)
+
+// useConfig charge content comme .benchy.yaml dans viper le temps du test
+func useConfig(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".benchy.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+}
+
+func TestLoadTopology(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		wantNodes []NodeSpec // Seuls Name, Client, Validator et les ports sont comparés
+		wantErr   []string   // Fragments attendus dans l'erreur
+	}{
+		{
+			name:   "default topology without a topology section",
+			config: "keys:\n  accounts: 2\n",
+			wantNodes: []NodeSpec{
+				{Name: "alice", Client: entities.ClientGeth, Validator: true, Port: 30303, RPCPort: 8545, WSPort: 9545},
+				{Name: "bob", Client: entities.ClientGeth, Validator: true, Port: 30304, RPCPort: 8546, WSPort: 9546},
+				{Name: "cassandra", Client: entities.ClientNethermind, Validator: true, Port: 30305, RPCPort: 8547, WSPort: 9547},
+				{Name: "driss", Client: entities.ClientGeth, Port: 30306, RPCPort: 8548, WSPort: 9548},
+				{Name: "elena", Client: entities.ClientNethermind, Port: 30307, RPCPort: 8549, WSPort: 9549},
+			},
+		},
+		{
+			name: "missing ports follow the node position",
+			config: `topology:
+  nodes:
+    - name: " Alice "
+      client: geth
+      validator: true
+    - name: bob
+      client: besu
+      validator: true
+      rpc_port: 9000
+    - name: carol
+      client: erigon
+      validator: true
+      port: 40000
+      ws_port: 9999
+`,
+			wantNodes: []NodeSpec{
+				{Name: "alice", Client: entities.ClientGeth, Validator: true, Port: 30303, RPCPort: 8545, WSPort: 9545},
+				{Name: "bob", Client: entities.ClientBesu, Validator: true, Port: 30304, RPCPort: 9000, WSPort: 10000},
+				{Name: "carol", Client: entities.ClientErigon, Validator: true, Port: 40000, RPCPort: 8547, WSPort: 9999},
+			},
+		},
+		{
+			name: "every problem is reported at once",
+			config: `topology:
+  nodes:
+    - name: alice
+      client: geth
+      resources:
+        memory: lots
+    - name: alice
+      client: parity
+    - name: "Bad Name"
+      client: geth
+      rpc_port: 8545
+`,
+			wantErr: []string{
+				"duplicate node name 'alice'",
+				"unknown client 'parity'",
+				"node name 'bad name' is not a valid container name",
+				"invalid memory limit 'lots'",
+				"port 8545 is used by both alice and bad name",
+				"at least one validator is required",
+			},
+		},
+		{
+			name: "port out of range",
+			config: `topology:
+  nodes:
+    - name: alice
+      client: geth
+      validator: true
+      port: 70000
+`,
+			wantErr: []string{"port 70000 is out of range 1-65535"},
+		},
+		{
+			name:    "empty node list",
+			config:  "topology:\n  nodes: []\n",
+			wantErr: []string{"no nodes declared"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useConfig(t, tt.config)
+			topology, err := LoadTopology()
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatalf("LoadTopology accepted an invalid topology")
+				}
+				for _, fragment := range tt.wantErr {
+					if !strings.Contains(err.Error(), fragment) {
+						t.Errorf("error %q does not mention %q", err, fragment)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadTopology: %v", err)
+			}
+
+			if len(topology.Nodes) != len(tt.wantNodes) {
+				t.Fatalf("%d nodes, want %d", len(topology.Nodes), len(tt.wantNodes))
+			}
+			for i, want := range tt.wantNodes {
+				got := topology.Nodes[i]
+				if got.Name != want.Name || got.Client != want.Client || got.Validator != want.Validator {
+					t.Errorf("node %d = %s/%s/%v, want %s/%s/%v", i, got.Name, got.Client, got.Validator, want.Name, want.Client, want.Validator)
+				}
+				if got.Port != want.Port || got.RPCPort != want.RPCPort || got.WSPort != want.WSPort {
+					t.Errorf("node %s ports = %d/%d/%d, want %d/%d/%d", got.Name, got.Port, got.RPCPort, got.WSPort, want.Port, want.RPCPort, want.WSPort)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadTopologyKeepsClientConfigCase(t *testing.T) {
+	useConfig(t, `topology:
+  nodes:
+    - name: alice
+      client: nethermind
+      validator: true
+      client_config:
+        JsonRpc:
+          EnabledModules: ["Eth", "Net"]
+`)
+	topology, err := LoadTopology()
+	if err != nil {
+		t.Fatalf("LoadTopology: %v", err)
+	}
+	section, ok := topology.Nodes[0].ClientConfig["JsonRpc"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("client_config = %v, want a JsonRpc section", topology.Nodes[0].ClientConfig)
+	}
+	if _, ok := section["EnabledModules"]; !ok {
+		t.Errorf("JsonRpc = %v, want the EnabledModules key with its case", section)
+	}
+}
+
+func TestCheckQuorum(t *testing.T) {
+	tests := []struct {
+		validators   int
+		wantErrors   int
+		wantWarnings int
+	}{
+		{validators: 0, wantErrors: 1},
+		{validators: 1, wantWarnings: 1},
+		{validators: 2, wantWarnings: 1},
+		{validators: 3},
+		{validators: 4},
+		{validators: 5},
+	}
+	for _, tt := range tests {
+		report := &ValidationReport{}
+		checkQuorum(report, tt.validators)
+		if len(report.Errors) != tt.wantErrors || len(report.Warnings) != tt.wantWarnings {
+			t.Errorf("%d validators: %d errors and %d warnings, want %d and %d",
+				tt.validators, len(report.Errors), len(report.Warnings), tt.wantErrors, tt.wantWarnings)
+		}
+	}
+}
+
+func TestNodeSpecMemoryBytes(t *testing.T) {
+	tests := []struct {
+		memory  string
+		want    int64
+		wantErr bool
+	}{
+		{memory: "", want: 0},
+		{memory: "1024", want: 1024},
+		{memory: "512k", want: 512 << 10},
+		{memory: "512m", want: 512 << 20},
+		{memory: " 2G ", want: 2 << 30},
+		{memory: "-1m", wantErr: true},
+		{memory: "lots", wantErr: true},
+	}
+	for _, tt := range tests {
+		node := NodeSpec{Resources: Resources{Memory: tt.memory}}
+		got, err := node.MemoryBytes()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("MemoryBytes(%q) error = %v, wantErr %v", tt.memory, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("MemoryBytes(%q) = %d, want %d", tt.memory, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+)
+
+// ValidationReport rassemble tous les problèmes d'une configuration au lieu de s'arrêter au premier
+type ValidationReport struct {
+	Errors   []string
+	Warnings []string
+}
+
+// Errorf ajoute un problème bloquant
+func (r *ValidationReport) Errorf(format string, args ...interface{}) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+// Warnf ajoute un problème qui n'empêche pas le lancement
+func (r *ValidationReport) Warnf(format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// OK indique qu'aucun problème bloquant n'a été trouvé
+func (r *ValidationReport) OK() bool {
+	return len(r.Errors) == 0
+}
+
+// Err retourne les problèmes bloquants sous forme d'une seule erreur, nil s'il n'y en a pas
+func (r *ValidationReport) Err() error {
+	switch len(r.Errors) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%s", r.Errors[0])
+	}
+	return fmt.Errorf("%d problems:\n  - %s", len(r.Errors), strings.Join(r.Errors, "\n  - "))
+}
+
+// merge ajoute les problèmes d'un autre rapport en préfixant leur section
+func (r *ValidationReport) merge(section string, other *ValidationReport) {
+	for _, message := range other.Errors {
+		r.Errorf("%s: %s", section, message)
+	}
+	for _, message := range other.Warnings {
+		r.Warnf("%s: %s", section, message)
+	}
+}
+
+// Validate vérifie toute la configuration du réseau (topologie, clés, genesis, monitoring) sans s'arrêter
+// au premier problème ; la topologie lue est retournée pour les vérifications des clients
+func (ncm *NodeConfigManager) Validate() (*Topology, *ValidationReport) {
+	report := &ValidationReport{}
+	network, err := ncm.Network()
+	if err != nil {
+		report.Errorf("network: %v", err)
+		return nil, report
+	}
+
+	topology, err := readTopology()
+	if err != nil {
+		report.Errorf("topology: %v", err)
+	} else {
+		topology.shiftPorts(network.PortOffset)
+		section := &ValidationReport{}
+		topology.check(section)
+		report.merge("topology", section)
+		checkPortConflicts(report, network, topology)
+	}
+
+	keys, err := readKeysConfig()
+	if err != nil {
+		report.Errorf("keys: %v", err)
+	} else {
+		section := &ValidationReport{}
+		keys.check(section)
+		report.merge("keys", section)
+	}
+
+	genesis, err := readGenesisConfig()
+	if err != nil {
+		report.Errorf("genesis: %v", err)
+	} else {
+		if !viper.IsSet("genesis.chain_id") {
+			genesis.ChainID += uint64(network.Slot)
+		}
+		section := &ValidationReport{}
+		genesis.check(section)
+		report.merge("genesis", section)
+	}
+
+	monitoring, err := readMonitoringConfig()
+	if err != nil {
+		report.Errorf("monitoring: %v", err)
+	} else {
+		section := &ValidationReport{}
+		monitoring.check(section)
+		report.merge("monitoring", section)
+	}
+
+	if topology != nil && genesis != nil {
+		if keys != nil && report.OK() {
+			checkAllocOverrides(report, topology, keys, genesis)
+		}
+	}
+	return topology, report
+}
+
+// checkAllocOverrides signale les comptes de alloc_file qui remplacent le solde d'un node :
+// les adresses ne sont connues à l'avance qu'avec des clés déterministes
+func checkAllocOverrides(report *ValidationReport, topology *Topology, keys *KeysConfig, genesis *GenesisConfig) {
+	deriver, err := NewKeyDeriver(keys)
+	if err != nil || deriver == nil {
+		return
+	}
+	alloc, err := genesis.LoadAlloc()
+	if err != nil || len(alloc) == 0 {
+		return
+	}
+
+	owners := make(map[common.Address]string)
+	for i, node := range topology.Nodes {
+		if keyPair, err := deriver.Derive(keys.Path, i); err == nil {
+			owners[keyPair.Address] = "node " + node.Name
+		}
+	}
+	for i := 0; i < keys.Accounts; i++ {
+		if keyPair, err := deriver.Derive(keys.AccountsPath, i); err == nil {
+			owners[keyPair.Address] = fmt.Sprintf("account-%d", i)
+		}
+	}
+	for address := range alloc {
+		if owner, ok := owners[address]; ok {
+			report.Warnf("genesis: alloc_file overrides the balance of %s (%s)", owner, address.Hex())
+		}
+	}
+}
+
+// checkPortConflicts signale les ports déjà pris par un autre réseau lancé
+func checkPortConflicts(report *ValidationReport, network *NetworkProfile, topology *Topology) {
+	networks, err := ListNetworks(network.Root)
+	if err != nil {
+		report.Warnf("networks: %v", err)
+		return
+	}
+
+	ports := make(map[int]string)
+	for _, node := range topology.Nodes {
+		for _, port := range []int{node.Port, node.RPCPort, node.WSPort} {
+			ports[port] = node.Name
+		}
+	}
+	for _, other := range networks {
+		if other.Name == network.Name {
+			continue
+		}
+		manifest, err := LoadManifest(other.BaseDir)
+		if err != nil {
+			continue
+		}
+		for _, node := range manifest.Nodes {
+			for _, port := range []int{node.Port, node.RPCPort, node.WSPort} {
+				if name, used := ports[port]; used {
+					report.Errorf("topology: port %d of node %s is already used by node %s of network %s", port, name, node.Name, other.Name)
+				}
+			}
+		}
+	}
+}
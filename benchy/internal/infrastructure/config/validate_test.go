@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// mnemonicConfig est une configuration valide : topologie par défaut et clés déterministes
+const mnemonicConfig = "keys:\n  mnemonic: " + testMnemonic + "\n"
+
+func TestNodeConfigManagerValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       func(t *testing.T, dir string) string
+		network      string
+		setup        func(t *testing.T, root string)
+		wantErrors   []string // Fragments attendus, chacun dans une erreur
+		wantWarnings []string
+		wantRPCPort  int // Port RPC du premier node, décalé selon le réseau
+	}{
+		{
+			name:        "valid configuration",
+			config:      func(*testing.T, string) string { return mnemonicConfig },
+			wantRPCPort: 8545,
+		},
+		{
+			name: "problems of every section are prefixed",
+			config: func(*testing.T, string) string {
+				return `keys:
+  accounts: -1
+genesis:
+  chain_id: 0
+  epoch: 0
+monitoring:
+  interval: 10s
+  resolution: 5s
+topology:
+  nodes:
+    - name: alice
+      client: parity
+`
+			},
+			wantErrors: []string{
+				"keys: accounts must be positive",
+				"genesis: chain_id must be positive",
+				"genesis: epoch must be positive",
+				"monitoring: resolution (5s) must not be shorter than interval (10s)",
+				"topology: node alice has unknown client 'parity'",
+				"topology: at least one validator is required",
+			},
+		},
+		{
+			name: "public chain ID",
+			config: func(*testing.T, string) string {
+				return mnemonicConfig + "genesis:\n  chain_id: 1\n"
+			},
+			wantWarnings: []string{"genesis: chain_id 1 is the one of mainnet"},
+			wantRPCPort:  8545,
+		},
+		{
+			name:        "named network shifts the ports",
+			config:      func(*testing.T, string) string { return mnemonicConfig },
+			network:     "lab",
+			wantRPCPort: 8645,
+		},
+		{
+			name:   "port used by another network",
+			config: func(*testing.T, string) string { return mnemonicConfig },
+			setup: func(t *testing.T, root string) {
+				other, err := LoadNetworkProfile(root, "lab")
+				if err != nil {
+					t.Fatalf("LoadNetworkProfile: %v", err)
+				}
+				if err := other.Register(); err != nil {
+					t.Fatalf("Register: %v", err)
+				}
+				manifest := &Manifest{Version: ManifestVersion, Network: "lab", Nodes: []ManifestNode{
+					{Name: "zoe", Port: 40000, RPCPort: 8546, WSPort: 40001},
+				}}
+				if err := manifest.Save(other.BaseDir); err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+			},
+			wantErrors: []string{"topology: port 8546 of node bob is already used by node zoe of network lab"},
+		},
+		{
+			name: "alloc file overrides a node balance",
+			config: func(t *testing.T, dir string) string {
+				alloc := filepath.Join(dir, "alloc.json")
+				content := `{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266": {"balance": "0x1"}}`
+				if err := os.WriteFile(alloc, []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write alloc file: %v", err)
+				}
+				return fmt.Sprintf("%sgenesis:\n  alloc_file: %s\n", mnemonicConfig, alloc)
+			},
+			wantWarnings: []string{"genesis: alloc_file overrides the balance of node alice"},
+			wantRPCPort:  8545,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			useConfig(t, tt.config(t, t.TempDir()))
+			if tt.network != "" {
+				viper.Set("network", tt.network)
+			}
+			if tt.setup != nil {
+				tt.setup(t, filepath.Join(home, ".benchy"))
+			}
+
+			topology, report := NewNodeConfigManager(filepath.Join(home, ".benchy")).Validate()
+			checkMessages(t, "error", report.Errors, tt.wantErrors)
+			checkMessages(t, "warning", report.Warnings, tt.wantWarnings)
+			if tt.wantRPCPort != 0 && topology.Nodes[0].RPCPort != tt.wantRPCPort {
+				t.Errorf("RPC port of %s = %d, want %d", topology.Nodes[0].Name, topology.Nodes[0].RPCPort, tt.wantRPCPort)
+			}
+		})
+	}
+}
+
+// checkMessages vérifie que chaque fragment attendu apparaît dans un message, et
+// qu'aucun message n'est signalé quand rien n'est attendu
+func checkMessages(t *testing.T, kind string, messages, want []string) {
+	t.Helper()
+	if len(want) == 0 && len(messages) > 0 {
+		t.Errorf("unexpected %ss: %v", kind, messages)
+	}
+	for _, fragment := range want {
+		found := false
+		for _, message := range messages {
+			if strings.Contains(message, fragment) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("no %s mentions %q in %v", kind, fragment, messages)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AddressResolver convertit un nom (node, contrat) en adresse
+type AddressResolver func(name string) (common.Address, bool)
+
+// ParseArguments convertit des arguments saisis en ligne de commande vers les
+// types Go attendus par l'ABI. Les adresses acceptent un nom résolu par resolve,
+// les tableaux s'écrivent sous la forme "a,b,c".
+func ParseArguments(arguments abi.Arguments, raw []string, resolve AddressResolver) ([]interface{}, error) {
+	if len(raw) != len(arguments) {
+		return nil, fmt.Errorf("expected %d arguments (%s), got %d", len(arguments), describeArguments(arguments), len(raw))
+	}
+
+	values := make([]interface{}, len(arguments))
+	for i, argument := range arguments {
+		value, err := parseValue(argument.Type, raw[i], resolve)
+		if err != nil {
+			name := argument.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i)
+			}
+			return nil, fmt.Errorf("invalid argument %s (%s): %w", name, argument.Type.String(), err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// parseValue convertit une valeur textuelle pour un type ABI
+func parseValue(typ abi.Type, raw string, resolve AddressResolver) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+
+	switch typ.T {
+	case abi.AddressTy:
+		return parseAddress(raw, resolve)
+	case abi.BoolTy:
+		return strconv.ParseBool(raw)
+	case abi.StringTy:
+		return raw, nil
+	case abi.IntTy, abi.UintTy:
+		return parseInteger(typ, raw)
+	case abi.BytesTy:
+		return hexutil.Decode(raw)
+	case abi.FixedBytesTy:
+		data, err := hexutil.Decode(raw)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > typ.Size {
+			return nil, fmt.Errorf("value longer than %d bytes", typ.Size)
+		}
+		array := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(array, reflect.ValueOf(common.RightPadBytes(data, typ.Size)))
+		return array.Interface(), nil
+	case abi.SliceTy, abi.ArrayTy:
+		return parseList(typ, raw, resolve)
+	default:
+		return nil, fmt.Errorf("type not supported from the command line")
+	}
+}
+
+// parseList convertit "a,b,c" (crochets optionnels) en slice ou tableau typé
+func parseList(typ abi.Type, raw string, resolve AddressResolver) (interface{}, error) {
+	raw = strings.TrimSuffix(strings.TrimPrefix(raw, "["), "]")
+
+	var items []string
+	if raw != "" {
+		items = strings.Split(raw, ",")
+	}
+
+	if typ.T == abi.ArrayTy && len(items) != typ.Size {
+		return nil, fmt.Errorf("expected %d elements, got %d", typ.Size, len(items))
+	}
+
+	var list reflect.Value
+	if typ.T == abi.ArrayTy {
+		list = reflect.New(typ.GetType()).Elem()
+	} else {
+		list = reflect.MakeSlice(typ.GetType(), len(items), len(items))
+	}
+
+	for i, item := range items {
+		value, err := parseValue(*typ.Elem, item, resolve)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		list.Index(i).Set(reflect.ValueOf(value))
+	}
+	return list.Interface(), nil
+}
+
+// parseAddress accepte une adresse hexadécimale ou un nom connu
+func parseAddress(raw string, resolve AddressResolver) (common.Address, error) {
+	if common.IsHexAddress(raw) {
+		return common.HexToAddress(raw), nil
+	}
+	if resolve != nil {
+		if address, ok := resolve(raw); ok {
+			return address, nil
+		}
+	}
+	return common.Address{}, fmt.Errorf("unknown address or name '%s'", raw)
+}
+
+// parseInteger accepte le décimal, l'hexadécimal (0x...) et la notation 1e18
+func parseInteger(typ abi.Type, raw string) (interface{}, error) {
+	value, err := ParseAmount(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ.T == abi.UintTy {
+		if value.Sign() < 0 {
+			return nil, fmt.Errorf("negative value for unsigned type")
+		}
+		if value.BitLen() > typ.Size {
+			return nil, fmt.Errorf("value overflows %d bits", typ.Size)
+		}
+	} else {
+		// intN couvre [-2^(N-1), 2^(N-1)-1]
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(typ.Size-1))
+		if value.Cmp(new(big.Int).Neg(limit)) < 0 || value.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("value out of range for int%d", typ.Size)
+		}
+	}
+
+	// L'ABI exige les types natifs jusqu'à 64 bits, *big.Int au-delà
+	switch {
+	case typ.T == abi.UintTy && typ.Size == 8:
+		return uint8(value.Uint64()), nil
+	case typ.T == abi.UintTy && typ.Size == 16:
+		return uint16(value.Uint64()), nil
+	case typ.T == abi.UintTy && typ.Size == 32:
+		return uint32(value.Uint64()), nil
+	case typ.T == abi.UintTy && typ.Size == 64:
+		return value.Uint64(), nil
+	case typ.T == abi.IntTy && typ.Size == 8:
+		return int8(value.Int64()), nil
+	case typ.T == abi.IntTy && typ.Size == 16:
+		return int16(value.Int64()), nil
+	case typ.T == abi.IntTy && typ.Size == 32:
+		return int32(value.Int64()), nil
+	case typ.T == abi.IntTy && typ.Size == 64:
+		return value.Int64(), nil
+	default:
+		return value, nil
+	}
+}
+
+// ParseAmount convertit un entier décimal, hexadécimal (0x...) ou en notation
+// scientifique (1e18, 2.5e18) en *big.Int
+func ParseAmount(raw string) (*big.Int, error) {
+	if strings.HasPrefix(raw, "0x") || strings.HasPrefix(raw, "0X") {
+		return hexutil.DecodeBig(raw)
+	}
+
+	if value, ok := new(big.Int).SetString(raw, 10); ok {
+		return value, nil
+	}
+
+	if strings.ContainsAny(raw, "eE") {
+		float, ok := new(big.Float).SetPrec(256).SetString(raw)
+		if ok && float.IsInt() {
+			value, _ := float.Int(nil)
+			return value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid integer '%s'", raw)
+}
+
+// FormatValue formate une valeur décodée pour l'affichage
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return hexutil.Encode(v)
+	case common.Hash:
+		return v.Hex()
+	}
+
+	// Tableaux d'octets de taille fixe (bytes32, ...)
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(data), rv)
+		return hexutil.Encode(data)
+	}
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		items := make([]string, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			items[i] = FormatValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+// describeArguments retourne la signature lisible d'une liste d'arguments
+func describeArguments(arguments abi.Arguments) string {
+	parts := make([]string, len(arguments))
+	for i, argument := range arguments {
+		parts[i] = strings.TrimSpace(argument.Type.String() + " " + argument.Name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatUnits formate un montant entier selon ses décimales (1500000000000000000, 18 → "1.5")
+func FormatUnits(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+
+	sign := ""
+	value := new(big.Int).Set(amount)
+	if value.Sign() < 0 {
+		sign = "-"
+		value.Neg(value)
+	}
+
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, fraction := new(big.Int).QuoRem(value, unit, new(big.Int))
+	if fraction.Sign() == 0 {
+		return sign + whole.String()
+	}
+
+	digits := fraction.String()
+	digits = strings.Repeat("0", int(decimals)-len(digits)) + digits
+	return sign + whole.String() + "." + strings.TrimRight(digits, "0")
+}
@@ -0,0 +1,88 @@
+package contracts
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// mustType construit un type ABI à partir de sa signature
+func mustType(t *testing.T, signature string) abi.Type {
+	t.Helper()
+	typ, err := abi.NewType(signature, "", nil)
+	if err != nil {
+		t.Fatalf("abi.NewType(%s): %v", signature, err)
+	}
+	return typ
+}
+
+func TestParseArguments(t *testing.T) {
+	alice := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	resolve := func(name string) (common.Address, bool) {
+		return alice, name == "alice"
+	}
+
+	tests := []struct {
+		typ     string
+		raw     string
+		want    interface{}
+		wantErr string
+	}{
+		{typ: "uint8", raw: "255", want: uint8(255)},
+		{typ: "uint8", raw: "256", wantErr: "overflows 8 bits"},
+		{typ: "uint8", raw: "-1", wantErr: "negative value"},
+		{typ: "uint64", raw: "0x10", want: uint64(16)},
+		{typ: "uint256", raw: "1e18", want: big.NewInt(1e18)},
+		{typ: "uint256", raw: "2.5e18", want: big.NewInt(25e17)},
+		{typ: "int8", raw: "127", want: int8(127)},
+		{typ: "int8", raw: "-128", want: int8(-128)},
+		{typ: "int8", raw: "128", wantErr: "out of range for int8"},
+		{typ: "int8", raw: "200", wantErr: "out of range for int8"},
+		{typ: "int8", raw: "-129", wantErr: "out of range for int8"},
+		{typ: "int8", raw: "-200", wantErr: "out of range for int8"},
+		{typ: "int64", raw: "-9223372036854775808", want: int64(-9223372036854775808)},
+		{typ: "int256", raw: "-1", want: big.NewInt(-1)},
+		{typ: "bool", raw: "true", want: true},
+		{typ: "string", raw: " hello ", want: "hello"},
+		{typ: "address", raw: "alice", want: alice},
+		{typ: "address", raw: alice.Hex(), want: alice},
+		{typ: "address", raw: "mallory", wantErr: "unknown address or name 'mallory'"},
+		{typ: "bytes", raw: "0x0102", want: []byte{1, 2}},
+		{typ: "bytes2", raw: "0x01", want: [2]byte{1, 0}},
+		{typ: "bytes2", raw: "0x010203", wantErr: "longer than 2 bytes"},
+		{typ: "uint16[]", raw: "[1,2,3]", want: []uint16{1, 2, 3}},
+		{typ: "address[2]", raw: "alice,alice", want: [2]common.Address{alice, alice}},
+		{typ: "address[2]", raw: "alice", wantErr: "expected 2 elements, got 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ+" "+tt.raw, func(t *testing.T) {
+			arguments := abi.Arguments{{Name: "value", Type: mustType(t, tt.typ)}}
+			values, err := ParseArguments(arguments, []string{tt.raw}, resolve)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseArguments error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseArguments: %v", err)
+			}
+			if !reflect.DeepEqual(values[0], tt.want) {
+				t.Errorf("ParseArguments = %#v, want %#v", values[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArgumentsCount(t *testing.T) {
+	arguments := abi.Arguments{{Name: "to", Type: mustType(t, "address")}, {Name: "amount", Type: mustType(t, "uint256")}}
+	_, err := ParseArguments(arguments, []string{"alice"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "expected 2 arguments (address to, uint256 amount), got 1") {
+		t.Fatalf("ParseArguments error = %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package contracts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Artifact représente un contrat compilé (ABI + bytecode)
+type Artifact struct {
+	Name     string
+	ABI      abi.ABI
+	Bytecode []byte
+	Source   string // Fichier d'origine de l'artifact
+}
+
+// rawArtifact couvre les formats produits par Foundry, Hardhat et solc
+type rawArtifact struct {
+	ContractName string          `json:"contractName"`
+	ABI          json.RawMessage `json:"abi"`
+	Bytecode     json.RawMessage `json:"bytecode"`
+	Bin          string          `json:"bin"`
+	EVM          struct {
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	} `json:"evm"`
+}
+
+// combinedJSON correspond à la sortie de `solc --combined-json abi,bin`
+type combinedJSON struct {
+	Contracts map[string]rawArtifact `json:"contracts"`
+}
+
+// ParseArtifacts lit un fichier d'artifact et retourne les contrats qu'il contient.
+// Formats supportés :
+//   - Foundry : {"abi": [...], "bytecode": {"object": "0x..."}}
+//   - Hardhat : {"contractName": "...", "abi": [...], "bytecode": "0x..."}
+//   - solc standard JSON : {"abi": [...], "evm": {"bytecode": {"object": "..."}}}
+//   - solc --combined-json : {"contracts": {"file.sol:Name": {"abi": ..., "bin": "..."}}}
+func ParseArtifacts(source string, data []byte) ([]*Artifact, error) {
+	var combined combinedJSON
+	if err := json.Unmarshal(data, &combined); err == nil && len(combined.Contracts) > 0 {
+		var artifacts []*Artifact
+		for fullName, raw := range combined.Contracts {
+			name := fullName
+			if idx := strings.LastIndex(fullName, ":"); idx >= 0 {
+				name = fullName[idx+1:]
+			}
+			artifact, err := raw.toArtifact(name, source)
+			if err != nil {
+				return nil, fmt.Errorf("invalid contract %s: %w", fullName, err)
+			}
+			artifacts = append(artifacts, artifact)
+		}
+		return artifacts, nil
+	}
+
+	var raw rawArtifact
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse artifact %s: %w", source, err)
+	}
+
+	// Sans nom explicite, le nom du fichier fait foi (Foundry : out/Token.sol/Token.json)
+	name := raw.ContractName
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+	}
+
+	artifact, err := raw.toArtifact(name, source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact %s: %w", source, err)
+	}
+	return []*Artifact{artifact}, nil
+}
+
+// toArtifact convertit le format brut en Artifact
+func (raw rawArtifact) toArtifact(name, source string) (*Artifact, error) {
+	if len(raw.ABI) == 0 {
+		return nil, fmt.Errorf("missing abi")
+	}
+
+	// solc --combined-json encode parfois l'ABI comme une chaîne JSON
+	abiJSON := []byte(raw.ABI)
+	var abiString string
+	if err := json.Unmarshal(raw.ABI, &abiString); err == nil {
+		abiJSON = []byte(abiString)
+	}
+
+	parsedABI, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return nil, fmt.Errorf("invalid abi: %w", err)
+	}
+
+	bytecodeHex, err := raw.bytecodeHex()
+	if err != nil {
+		return nil, err
+	}
+
+	var bytecode []byte
+	if bytecodeHex != "" {
+		bytecode, err = hexutil.Decode("0x" + strings.TrimPrefix(bytecodeHex, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytecode (unlinked libraries?): %w", err)
+		}
+	}
+
+	return &Artifact{
+		Name:     name,
+		ABI:      parsedABI,
+		Bytecode: bytecode,
+		Source:   source,
+	}, nil
+}
+
+// bytecodeHex retourne le bytecode de déploiement quel que soit le format
+func (raw rawArtifact) bytecodeHex() (string, error) {
+	if raw.Bin != "" {
+		return raw.Bin, nil
+	}
+	if raw.EVM.Bytecode.Object != "" {
+		return raw.EVM.Bytecode.Object, nil
+	}
+	if len(raw.Bytecode) == 0 {
+		return "", nil
+	}
+
+	// Hardhat : chaîne ; Foundry : objet {"object": "0x..."}
+	var bytecode string
+	if err := json.Unmarshal(raw.Bytecode, &bytecode); err == nil {
+		return bytecode, nil
+	}
+
+	var object struct {
+		Object string `json:"object"`
+	}
+	if err := json.Unmarshal(raw.Bytecode, &object); err != nil {
+		return "", fmt.Errorf("unsupported bytecode format")
+	}
+	return object.Object, nil
+}
+
+// IsDeployable indique si l'artifact contient du bytecode de déploiement
+func (a *Artifact) IsDeployable() bool {
+	return len(a.Bytecode) > 0
+}
+
+// IsERC20 indique si l'ABI expose l'interface ERC20 utilisée par benchy
+func (a *Artifact) IsERC20() bool {
+	for _, method := range []string{"balanceOf", "transfer", "approve", "transferFrom", "allowance", "decimals", "symbol"} {
+		if _, exists := a.ABI.Methods[method]; !exists {
+			return false
+		}
+	}
+	return true
+}
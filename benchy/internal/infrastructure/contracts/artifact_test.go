@@ -0,0 +1,112 @@
+package contracts
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Fragment d'ABI commun aux artifacts de test
+const testABI = `[{"type":"function","name":"get","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}]`
+
+func TestParseArtifacts(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		data      string
+		wantNames []string
+		wantCode  bool
+		wantErr   string
+	}{
+		{
+			name:      "foundry",
+			source:    "out/Counter.sol/Counter.json",
+			data:      `{"abi":` + testABI + `,"bytecode":{"object":"0x6001"}}`,
+			wantNames: []string{"Counter"},
+			wantCode:  true,
+		},
+		{
+			name:      "hardhat",
+			source:    "artifacts/Counter.json",
+			data:      `{"contractName":"Store","abi":` + testABI + `,"bytecode":"0x6001"}`,
+			wantNames: []string{"Store"},
+			wantCode:  true,
+		},
+		{
+			name:      "solc standard json",
+			source:    "Counter.json",
+			data:      `{"abi":` + testABI + `,"evm":{"bytecode":{"object":"6001"}}}`,
+			wantNames: []string{"Counter"},
+			wantCode:  true,
+		},
+		{
+			name:      "solc combined json with string abi",
+			source:    "combined.json",
+			data:      `{"contracts":{"a.sol:First":{"abi":` + quoteJSON(testABI) + `,"bin":"6001"},"b.sol:Second":{"abi":` + testABI + `,"bin":"6002"}}}`,
+			wantNames: []string{"First", "Second"},
+			wantCode:  true,
+		},
+		{
+			name:      "interface without bytecode",
+			source:    "IERC20.json",
+			data:      `{"abi":` + testABI + `}`,
+			wantNames: []string{"IERC20"},
+		},
+		{
+			name:    "missing abi",
+			source:  "Broken.json",
+			data:    `{"bytecode":"0x6001"}`,
+			wantErr: "missing abi",
+		},
+		{
+			name:    "unlinked library placeholder",
+			source:  "Linked.json",
+			data:    `{"abi":` + testABI + `,"bytecode":"0x60__$lib$__"}`,
+			wantErr: "unlinked libraries",
+		},
+		{
+			name:    "not json",
+			source:  "Garbage.json",
+			data:    `garbage`,
+			wantErr: "failed to parse artifact",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifacts, err := ParseArtifacts(tt.source, []byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseArtifacts error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseArtifacts: %v", err)
+			}
+
+			var names []string
+			for _, artifact := range artifacts {
+				names = append(names, artifact.Name)
+				if artifact.IsDeployable() != tt.wantCode {
+					t.Errorf("%s deployable = %v, want %v", artifact.Name, artifact.IsDeployable(), tt.wantCode)
+				}
+				if _, exists := artifact.ABI.Methods["get"]; !exists {
+					t.Errorf("%s: method get missing from the ABI", artifact.Name)
+				}
+				if artifact.Source != tt.source {
+					t.Errorf("%s source = %s, want %s", artifact.Name, artifact.Source, tt.source)
+				}
+			}
+			sort.Strings(names)
+			if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("names = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
+
+// quoteJSON encode une chaîne comme valeur JSON
+func quoteJSON(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
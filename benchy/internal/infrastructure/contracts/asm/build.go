@@ -0,0 +1,83 @@
+//go:build ignore
+
+// build assemble le token ERC20 embarqué et écrit artifacts/ERC20.json.
+// Lancé depuis le package contracts via `go generate`.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/asm"
+)
+
+// erc20ABI décrit l'interface ERC20 standard implémentée par l'assembleur
+const erc20ABI = `[
+	{"type":"constructor","stateMutability":"nonpayable","inputs":[{"name":"name","type":"string"},{"name":"symbol","type":"string"},{"name":"initialSupply","type":"uint256"}]},
+	{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","stateMutability":"nonpayable","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Approval","anonymous":false,"inputs":[{"name":"owner","type":"address","indexed":true},{"name":"spender","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func main() {
+	runtime, err := assemble("asm/erc20_runtime.easm", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	runtimeSize := strconv.Itoa(len(runtime) / 2)
+	init, err := assemble("asm/erc20_init.easm", strings.NewReplacer("{{RUNTIME_SIZE}}", runtimeSize))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	artifact := struct {
+		ContractName string          `json:"contractName"`
+		ABI          json.RawMessage `json:"abi"`
+		Bytecode     string          `json:"bytecode"`
+	}{
+		ContractName: "ERC20",
+		ABI:          json.RawMessage(erc20ABI),
+		Bytecode:     "0x" + init + runtime,
+	}
+
+	data, err := json.MarshalIndent(artifact, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("artifacts/ERC20.json", append(data, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("ERC20: %s bytes of runtime code\n", runtimeSize)
+}
+
+// assemble compile un fichier d'assembleur EVM et retourne son bytecode hexadécimal
+func assemble(path string, replacer *strings.Replacer) (string, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if replacer != nil {
+		source = []byte(replacer.Replace(string(source)))
+	}
+
+	compiler := asm.NewCompiler(false)
+	compiler.Feed(asm.Lex(source, false))
+	bytecode, errs := compiler.Compile()
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%s: %v", path, errs)
+	}
+	return bytecode, nil
+}
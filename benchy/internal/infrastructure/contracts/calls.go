@@ -0,0 +1,61 @@
+package contracts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DescribeCall décrit un appel de méthode à partir de son calldata, par
+// exemple transfer(to=bob, amount=1000). L'ABI est cherchée comme pour DecodeLog.
+func (r *Registry) DescribeCall(network string, to common.Address, input []byte, label AddressLabeler) string {
+	if len(input) == 0 {
+		return ""
+	}
+	if len(input) < 4 {
+		return hexutil.Encode(input)
+	}
+
+	if deployment, exists := r.deployments.FindByAddress(network, to); exists {
+		if artifact, exists := r.artifacts[strings.ToLower(deployment.Artifact)]; exists {
+			if description, err := describeMethod(artifact.ABI, input, label); err == nil {
+				return description
+			}
+		}
+	}
+
+	for _, artifact := range r.Artifacts() {
+		if description, err := describeMethod(artifact.ABI, input, label); err == nil {
+			return description
+		}
+	}
+	return hexutil.Encode(input[:4])
+}
+
+// describeMethod décode le sélecteur et les arguments d'un calldata
+func describeMethod(contractABI abi.ABI, input []byte, label AddressLabeler) (string, error) {
+	method, err := contractABI.MethodById(input[:4])
+	if err != nil {
+		return "", err
+	}
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, len(values))
+	for i, value := range values {
+		text := FormatValue(value)
+		if address, ok := value.(common.Address); ok && label != nil {
+			text = label(address)
+		}
+		if method.Inputs[i].Name != "" {
+			text = method.Inputs[i].Name + "=" + text
+		}
+		parts[i] = text
+	}
+	return fmt.Sprintf("%s(%s)", method.RawName, strings.Join(parts, ", ")), nil
+}
@@ -0,0 +1,130 @@
+package contracts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Deployment représente un contrat déployé sur un réseau
+type Deployment struct {
+	Name        string         `json:"name"`
+	Artifact    string         `json:"artifact"`
+	Address     common.Address `json:"address"`
+	TxHash      common.Hash    `json:"tx_hash"`
+	Deployer    common.Address `json:"deployer"`
+	BlockNumber uint64         `json:"block_number"`
+	DeployedAt  time.Time      `json:"deployed_at"`
+}
+
+// legacyNetworkKey est la clé commune sous laquelle les déploiements étaient mémorisés
+// avant que chaque réseau n'utilise son nom
+const legacyNetworkKey = "benchy-network"
+
+// DeploymentBook mémorise les adresses des contrats déployés, par réseau
+type DeploymentBook struct {
+	path     string
+	Networks map[string]map[string]*Deployment `json:"networks"`
+}
+
+// LoadDeploymentBook charge le carnet de déploiements (vide s'il n'existe pas)
+func LoadDeploymentBook(path string) (*DeploymentBook, error) {
+	book := &DeploymentBook{
+		path:     path,
+		Networks: make(map[string]map[string]*Deployment),
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return book, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployments: %w", err)
+	}
+
+	if err := json.Unmarshal(data, book); err != nil {
+		return nil, fmt.Errorf("failed to parse deployments %s: %w", path, err)
+	}
+	if book.Networks == nil {
+		book.Networks = make(map[string]map[string]*Deployment)
+	}
+	return book, nil
+}
+
+// Save sauvegarde le carnet de déploiements
+func (db *DeploymentBook) Save() error {
+	if err := os.MkdirAll(filepath.Dir(db.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(db, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal deployments: %w", err)
+	}
+
+	if err := os.WriteFile(db.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write deployments: %w", err)
+	}
+	return nil
+}
+
+// AdoptLegacy rattache au réseau les déploiements mémorisés sous l'ancienne clé commune :
+// chaque réseau a son propre carnet, ils lui appartiennent donc tous
+func (db *DeploymentBook) AdoptLegacy(network string) {
+	legacy, exists := db.Networks[legacyNetworkKey]
+	if !exists || network == legacyNetworkKey {
+		return
+	}
+	if db.Networks[network] == nil {
+		db.Networks[network] = make(map[string]*Deployment)
+	}
+	for name, deployment := range legacy {
+		if _, taken := db.Networks[network][name]; !taken {
+			db.Networks[network][name] = deployment
+		}
+	}
+	delete(db.Networks, legacyNetworkKey)
+}
+
+// Record enregistre un déploiement et sauvegarde le carnet
+func (db *DeploymentBook) Record(network string, deployment *Deployment) error {
+	if db.Networks[network] == nil {
+		db.Networks[network] = make(map[string]*Deployment)
+	}
+	db.Networks[network][strings.ToLower(deployment.Name)] = deployment
+	return db.Save()
+}
+
+// Get retourne un déploiement par son nom
+func (db *DeploymentBook) Get(network, name string) (*Deployment, bool) {
+	deployment, exists := db.Networks[network][strings.ToLower(name)]
+	return deployment, exists
+}
+
+// FindByAddress retourne le déploiement correspondant à une adresse
+func (db *DeploymentBook) FindByAddress(network string, address common.Address) (*Deployment, bool) {
+	for _, deployment := range db.Networks[network] {
+		if deployment.Address == address {
+			return deployment, true
+		}
+	}
+	return nil, false
+}
+
+// List retourne les déploiements d'un réseau triés par nom
+func (db *DeploymentBook) List(network string) []*Deployment {
+	deployments := make([]*Deployment, 0, len(db.Networks[network]))
+	for _, deployment := range db.Networks[network] {
+		deployments = append(deployments, deployment)
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].Name < deployments[j].Name
+	})
+	return deployments
+}
@@ -0,0 +1,42 @@
+package contracts
+
+import (
+	"embed"
+	"fmt"
+	"sync"
+)
+
+//go:generate go run ./asm/build.go
+
+// Nom de l'artifact ERC20 embarqué
+const ERC20ArtifactName = "ERC20"
+
+// Artifacts livrés avec benchy (voir asm/ pour leurs sources)
+//
+//go:embed artifacts/*.json
+var embeddedArtifacts embed.FS
+
+var (
+	erc20Once     sync.Once
+	erc20Artifact *Artifact
+	erc20Err      error
+)
+
+// ERC20 retourne l'artifact ERC20 standard embarqué :
+// constructor(string name, string symbol, uint256 initialSupply)
+func ERC20() (*Artifact, error) {
+	erc20Once.Do(func() {
+		data, err := embeddedArtifacts.ReadFile("artifacts/ERC20.json")
+		if err != nil {
+			erc20Err = fmt.Errorf("failed to read embedded ERC20 artifact: %w", err)
+			return
+		}
+		artifacts, err := ParseArtifacts("embedded:ERC20.json", data)
+		if err != nil {
+			erc20Err = err
+			return
+		}
+		erc20Artifact = artifacts[0]
+	})
+	return erc20Artifact, erc20Err
+}
@@ -0,0 +1,153 @@
+package contracts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"benchy/internal/domain/ports"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EventArgument représente un argument décodé d'un événement
+type EventArgument struct {
+	Name    string
+	Type    string
+	Indexed bool
+	Value   interface{}
+}
+
+// DecodedEvent représente un log décodé avec l'ABI du contrat émetteur
+type DecodedEvent struct {
+	Contract  string // Nom du déploiement, ou de l'artifact si le contrat est inconnu
+	Name      string
+	Signature string
+	Arguments []EventArgument
+	Log       ports.LogEntry
+}
+
+// AddressLabeler retourne le libellé affiché pour une adresse
+type AddressLabeler func(address common.Address) string
+
+// DecodeLog décode un log. L'ABI est d'abord cherchée parmi les contrats
+// déployés sur le réseau, puis parmi tous les artifacts connus (un Transfer
+// émis par un token déployé hors de benchy est ainsi reconnu).
+func (r *Registry) DecodeLog(network string, log ports.LogEntry) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("anonymous event")
+	}
+
+	if deployment, exists := r.deployments.FindByAddress(network, log.Address); exists {
+		if artifact, exists := r.artifacts[strings.ToLower(deployment.Artifact)]; exists {
+			if event, err := artifact.ABI.EventByID(log.Topics[0]); err == nil {
+				return decodeEvent(deployment.Name, *event, log)
+			}
+		}
+	}
+
+	for _, artifact := range r.Artifacts() {
+		event, err := artifact.ABI.EventByID(log.Topics[0])
+		if err != nil {
+			continue
+		}
+		// Deux ABI peuvent partager une signature avec un nombre d'indexés différent (ERC20/ERC721)
+		if decoded, err := decodeEvent(artifact.Name, *event, log); err == nil {
+			return decoded, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown event %s", log.Topics[0].Hex())
+}
+
+// EventTopic retourne le topic d'un événement d'un artifact
+func (a *Artifact) EventTopic(name string) (common.Hash, error) {
+	event, exists := a.ABI.Events[name]
+	if !exists {
+		var names []string
+		for eventName := range a.ABI.Events {
+			names = append(names, eventName)
+		}
+		sort.Strings(names)
+		return common.Hash{}, fmt.Errorf("contract %s has no event '%s' (available: %s)", a.Name, name, strings.Join(names, ", "))
+	}
+	return event.ID, nil
+}
+
+// EventTopics retourne les topics distincts d'un événement dans tous les ABI enregistrés,
+// pour filtrer les logs d'un contrat dont on ne connaît pas l'artifact
+func (r *Registry) EventTopics(name string) ([]common.Hash, error) {
+	seen := make(map[common.Hash]bool)
+	var topics []common.Hash
+	for _, artifact := range r.Artifacts() {
+		if event, exists := artifact.ABI.Events[name]; exists && !seen[event.ID] {
+			seen[event.ID] = true
+			topics = append(topics, event.ID)
+		}
+	}
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("no registered ABI has an event '%s'", name)
+	}
+	return topics, nil
+}
+
+// decodeEvent décode les arguments indexés (topics) et non indexés (data)
+func decodeEvent(contract string, event abi.Event, log ports.LogEntry) (*DecodedEvent, error) {
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	values := make(map[string]interface{})
+	if err := abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+
+	nonIndexed, err := event.Inputs.NonIndexed().UnpackValues(log.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &DecodedEvent{
+		Contract:  contract,
+		Name:      event.Name,
+		Signature: event.Sig,
+		Log:       log,
+	}
+
+	position := 0
+	for _, input := range event.Inputs {
+		argument := EventArgument{
+			Name:    input.Name,
+			Type:    input.Type.String(),
+			Indexed: input.Indexed,
+		}
+		if input.Indexed {
+			argument.Value = values[input.Name]
+		} else {
+			argument.Value = nonIndexed[position]
+			position++
+		}
+		decoded.Arguments = append(decoded.Arguments, argument)
+	}
+	return decoded, nil
+}
+
+// Format affiche l'événement sous la forme Transfer(from=alice, to=bob, value=1000)
+func (e *DecodedEvent) Format(label AddressLabeler) string {
+	parts := make([]string, len(e.Arguments))
+	for i, argument := range e.Arguments {
+		value := FormatValue(argument.Value)
+		if address, ok := argument.Value.(common.Address); ok && label != nil {
+			value = label(address)
+		}
+		if argument.Name == "" {
+			parts[i] = value
+			continue
+		}
+		parts[i] = argument.Name + "=" + value
+	}
+	return fmt.Sprintf("%s(%s)", e.Name, strings.Join(parts, ", "))
+}
@@ -0,0 +1,193 @@
+package contracts
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Registry regroupe les artifacts connus et les contrats déployés
+type Registry struct {
+	artifacts   map[string]*Artifact
+	deployments *DeploymentBook
+}
+
+// NewRegistry crée un registre contenant les artifacts embarqués et ceux de
+// <baseDir>/contracts/artifacts, dont les déploiements sont stockés sous baseDir
+func NewRegistry(baseDir string) (*Registry, error) {
+	deployments, err := LoadDeploymentBook(filepath.Join(baseDir, "contracts", "deployments.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &Registry{
+		artifacts:   make(map[string]*Artifact),
+		deployments: deployments,
+	}
+
+	erc20, err := ERC20()
+	if err != nil {
+		return nil, err
+	}
+	registry.Register(erc20)
+
+	if err := registry.LoadDir(filepath.Join(baseDir, "contracts", "artifacts")); err != nil {
+		return nil, fmt.Errorf("failed to load contract artifacts: %w", err)
+	}
+
+	return registry, nil
+}
+
+// Register ajoute un artifact au registre (remplace un artifact du même nom)
+func (r *Registry) Register(artifact *Artifact) {
+	r.artifacts[strings.ToLower(artifact.Name)] = artifact
+}
+
+// LoadDir charge tous les artifacts JSON d'un répertoire (récursivement,
+// pour supporter l'arborescence out/<File>.sol/<Name>.json de Foundry)
+func (r *Registry) LoadDir(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	return r.LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFS charge tous les artifacts JSON d'un système de fichiers (fichiers embarqués inclus)
+func (r *Registry) LoadFS(fsys fs.FS, root string) error {
+	return fs.WalkDir(fsys, root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Les build-info de Foundry/Hardhat ne sont pas des artifacts
+		if entry.IsDir() && entry.Name() == "build-info" {
+			return fs.SkipDir
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("failed to read artifact %s: %w", path, err)
+		}
+
+		artifacts, err := ParseArtifacts(path, data)
+		if err != nil {
+			return err
+		}
+		for _, artifact := range artifacts {
+			r.Register(artifact)
+		}
+		return nil
+	})
+}
+
+// Artifact retourne un artifact par son nom
+func (r *Registry) Artifact(name string) (*Artifact, error) {
+	artifact, exists := r.artifacts[strings.ToLower(name)]
+	if !exists {
+		return nil, fmt.Errorf("unknown contract artifact '%s'", name)
+	}
+	return artifact, nil
+}
+
+// Artifacts retourne tous les artifacts triés par nom
+func (r *Registry) Artifacts() []*Artifact {
+	artifacts := make([]*Artifact, 0, len(r.artifacts))
+	for _, artifact := range r.artifacts {
+		artifacts = append(artifacts, artifact)
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Name < artifacts[j].Name
+	})
+	return artifacts
+}
+
+// Deployments retourne le carnet des contrats déployés
+func (r *Registry) Deployments() *DeploymentBook {
+	return r.deployments
+}
+
+// EncodeDeployment retourne le bytecode suivi des arguments du constructeur encodés
+func (r *Registry) EncodeDeployment(artifactName string, args []interface{}) ([]byte, error) {
+	artifact, err := r.Artifact(artifactName)
+	if err != nil {
+		return nil, err
+	}
+	if !artifact.IsDeployable() {
+		return nil, fmt.Errorf("artifact '%s' has no bytecode (interface or abstract contract?)", artifact.Name)
+	}
+
+	constructorArgs, err := artifact.ABI.Pack("", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode constructor arguments: %w", err)
+	}
+
+	code := make([]byte, 0, len(artifact.Bytecode)+len(constructorArgs))
+	code = append(code, artifact.Bytecode...)
+	return append(code, constructorArgs...), nil
+}
+
+// EncodeCall encode l'appel d'une méthode par son nom
+func (r *Registry) EncodeCall(artifactName, method string, args []interface{}) ([]byte, error) {
+	artifact, err := r.Artifact(artifactName)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := artifact.ABI.Methods[method]; !exists {
+		return nil, fmt.Errorf("contract '%s' has no method '%s'", artifact.Name, method)
+	}
+
+	data, err := artifact.ABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s.%s: %w", artifact.Name, method, err)
+	}
+	return data, nil
+}
+
+// DecodeResult décode les valeurs retournées par une méthode
+func (r *Registry) DecodeResult(artifactName, method string, data []byte) ([]interface{}, error) {
+	artifact, err := r.Artifact(artifactName)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := artifact.ABI.Unpack(method, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s.%s result: %w", artifact.Name, method, err)
+	}
+	return values, nil
+}
+
+// Tokens retourne les contrats déployés dont l'artifact implémente ERC20
+func (r *Registry) Tokens(network string) []*Deployment {
+	var tokens []*Deployment
+	for _, deployment := range r.deployments.List(network) {
+		if artifact, exists := r.artifacts[strings.ToLower(deployment.Artifact)]; exists && artifact.IsERC20() {
+			tokens = append(tokens, deployment)
+		}
+	}
+	return tokens
+}
+
+// Resolve retrouve le déploiement d'un contrat par son nom ou son adresse
+func (r *Registry) Resolve(network, ref string) (*Deployment, error) {
+	if deployment, exists := r.deployments.Get(network, ref); exists {
+		return deployment, nil
+	}
+
+	if common.IsHexAddress(ref) {
+		if deployment, exists := r.deployments.FindByAddress(network, common.HexToAddress(ref)); exists {
+			return deployment, nil
+		}
+		return nil, fmt.Errorf("no known contract at %s on %s", ref, network)
+	}
+
+	return nil, fmt.Errorf("no contract named '%s' deployed on %s", ref, network)
+}
@@ -0,0 +1,127 @@
+package dashboard
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Page du dashboard, embarquée dans le binaire
+//
+//go:embed static
+var static embed.FS
+
+// Intervalle des commentaires envoyés pour garder le flux SSE ouvert derrière un proxy
+const keepAliveInterval = 15 * time.Second
+
+// Server sert la page du dashboard, le flux Server-Sent Events de ses mises à jour
+// et le dernier état en JSON
+type Server struct {
+	mu      sync.Mutex
+	latest  []byte // Dernier état publié, encodé en JSON
+	clients map[chan []byte]struct{}
+}
+
+// NewServer crée un serveur de dashboard sans état publié
+func NewServer() *Server {
+	return &Server{clients: make(map[chan []byte]struct{})}
+}
+
+// Routes enregistre les routes du dashboard sur mux
+func (s *Server) Routes(mux *http.ServeMux) {
+	assets, _ := fs.Sub(static, "static")
+	mux.Handle("/", http.FileServer(http.FS(assets)))
+	mux.HandleFunc("/events", s.serveEvents)
+	mux.HandleFunc("/snapshot.json", s.serveSnapshot)
+}
+
+// Publish envoie un nouvel état à tous les navigateurs connectés. Un navigateur en
+// retard ne reçoit que le plus récent.
+func (s *Server) Publish(snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode dashboard snapshot: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest = data
+	for client := range s.clients {
+		select {
+		case <-client:
+		default:
+		}
+		client <- data
+	}
+	return nil
+}
+
+// subscribe inscrit un navigateur et retourne son canal, amorcé avec le dernier état
+func (s *Server) subscribe() chan []byte {
+	client := make(chan []byte, 1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.latest != nil {
+		client <- s.latest
+	}
+	s.clients[client] = struct{}{}
+	return client
+}
+
+// unsubscribe désinscrit un navigateur
+func (s *Server) unsubscribe(client chan []byte) {
+	s.mu.Lock()
+	delete(s.clients, client)
+	s.mu.Unlock()
+}
+
+// serveEvents diffuse chaque état publié comme un événement "snapshot"
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	flusher.Flush()
+
+	client := s.subscribe()
+	defer s.unsubscribe(client)
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-client:
+			if _, err := fmt.Fprintf(w, "event: snapshot\ndata: %s\n\n", data); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// serveSnapshot retourne le dernier état publié
+func (s *Server) serveSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	data := s.latest
+	s.mu.Unlock()
+	if data == nil {
+		http.Error(w, "no sample collected yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
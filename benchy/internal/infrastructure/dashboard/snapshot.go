@@ -0,0 +1,83 @@
+package dashboard
+
+import "time"
+
+// Snapshot est l'état du réseau affiché par le dashboard, publié à chaque tour du collecteur
+type Snapshot struct {
+	Network      string        `json:"network"`
+	GeneratedAt  time.Time     `json:"generated_at"`
+	Summary      Summary       `json:"summary"`
+	Nodes        []Node        `json:"nodes"`
+	Head         []HeadPoint   `json:"head"`
+	Validators   []Validator   `json:"validators"`
+	Alerts       []Alert       `json:"alerts"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+// Summary résume les métriques agrégées du réseau
+type Summary struct {
+	TotalNodes       int     `json:"total_nodes"`
+	OnlineNodes      int     `json:"online_nodes"`
+	Validators       int     `json:"validators"`
+	HeadBlock        uint64  `json:"head_block"`
+	BlockTimeSeconds float64 `json:"block_time_seconds"`
+	LatencyMs        float64 `json:"latency_ms"`
+	ConsensusStatus  string  `json:"consensus_status"`
+	MissedBlocks     int     `json:"missed_blocks"`
+}
+
+// Node est une ligne du tableau des nodes, comme dans benchy infos
+type Node struct {
+	Name          string  `json:"name"`
+	Client        string  `json:"client"`
+	Validator     bool    `json:"validator"`
+	Address       string  `json:"address"`
+	Container     string  `json:"container"`
+	Online        bool    `json:"online"`
+	Status        string  `json:"status"` // Statut de synchronisation du collecteur
+	HeadBlock     uint64  `json:"head_block"`
+	Peers         int     `json:"peers"`
+	ExpectedPeers int     `json:"expected_peers"`
+	PendingTxs    int     `json:"pending_txs"`
+	QueuedTxs     int     `json:"queued_txs"`
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryMB      float64 `json:"memory_mb"`
+	LatencyMs     float64 `json:"latency_ms"`
+	BalanceETH    float64 `json:"balance_eth"`
+}
+
+// HeadPoint est la tête de chaîne du réseau à un instant
+type HeadPoint struct {
+	Time  time.Time `json:"time"`
+	Block uint64    `json:"block"`
+}
+
+// Validator est l'activité de scellement d'un validateur sur les derniers blocs analysés
+type Validator struct {
+	Name            string  `json:"name"`
+	Address         string  `json:"address"`
+	SealedBlocks    int     `json:"sealed_blocks"`
+	InTurnPercent   float64 `json:"in_turn_percent"`
+	MissedTurns     int     `json:"missed_turns"`
+	LastSealedBlock uint64  `json:"last_sealed_block"`
+}
+
+// Alert est une alerte active du réseau
+type Alert struct {
+	Type     string    `json:"type"`
+	Severity string    `json:"severity"`
+	Node     string    `json:"node"`
+	Message  string    `json:"message"`
+	Since    time.Time `json:"since"`
+}
+
+// Transaction est une transaction minée récemment
+type Transaction struct {
+	Hash    string    `json:"hash"`
+	Block   uint64    `json:"block"`
+	Time    time.Time `json:"time"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Status  string    `json:"status"`
+	GasUsed uint64    `json:"gas_used"`
+}
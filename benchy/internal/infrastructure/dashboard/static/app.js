@@ -0,0 +1,196 @@
+// Dashboard benchy : affiche chaque état publié sur /events par le collecteur
+"use strict";
+
+const $ = (id) => document.getElementById(id);
+
+// el crée un élément avec son texte et sa classe
+function el(tag, text, className) {
+  const node = document.createElement(tag);
+  if (text !== undefined && text !== null) node.textContent = text;
+  if (className) node.className = className;
+  return node;
+}
+
+// row crée une ligne de tableau ; une cellule est un texte ou [texte, classe] ou un élément
+function row(cells) {
+  const tr = el("tr");
+  for (const cell of cells) {
+    if (cell instanceof Node) {
+      const td = el("td");
+      td.appendChild(cell);
+      tr.appendChild(td);
+    } else if (Array.isArray(cell)) {
+      tr.appendChild(el("td", cell[0], cell[1]));
+    } else {
+      tr.appendChild(el("td", cell));
+    }
+  }
+  return tr;
+}
+
+// fill remplace le contenu d'un tableau, avec un message quand il n'y a rien à afficher
+function fill(tbody, rows, columns, emptyText) {
+  tbody.replaceChildren(...rows);
+  if (rows.length === 0) {
+    const td = el("td", emptyText, "empty");
+    td.colSpan = columns;
+    tbody.appendChild(el("tr")).appendChild(td);
+  }
+}
+
+const shortHash = (hash) => (hash.length > 14 ? hash.slice(0, 8) + "…" + hash.slice(-4) : hash);
+const time = (iso) => new Date(iso).toLocaleTimeString();
+
+const statusClass = {
+  synced: "ok",
+  syncing: "warn",
+  starting: "warn",
+  offline: "bad",
+  unknown: "muted",
+  healthy: "ok",
+  degraded: "warn",
+  critical: "bad",
+  info: "muted",
+  warning: "warn",
+  error: "bad",
+  success: "ok",
+  failed: "bad",
+};
+
+function renderSummary(summary) {
+  const cards = [
+    ["Head block", "#" + summary.head_block],
+    ["Online nodes", summary.online_nodes + "/" + summary.total_nodes, summary.online_nodes < summary.total_nodes ? "warn" : "ok"],
+    ["Validators", summary.validators],
+    ["Block time", summary.block_time_seconds.toFixed(1) + "s"],
+    ["RPC latency", summary.latency_ms.toFixed(0) + "ms"],
+    ["Consensus", summary.consensus_status, statusClass[summary.consensus_status]],
+    ["Missed blocks", summary.missed_blocks, summary.missed_blocks > 0 ? "warn" : ""],
+  ];
+  $("summary").replaceChildren(...cards.map(([label, value, className]) => {
+    const card = el("div", null, "card");
+    card.appendChild(el("div", label, "label"));
+    card.appendChild(el("div", value, "value " + (className || "")));
+    return card;
+  }));
+}
+
+function renderNodes(nodes) {
+  fill($("nodes"), nodes.map((node) => row([
+    [node.name, "mono"],
+    node.client,
+    node.validator ? "validator" : "node",
+    [node.status, statusClass[node.status]],
+    [node.online ? "#" + node.head_block : "—", "num"],
+    [node.online ? node.peers + "/" + node.expected_peers : "—", "num " + (node.online && node.peers < node.expected_peers ? "warn" : "")],
+    [node.online ? node.pending_txs + " / " + node.queued_txs : "—", "num"],
+    [node.cpu_percent.toFixed(1) + "%", "num"],
+    [node.memory_mb.toFixed(0) + " MB", "num"],
+    [node.online ? node.latency_ms.toFixed(0) + " ms" : "—", "num"],
+    [node.balance_eth.toFixed(2) + " ETH", "num"],
+  ])), 11, "No nodes");
+}
+
+function renderHead(points) {
+  const chart = $("head-chart");
+  chart.replaceChildren();
+  $("head-from").textContent = "";
+  $("head-to").textContent = "";
+  $("head-rate").textContent = "";
+  if (points.length < 2) return;
+
+  const t0 = Date.parse(points[0].time);
+  const t1 = Date.parse(points[points.length - 1].time);
+  const low = Math.min(...points.map((p) => p.block));
+  const high = Math.max(...points.map((p) => p.block));
+  const x = (p) => ((Date.parse(p.time) - t0) / Math.max(t1 - t0, 1)) * 800;
+  const y = (p) => 190 - ((p.block - low) / Math.max(high - low, 1)) * 180;
+
+  for (const level of [10, 100, 190]) {
+    const line = document.createElementNS("http://www.w3.org/2000/svg", "line");
+    line.setAttribute("x1", 0);
+    line.setAttribute("x2", 800);
+    line.setAttribute("y1", level);
+    line.setAttribute("y2", level);
+    chart.appendChild(line);
+  }
+  const polyline = document.createElementNS("http://www.w3.org/2000/svg", "polyline");
+  polyline.setAttribute("points", points.map((p) => x(p).toFixed(1) + "," + y(p).toFixed(1)).join(" "));
+  chart.appendChild(polyline);
+
+  $("head-from").textContent = time(points[0].time) + " · #" + low;
+  $("head-to").textContent = "#" + high + " · " + time(points[points.length - 1].time);
+  const minutes = (t1 - t0) / 60000;
+  if (minutes > 0) $("head-rate").textContent = ((high - low) / minutes).toFixed(1) + " blocks/min";
+}
+
+function renderValidators(validators) {
+  fill($("validators"), validators.map((validator) => {
+    const bar = el("span", null, "bar");
+    bar.appendChild(el("span")).style.width = validator.in_turn_percent + "%";
+    const inTurn = el("span");
+    inTurn.append(bar, validator.in_turn_percent.toFixed(0) + "%");
+    return row([
+      [validator.name || shortHash(validator.address), "mono"],
+      [validator.sealed_blocks, "num"],
+      inTurn,
+      [validator.missed_turns, "num " + (validator.missed_turns > 0 ? "warn" : "")],
+      [validator.sealed_blocks > 0 ? "#" + validator.last_sealed_block : "never", "num"],
+    ]);
+  }), 5, "Waiting for the first sealing analysis");
+}
+
+function renderAlerts(alerts) {
+  const list = $("alerts");
+  list.replaceChildren(...alerts.map((alert) => {
+    const item = el("li");
+    item.append(
+      el("span", alert.severity.toUpperCase() + " ", statusClass[alert.severity]),
+      el("strong", alert.node + " "),
+      alert.message + " ",
+      el("span", "since " + time(alert.since), "muted"),
+    );
+    return item;
+  }));
+  if (alerts.length === 0) list.appendChild(el("li", "No active alerts", "empty"));
+}
+
+function renderTransactions(transactions) {
+  fill($("transactions"), transactions.map((tx) => row([
+    [shortHash(tx.hash), "mono"],
+    [tx.block, "num"],
+    time(tx.time),
+    [tx.from, "mono"],
+    [tx.to, "mono"],
+    [tx.status, statusClass[tx.status]],
+    [tx.gas_used, "num"],
+  ])), 7, "No transactions in the recent blocks");
+}
+
+function render(snapshot) {
+  $("network").textContent = snapshot.network;
+  $("updated").textContent = "updated " + time(snapshot.generated_at);
+  renderSummary(snapshot.summary);
+  renderNodes(snapshot.nodes || []);
+  renderHead(snapshot.head || []);
+  renderValidators(snapshot.validators || []);
+  renderAlerts(snapshot.alerts || []);
+  renderTransactions(snapshot.transactions || []);
+}
+
+// EventSource se reconnecte seul si benchy dashboard redémarre
+function connect() {
+  const connection = $("connection");
+  const events = new EventSource("events");
+  events.onopen = () => {
+    connection.textContent = "live";
+    connection.className = "connection online";
+  };
+  events.onerror = () => {
+    connection.textContent = "reconnecting";
+    connection.className = "connection offline";
+  };
+  events.addEventListener("snapshot", (event) => render(JSON.parse(event.data)));
+}
+
+connect();
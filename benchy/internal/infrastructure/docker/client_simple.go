@@ -3,6 +3,7 @@ package docker
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"benchy/internal/domain/entities"
 	"benchy/internal/domain/ports"
@@ -10,6 +11,7 @@ import (
 
 // DockerClient version simplifiée sans dépendances problématiques
 type DockerClient struct {
+	mu         sync.Mutex // Les pannes lancées par l'API arrêtent des containers en parallèle
 	containers map[string]bool
 }
 
@@ -23,18 +25,24 @@ func NewDockerClient() (*DockerClient, error) {
 // CreateContainer simule la création d'un container
 func (dc *DockerClient) CreateContainer(ctx context.Context, node *entities.Node, config ports.ContainerConfig) (string, error) {
 	containerID := fmt.Sprintf("benchy-%s-%s", node.Name, "abc123")
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
 	dc.containers[containerID] = false
 	return containerID, nil
 }
 
 // StartContainer simule le démarrage
 func (dc *DockerClient) StartContainer(ctx context.Context, containerID string) error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
 	dc.containers[containerID] = true
 	return nil
 }
 
 // StopContainer simule l'arrêt
 func (dc *DockerClient) StopContainer(ctx context.Context, containerID string) error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
 	dc.containers[containerID] = false
 	return nil
 }
@@ -46,6 +54,8 @@ func (dc *DockerClient) RestartContainer(ctx context.Context, containerID string
 
 // RemoveContainer simule la suppression
 func (dc *DockerClient) RemoveContainer(ctx context.Context, containerID string) error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
 	delete(dc.containers, containerID)
 	return nil
 }
@@ -66,6 +76,8 @@ func (dc *DockerClient) GetContainerLogs(ctx context.Context, containerID string
 
 // IsContainerRunning simule la vérification
 func (dc *DockerClient) IsContainerRunning(ctx context.Context, containerID string) (bool, error) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
 	return dc.containers[containerID], nil
 }
 
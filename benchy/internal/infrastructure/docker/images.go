@@ -0,0 +1,67 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// ErrDaemonUnreachable signale que l'API Docker n'a pas répondu
+var ErrDaemonUnreachable = errors.New("docker daemon not reachable")
+
+// defaultDockerHost est le socket du daemon quand DOCKER_HOST n'est pas défini
+const defaultDockerHost = "unix:///var/run/docker.sock"
+
+// engineClient retourne un client HTTP vers l'API Docker et l'URL de base correspondante
+func engineClient() (*http.Client, string, error) {
+	host := os.Getenv("DOCKER_HOST")
+	if host == "" {
+		host = defaultDockerHost
+	}
+	switch {
+	case strings.HasPrefix(host, "unix://"):
+		socket := strings.TrimPrefix(host, "unix://")
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+		}
+		return &http.Client{Transport: transport}, "http://docker", nil
+	case strings.HasPrefix(host, "tcp://"):
+		return http.DefaultClient, "http://" + strings.TrimPrefix(host, "tcp://"), nil
+	default:
+		return nil, "", fmt.Errorf("unsupported DOCKER_HOST '%s'", host)
+	}
+}
+
+// ImageExists interroge l'API Docker (GET /images/{name}/json) : 404 signifie que l'image est absente
+func (dc *DockerClient) ImageExists(ctx context.Context, image string) (bool, error) {
+	client, baseURL, err := engineClient()
+	if err != nil {
+		return false, err
+	}
+	// Comme la CLI docker, la référence n'est pas échappée : l'API accepte les '/' du nom
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/images/"+image+"/json", nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to build image inspect request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrDaemonUnreachable, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to inspect image %s: docker API returned %s", image, resp.Status)
+	}
+}
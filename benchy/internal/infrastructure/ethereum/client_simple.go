@@ -2,98 +2,458 @@ package ethereum
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"benchy/internal/domain/entities"
 	"benchy/internal/domain/ports"
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
-// EthereumClient version simplifiée sans go-ethereum
+// Intervalle de vérification des reçus de transaction
+const receiptPollInterval = time.Second
+
+// EthereumClient parle aux nodes via JSON-RPC ; il peut être partagé entre goroutines
 type EthereumClient struct {
-	connections map[string]bool
+	mu          sync.RWMutex // Protège connections et accounts
+	connections map[string]*rpc.Client
+	accounts    map[common.Address]*ecdsa.PrivateKey
 }
 
-// NewEthereumClient crée un nouveau client simplifié
+// NewEthereumClient crée un nouveau client Ethereum
 func NewEthereumClient() *EthereumClient {
 	return &EthereumClient{
-		connections: make(map[string]bool),
+		connections: make(map[string]*rpc.Client),
+		accounts:    make(map[common.Address]*ecdsa.PrivateKey),
 	}
 }
 
-// ConnectToNode simule une connexion
+// RegisterAccount enregistre une clé privée utilisée pour signer les transactions
+func (ec *EthereumClient) RegisterAccount(privateKey *ecdsa.PrivateKey) common.Address {
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	ec.mu.Lock()
+	ec.accounts[address] = privateKey
+	ec.mu.Unlock()
+	return address
+}
+
+// ConnectToNode ouvre une connexion RPC vers un node
 func (ec *EthereumClient) ConnectToNode(ctx context.Context, nodeURL string) error {
-	ec.connections[nodeURL] = true
-	return nil
+	_, err := ec.connection(ctx, nodeURL)
+	return err
+}
+
+// connection retourne la connexion RPC du node, ouverte au premier appel
+func (ec *EthereumClient) connection(ctx context.Context, nodeURL string) (*rpc.Client, error) {
+	ec.mu.RLock()
+	client, exists := ec.connections[nodeURL]
+	ec.mu.RUnlock()
+	if exists {
+		return client, nil
+	}
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	if client, exists := ec.connections[nodeURL]; exists {
+		return client, nil
+	}
+	client, err := rpc.DialContext(ctx, nodeURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", nodeURL, err)
+	}
+	ec.connections[nodeURL] = client
+	return client, nil
 }
 
-// DisconnectFromNode simule une déconnexion
+// DisconnectFromNode ferme la connexion RPC vers un node
 func (ec *EthereumClient) DisconnectFromNode(ctx context.Context, nodeURL string) error {
-	delete(ec.connections, nodeURL)
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	if client, exists := ec.connections[nodeURL]; exists {
+		client.Close()
+		delete(ec.connections, nodeURL)
+	}
 	return nil
 }
 
-// IsNodeConnected vérifie la connexion simulée
+// IsNodeConnected vérifie qu'une connexion est ouverte vers le node
 func (ec *EthereumClient) IsNodeConnected(ctx context.Context, nodeURL string) (bool, error) {
-	return ec.connections[nodeURL], nil
+	ec.mu.RLock()
+	_, exists := ec.connections[nodeURL]
+	ec.mu.RUnlock()
+	return exists, nil
 }
 
-// GetLatestBlockNumber retourne un numéro de bloc simulé
+// GetLatestBlockNumber retourne le numéro du dernier bloc
 func (ec *EthereumClient) GetLatestBlockNumber(ctx context.Context, nodeURL string) (uint64, error) {
-	return 1234, nil
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return 0, err
+	}
+	return client.BlockNumber(ctx)
 }
 
-// GetPeerCount retourne un nombre de peers simulé
+// GetPeerCount retourne le nombre de peers connectés
 func (ec *EthereumClient) GetPeerCount(ctx context.Context, nodeURL string) (int, error) {
-	return 4, nil
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return 0, err
+	}
+
+	peers, err := client.PeerCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int(peers), nil
 }
 
-// GetPendingTransactionCount retourne un nombre de transactions simulé
+// GetPendingTransactionCount retourne le nombre de transactions en attente
 func (ec *EthereumClient) GetPendingTransactionCount(ctx context.Context, nodeURL string) (int, error) {
-	return 2, nil
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := client.PendingTransactionCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
 }
 
-// GetBalance retourne une balance simulée
-func (ec *EthereumClient) GetBalance(ctx context.Context, nodeURL string, address interface{}) (*big.Int, error) {
-	// Balance simulée entre 0 et 100 ETH
-	balance := big.NewInt(int64(50 + len(nodeURL)%50))
-	balance.Mul(balance, big.NewInt(1e18)) // Convertir en wei
-	return balance, nil
+// GetBalance retourne la balance d'une adresse
+func (ec *EthereumClient) GetBalance(ctx context.Context, nodeURL string, address common.Address) (*big.Int, error) {
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return nil, err
+	}
+	return client.BalanceAt(ctx, address, nil)
 }
 
-// Méthodes non implémentées pour l'instant
+// GetBlockByNumber récupère un bloc et retrouve son signataire Clique
 func (ec *EthereumClient) GetBlockByNumber(ctx context.Context, nodeURL string, blockNumber uint64) (*ports.BlockInfo, error) {
-	return nil, fmt.Errorf("not implemented")
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := client.BlockByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", blockNumber, err)
+	}
+
+	header := block.Header()
+	info := &ports.BlockInfo{
+		Number:     header.Number.Uint64(),
+		Hash:       block.Hash(),
+		ParentHash: header.ParentHash,
+		Timestamp:  header.Time,
+		Difficulty: header.Difficulty,
+		GasLimit:   header.GasLimit,
+		GasUsed:    header.GasUsed,
+		Miner:      header.Coinbase,
+		ExtraData:  header.Extra,
+	}
+
+	for _, tx := range block.Transactions() {
+		info.Transactions = append(info.Transactions, tx.Hash())
+	}
+
+	// Le genesis n'a pas de sceau, les autres blocs doivent en avoir un
+	if info.Number > 0 {
+		signer, err := RecoverCliqueSigner(header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover signer of block %d: %w", blockNumber, err)
+		}
+		info.Signer = signer
+	}
+
+	return info, nil
+}
+
+// GetBlockHash retourne le hash d'un bloc tel que le node l'a calculé, sans décoder le bloc
+func (ec *EthereumClient) GetBlockHash(ctx context.Context, nodeURL string, blockNumber uint64) (common.Hash, error) {
+	var block *struct {
+		Hash common.Hash `json:"hash"`
+	}
+	if err := ec.Call(ctx, nodeURL, &block, "eth_getBlockByNumber", hexutil.EncodeUint64(blockNumber), false); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get block %d: %w", blockNumber, err)
+	}
+	if block == nil {
+		return common.Hash{}, fmt.Errorf("block %d not found", blockNumber)
+	}
+	return block.Hash, nil
+}
+
+// GetNonce retourne le prochain nonce d'une adresse (transactions en attente incluses)
+func (ec *EthereumClient) GetNonce(ctx context.Context, nodeURL string, address common.Address) (uint64, error) {
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return 0, err
+	}
+	return client.PendingNonceAt(ctx, address)
+}
+
+// SendTransaction signe la transaction avec la clé de l'émetteur et la diffuse.
+// Le nonce, le prix du gas et la limite de gas sont complétés s'ils sont absents ; un
+// nonce explicite, même 0, permet de remplacer une transaction en attente.
+func (ec *EthereumClient) SendTransaction(ctx context.Context, nodeURL string, tx *entities.Transaction) (common.Hash, error) {
+	ec.mu.RLock()
+	key, exists := ec.accounts[tx.From]
+	ec.mu.RUnlock()
+	if !exists {
+		return common.Hash{}, fmt.Errorf("no private key registered for %s", tx.From.Hex())
+	}
+
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	if tx.Nonce == nil {
+		nonce, err := client.PendingNonceAt(ctx, tx.From)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to get nonce: %w", err)
+		}
+		tx.Nonce = &nonce
+	}
+
+	if tx.GasPrice == nil {
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to get gas price: %w", err)
+		}
+		tx.GasPrice = gasPrice
+	}
+
+	if tx.Value == nil {
+		tx.Value = big.NewInt(0)
+	}
+
+	// Une adresse vide signifie un déploiement de contrat
+	var to *common.Address
+	if tx.To != (common.Address{}) {
+		recipient := tx.To
+		to = &recipient
+	}
+
+	if tx.Gas == 0 {
+		gas, err := client.EstimateGas(ctx, goethereum.CallMsg{
+			From:     tx.From,
+			To:       to,
+			GasPrice: tx.GasPrice,
+			Value:    tx.Value,
+			Data:     tx.Data,
+		})
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		tx.Gas = gas
+	}
+
+	ethTx := types.NewTx(&types.LegacyTx{
+		Nonce:    *tx.Nonce,
+		To:       to,
+		Value:    tx.Value,
+		Gas:      tx.Gas,
+		GasPrice: tx.GasPrice,
+		Data:     tx.Data,
+	})
+
+	signedTx, err := types.SignTx(ethTx, types.LatestSignerForChainID(chainID), key)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	if err := client.SendTransaction(ctx, signedTx); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	tx.Hash = signedTx.Hash()
+	tx.EthTx = signedTx
+	return tx.Hash, nil
 }
 
-func (ec *EthereumClient) GetNonce(ctx context.Context, nodeURL string, address interface{}) (uint64, error) {
-	return 0, fmt.Errorf("not implemented")
+// GetTransactionStatus déduit le statut d'une transaction depuis son reçu
+func (ec *EthereumClient) GetTransactionStatus(ctx context.Context, nodeURL string, txHash common.Hash) (entities.TransactionStatus, error) {
+	receipt, err := ec.GetTransactionReceipt(ctx, nodeURL, txHash)
+	if err != nil {
+		if errors.Is(err, goethereum.NotFound) {
+			return entities.TxStatusPending, nil
+		}
+		return entities.TxStatusPending, err
+	}
+
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return entities.TxStatusConfirmed, nil
+	}
+	return entities.TxStatusFailed, nil
+}
+
+// GetTransactionReceipt récupère le reçu d'une transaction minée
+func (ec *EthereumClient) GetTransactionReceipt(ctx context.Context, nodeURL string, txHash common.Hash) (*ports.TransactionReceipt, error) {
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt for %s: %w", txHash.Hex(), err)
+	}
+
+	result := &ports.TransactionReceipt{
+		TransactionHash:  receipt.TxHash,
+		BlockNumber:      receipt.BlockNumber.Uint64(),
+		BlockHash:        receipt.BlockHash,
+		TransactionIndex: receipt.TransactionIndex,
+		GasUsed:          receipt.GasUsed,
+		Status:           receipt.Status,
+		ContractAddress:  receipt.ContractAddress,
+	}
+
+	for _, log := range receipt.Logs {
+		result.Logs = append(result.Logs, toLogEntry(log))
+	}
+
+	// Le reçu ne contient ni l'émetteur ni le destinataire
+	tx, _, err := client.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", txHash.Hex(), err)
+	}
+	if tx.To() != nil {
+		result.To = *tx.To()
+	}
+	if from, err := client.TransactionSender(ctx, tx, receipt.BlockHash, receipt.TransactionIndex); err == nil {
+		result.From = from
+	}
+
+	return result, nil
 }
 
-func (ec *EthereumClient) SendTransaction(ctx context.Context, nodeURL string, tx *entities.Transaction) (interface{}, error) {
-	return nil, fmt.Errorf("not implemented")
+// FilterLogs récupère les logs correspondant au filtre (eth_getLogs)
+func (ec *EthereumClient) FilterLogs(ctx context.Context, nodeURL string, filter ports.LogFilter) ([]ports.LogEntry, error) {
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := client.FilterLogs(ctx, goethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(filter.FromBlock),
+		ToBlock:   new(big.Int).SetUint64(filter.ToBlock),
+		Addresses: filter.Addresses,
+		Topics:    filter.Topics,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs: %w", err)
+	}
+
+	entries := make([]ports.LogEntry, 0, len(logs))
+	for _, log := range logs {
+		entries = append(entries, toLogEntry(&log))
+	}
+	return entries, nil
 }
 
-func (ec *EthereumClient) GetTransactionStatus(ctx context.Context, nodeURL string, txHash interface{}) (entities.TransactionStatus, error) {
-	return entities.TxStatusPending, fmt.Errorf("not implemented")
+// toLogEntry convertit un log go-ethereum vers le port
+func toLogEntry(log *types.Log) ports.LogEntry {
+	return ports.LogEntry{
+		Address:     log.Address,
+		Topics:      log.Topics,
+		Data:        log.Data,
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		Index:       log.Index,
+	}
 }
 
-func (ec *EthereumClient) GetTransactionReceipt(ctx context.Context, nodeURL string, txHash interface{}) (*ports.TransactionReceipt, error) {
-	return nil, fmt.Errorf("not implemented")
+// DeployContract déploie un contrat (bytecode + arguments du constructeur encodés)
+func (ec *EthereumClient) DeployContract(ctx context.Context, nodeURL string, contractCode []byte, from common.Address) (common.Address, common.Hash, error) {
+	tx := entities.NewTransaction(from, common.Address{}, big.NewInt(0), entities.TxTypeContract)
+	tx.Data = contractCode
+
+	nonce, err := ec.GetNonce(ctx, nodeURL, from)
+	if err != nil {
+		return common.Address{}, common.Hash{}, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	tx.Nonce = &nonce
+
+	txHash, err := ec.SendTransaction(ctx, nodeURL, tx)
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+
+	return crypto.CreateAddress(from, nonce), txHash, nil
 }
 
-func (ec *EthereumClient) DeployContract(ctx context.Context, nodeURL string, contractCode []byte, from interface{}) (interface{}, interface{}, error) {
-	return nil, nil, fmt.Errorf("not implemented")
+// CallContract exécute un appel en lecture seule sur le dernier bloc
+func (ec *EthereumClient) CallContract(ctx context.Context, nodeURL string, contractAddress common.Address, data []byte) ([]byte, error) {
+	client, err := ec.ethClient(ctx, nodeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.CallContract(ctx, goethereum.CallMsg{
+		To:   &contractAddress,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("contract call failed: %w", err)
+	}
+	return result, nil
 }
 
-func (ec *EthereumClient) CallContract(ctx context.Context, nodeURL string, contractAddress interface{}, data []byte) ([]byte, error) {
-	return nil, fmt.Errorf("not implemented")
+// WaitForReceipt attend qu'une transaction soit minée et retourne son reçu
+func (ec *EthereumClient) WaitForReceipt(ctx context.Context, nodeURL string, txHash common.Hash) (*ports.TransactionReceipt, error) {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := ec.GetTransactionReceipt(ctx, nodeURL, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, goethereum.NotFound) {
+			return nil, err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %s not mined: %w", txHash.Hex(), ctx.Err())
+		}
+	}
 }
 
-func (ec *EthereumClient) GetTokenBalance(ctx context.Context, nodeURL string, tokenAddress, holderAddress interface{}) (*big.Int, error) {
-	return nil, fmt.Errorf("not implemented")
+// Call exécute un appel JSON-RPC brut (namespaces propres à chaque client)
+func (ec *EthereumClient) Call(ctx context.Context, nodeURL string, result interface{}, method string, args ...interface{}) error {
+	client, err := ec.connection(ctx, nodeURL)
+	if err != nil {
+		return err
+	}
+	return client.CallContext(ctx, result, method, args...)
 }
 
-func (ec *EthereumClient) TransferToken(ctx context.Context, nodeURL string, tokenAddress, from, to interface{}, amount *big.Int) (interface{}, error) {
-	return nil, fmt.Errorf("not implemented")
+// ethClient retourne un client ethclient au-dessus de la connexion RPC du node
+func (ec *EthereumClient) ethClient(ctx context.Context, nodeURL string) (*ethclient.Client, error) {
+	client, err := ec.connection(ctx, nodeURL)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(client), nil
 }
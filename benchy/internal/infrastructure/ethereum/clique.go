@@ -0,0 +1,82 @@
+package ethereum
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// cliqueExtraVanity est le préfixe réservé de l'extraData
+	cliqueExtraVanity = 32
+	// cliqueExtraSeal est la taille de la signature du scelleur
+	cliqueExtraSeal = crypto.SignatureLength
+)
+
+var (
+	// DifficultyInTurn est la difficulté d'un bloc scellé à son tour
+	DifficultyInTurn = big.NewInt(2)
+	// DifficultyNoTurn est la difficulté d'un bloc scellé hors tour
+	DifficultyNoTurn = big.NewInt(1)
+)
+
+// RecoverCliqueSigner retrouve l'adresse du validateur qui a scellé le bloc.
+// En Clique le coinbase est vide : le signataire n'est connu que par la
+// signature placée à la fin de l'extraData.
+func RecoverCliqueSigner(header *types.Header) (common.Address, error) {
+	if len(header.Extra) < cliqueExtraVanity+cliqueExtraSeal {
+		return common.Address{}, fmt.Errorf("extra-data too short for a clique seal (%d bytes)", len(header.Extra))
+	}
+
+	signature := header.Extra[len(header.Extra)-cliqueExtraSeal:]
+	pubkey, err := crypto.Ecrecover(clique.SealHash(header).Bytes(), signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid clique seal: %w", err)
+	}
+
+	var signer common.Address
+	copy(signer[:], crypto.Keccak256(pubkey[1:])[12:])
+	return signer, nil
+}
+
+// IsInTurn indique si la difficulté correspond à un bloc scellé à son tour
+func IsInTurn(difficulty *big.Int) bool {
+	return difficulty != nil && difficulty.Cmp(DifficultyInTurn) == 0
+}
+
+// SealLabel retourne un libellé lisible pour la difficulté d'un bloc Clique
+func SealLabel(difficulty *big.Int) string {
+	switch {
+	case difficulty == nil:
+		return "unknown"
+	case difficulty.Cmp(DifficultyInTurn) == 0:
+		return "in-turn"
+	case difficulty.Cmp(DifficultyNoTurn) == 0:
+		return "out-of-turn"
+	default:
+		return fmt.Sprintf("unexpected (%s)", difficulty.String())
+	}
+}
+
+// ParseCliqueSigners extrait la liste des validateurs de l'extraData d'un bloc
+// de checkpoint (genesis ou début d'epoch)
+func ParseCliqueSigners(extra []byte) ([]common.Address, error) {
+	if len(extra) < cliqueExtraVanity+cliqueExtraSeal {
+		return nil, fmt.Errorf("extra-data too short for a clique checkpoint (%d bytes)", len(extra))
+	}
+
+	signersBytes := extra[cliqueExtraVanity : len(extra)-cliqueExtraSeal]
+	if len(signersBytes)%common.AddressLength != 0 {
+		return nil, fmt.Errorf("invalid clique signer list length (%d bytes)", len(signersBytes))
+	}
+
+	signers := make([]common.Address, 0, len(signersBytes)/common.AddressLength)
+	for i := 0; i < len(signersBytes); i += common.AddressLength {
+		signers = append(signers, common.BytesToAddress(signersBytes[i:i+common.AddressLength]))
+	}
+	return signers, nil
+}
@@ -0,0 +1,118 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/contracts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Le client implémente le port Ethereum
+var _ ports.EthereumService = (*EthereumClient)(nil)
+
+// TokenInfo regroupe les métadonnées d'un token ERC20
+type TokenInfo struct {
+	Address     common.Address
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	TotalSupply *big.Int
+}
+
+// GetTokenBalance retourne le solde ERC20 d'une adresse (balanceOf)
+func (ec *EthereumClient) GetTokenBalance(ctx context.Context, nodeURL string, tokenAddress, holderAddress common.Address) (*big.Int, error) {
+	var balance *big.Int
+	if err := ec.callToken(ctx, nodeURL, tokenAddress, &balance, "balanceOf", holderAddress); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// GetTokenAllowance retourne le montant qu'un spender peut dépenser pour owner (allowance)
+func (ec *EthereumClient) GetTokenAllowance(ctx context.Context, nodeURL string, tokenAddress, owner, spender common.Address) (*big.Int, error) {
+	var allowance *big.Int
+	if err := ec.callToken(ctx, nodeURL, tokenAddress, &allowance, "allowance", owner, spender); err != nil {
+		return nil, err
+	}
+	return allowance, nil
+}
+
+// GetTokenInfo retourne le nom, le symbole, les décimales et l'offre totale d'un token
+func (ec *EthereumClient) GetTokenInfo(ctx context.Context, nodeURL string, tokenAddress common.Address) (*TokenInfo, error) {
+	info := &TokenInfo{Address: tokenAddress}
+	if err := ec.callToken(ctx, nodeURL, tokenAddress, &info.Name, "name"); err != nil {
+		return nil, err
+	}
+	if err := ec.callToken(ctx, nodeURL, tokenAddress, &info.Symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := ec.callToken(ctx, nodeURL, tokenAddress, &info.Decimals, "decimals"); err != nil {
+		return nil, err
+	}
+	if err := ec.callToken(ctx, nodeURL, tokenAddress, &info.TotalSupply, "totalSupply"); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// TransferToken envoie des tokens de from vers to (transfer)
+func (ec *EthereumClient) TransferToken(ctx context.Context, nodeURL string, tokenAddress, from, to common.Address, amount *big.Int) (common.Hash, error) {
+	return ec.sendToken(ctx, nodeURL, tokenAddress, from, "transfer", to, amount)
+}
+
+// ApproveToken autorise spender à dépenser amount pour le compte de owner (approve)
+func (ec *EthereumClient) ApproveToken(ctx context.Context, nodeURL string, tokenAddress, owner, spender common.Address, amount *big.Int) (common.Hash, error) {
+	return ec.sendToken(ctx, nodeURL, tokenAddress, owner, "approve", spender, amount)
+}
+
+// TransferTokenFrom dépense l'allowance de spender pour envoyer des tokens de from vers to (transferFrom)
+func (ec *EthereumClient) TransferTokenFrom(ctx context.Context, nodeURL string, tokenAddress, spender, from, to common.Address, amount *big.Int) (common.Hash, error) {
+	return ec.sendToken(ctx, nodeURL, tokenAddress, spender, "transferFrom", from, to, amount)
+}
+
+// callToken appelle une méthode ERC20 en lecture seule et décode son unique résultat
+func (ec *EthereumClient) callToken(ctx context.Context, nodeURL string, tokenAddress common.Address, result interface{}, method string, args ...interface{}) error {
+	erc20, err := contracts.ERC20()
+	if err != nil {
+		return err
+	}
+
+	data, err := erc20.ABI.Pack(method, args...)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+
+	output, err := ec.CallContract(ctx, nodeURL, tokenAddress, data)
+	if err != nil {
+		return err
+	}
+	if len(output) == 0 {
+		return fmt.Errorf("no ERC20 token at %s", tokenAddress.Hex())
+	}
+
+	if err := erc20.ABI.UnpackIntoInterface(result, method, output); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", method, err)
+	}
+	return nil
+}
+
+// sendToken signe et diffuse une transaction appelant une méthode ERC20
+func (ec *EthereumClient) sendToken(ctx context.Context, nodeURL string, tokenAddress, from common.Address, method string, args ...interface{}) (common.Hash, error) {
+	erc20, err := contracts.ERC20()
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	data, err := erc20.ABI.Pack(method, args...)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+
+	tx := entities.NewTransaction(from, tokenAddress, big.NewInt(0), entities.TxTypeERC20)
+	tx.Data = data
+	return ec.SendTransaction(ctx, nodeURL, tx)
+}
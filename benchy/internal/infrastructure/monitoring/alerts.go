@@ -0,0 +1,112 @@
+package monitoring
+
+import (
+	"fmt"
+	"time"
+
+	"benchy/internal/domain/ports"
+)
+
+// Seuils des alertes de ressources, comme CheckNodeHealth
+const (
+	alertCPUThreshold    = 80.0 // Pourcentage d'un cœur
+	alertMemoryThreshold = 80.0 // Pourcentage de la limite du container
+)
+
+// Durée pendant laquelle une alerte résolue reste consultable
+const resolvedAlertRetention = time.Hour
+
+// nodeAlertRule est une alerte évaluée sur chaque échantillon d'un node
+type nodeAlertRule struct {
+	Type     ports.AlertType
+	Severity ports.AlertSeverity
+	// Check retourne le message de l'alerte si elle est déclenchée
+	Check func(c *collector, metrics *ports.NodeMetrics) (string, bool)
+}
+
+// nodeAlertRules sont les alertes levées et résolues par les collecteurs
+var nodeAlertRules = []nodeAlertRule{
+	{
+		Type:     ports.AlertTypeNodeDown,
+		Severity: ports.AlertSeverityCritical,
+		Check: func(c *collector, metrics *ports.NodeMetrics) (string, bool) {
+			return "RPC endpoint is not responding", !metrics.IsOnline
+		},
+	},
+	{
+		Type:     ports.AlertTypeHighCPU,
+		Severity: ports.AlertSeverityWarning,
+		Check: func(c *collector, metrics *ports.NodeMetrics) (string, bool) {
+			return fmt.Sprintf("High CPU usage: %.1f%%", metrics.CPUUsage), metrics.CPUUsage >= alertCPUThreshold
+		},
+	},
+	{
+		Type:     ports.AlertTypeHighMemory,
+		Severity: ports.AlertSeverityWarning,
+		Check: func(c *collector, metrics *ports.NodeMetrics) (string, bool) {
+			return fmt.Sprintf("High memory usage: %.1f%%", metrics.MemoryUsage), metrics.MemoryUsage >= alertMemoryThreshold
+		},
+	},
+	{
+		// Un node seul dans son réseau n'a pas de peer à attendre
+		Type:     ports.AlertTypeSyncIssue,
+		Severity: ports.AlertSeverityWarning,
+		Check: func(c *collector, metrics *ports.NodeMetrics) (string, bool) {
+			return "No connected peers", metrics.IsOnline && metrics.ConnectedPeers == 0 && len(c.nodes) > 1
+		},
+	},
+}
+
+// AlertTypes retourne les types d'alertes levées par les collecteurs et leur sévérité
+func AlertTypes() map[ports.AlertType]ports.AlertSeverity {
+	types := make(map[ports.AlertType]ports.AlertSeverity, len(nodeAlertRules))
+	for _, rule := range nodeAlertRules {
+		types[rule.Type] = rule.Severity
+	}
+	return types
+}
+
+// evaluateAlerts lève ou résout les alertes des nodes d'un réseau à partir d'un tour
+// du collecteur ; à appeler sous sm.mu
+func (sm *SystemMonitor) evaluateAlerts(c *collector, samples []*ports.NodeMetrics, at time.Time) {
+	active := make(map[string]*ports.Alert)
+	alerts := make([]*ports.Alert, 0, len(sm.alerts[c.network]))
+	for _, alert := range sm.alerts[c.network] {
+		switch {
+		case !alert.Resolved && !c.hasNode(alert.NodeName) && alert.NodeName != "":
+			// Le node a quitté le réseau
+			alert.Resolved = true
+			alert.ResolvedAt = at
+		case !alert.Resolved:
+			active[alert.ID] = alert
+		case at.Sub(alert.ResolvedAt) > resolvedAlertRetention:
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+
+	for _, metrics := range samples {
+		for _, rule := range nodeAlertRules {
+			id := fmt.Sprintf("%s/%s", metrics.NodeName, rule.Type)
+			message, firing := rule.Check(c, metrics)
+			alert, raised := active[id]
+			switch {
+			case firing && raised:
+				alert.Message = message
+			case firing:
+				alerts = append(alerts, &ports.Alert{
+					ID:        id,
+					Type:      rule.Type,
+					Severity:  rule.Severity,
+					NodeName:  metrics.NodeName,
+					Message:   message,
+					Timestamp: at,
+				})
+			case raised:
+				alert.Resolved = true
+				alert.ResolvedAt = at
+			}
+		}
+	}
+	sm.alerts[c.network] = alerts
+}
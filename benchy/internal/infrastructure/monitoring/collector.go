@@ -0,0 +1,246 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+)
+
+// Intervalle d'échantillonnage par défaut des nodes
+const DefaultSampleInterval = 5 * time.Second
+
+// Statuts de synchronisation calculés par le collecteur
+const (
+	SyncStatusOffline  = "offline"  // RPC injoignable
+	SyncStatusStarting = "starting" // Aucun bloc ni peer
+	SyncStatusSyncing  = "syncing"  // Des blocs mais aucun peer
+	SyncStatusSynced   = "synced"
+	SyncStatusUnknown  = "unknown" // Pas encore échantillonné
+)
+
+// collector échantillonne en continu les nodes d'un réseau
+type collector struct {
+	network   string
+	nodes     []*entities.Node
+	blockTime time.Duration // Période Clique du genesis
+	interval  time.Duration
+	cancel    context.CancelFunc
+	done      chan struct{} // Fermé à l'arrêt de la goroutine
+
+	mu        sync.Mutex
+	sampledAt time.Time         // Début du dernier tour publié
+	sampled   chan struct{}     // Fermé puis remplacé à la fin de chaque tour
+	rounds    uint64            // Tours publiés
+	failures  map[string]uint64 // Échantillons sans réponse RPC, par node
+}
+
+// collectorCounters sont les compteurs cumulés d'un collecteur depuis son démarrage
+type collectorCounters struct {
+	Rounds   uint64
+	Failures map[string]uint64
+}
+
+// newCollector prépare le collecteur d'un réseau sans le démarrer
+func newCollector(network *entities.Network, interval time.Duration) *collector {
+	nodes := make([]*entities.Node, len(network.Nodes))
+	copy(nodes, network.Nodes)
+	return &collector{
+		network:   network.Name,
+		nodes:     nodes,
+		blockTime: network.BlockTime,
+		interval:  interval,
+		done:      make(chan struct{}),
+		sampled:   make(chan struct{}),
+		failures:  make(map[string]uint64),
+	}
+}
+
+// validators compte les validateurs déclarés du réseau
+func (c *collector) validators() int {
+	count := 0
+	for _, node := range c.nodes {
+		if node.IsValidator {
+			count++
+		}
+	}
+	return count
+}
+
+// hasNode indique si le node fait partie du réseau suivi
+func (c *collector) hasNode(name string) bool {
+	for _, node := range c.nodes {
+		if node.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sampleAfter retourne l'heure du dernier tour s'il est postérieur à since,
+// sinon le canal fermé à la fin du prochain tour
+func (c *collector) sampleAfter(since time.Time) (time.Time, <-chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sampledAt.After(since) {
+		return c.sampledAt, nil
+	}
+	return time.Time{}, c.sampled
+}
+
+// counters retourne une copie des compteurs du collecteur
+func (c *collector) counters() collectorCounters {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counters := collectorCounters{Rounds: c.rounds, Failures: make(map[string]uint64, len(c.failures))}
+	for name, count := range c.failures {
+		counters.Failures[name] = count
+	}
+	return counters
+}
+
+// signalSample compte le tour, publie son heure et réveille les lecteurs qui l'attendaient
+func (c *collector) signalSample(at time.Time, samples []*ports.NodeMetrics) {
+	c.mu.Lock()
+	c.rounds++
+	for _, metrics := range samples {
+		if !metrics.IsOnline {
+			c.failures[metrics.NodeName]++
+		}
+	}
+	c.sampledAt = at
+	close(c.sampled)
+	c.sampled = make(chan struct{})
+	c.mu.Unlock()
+}
+
+// collect échantillonne tous les nodes à chaque intervalle jusqu'à l'annulation du contexte
+func (sm *SystemMonitor) collect(ctx context.Context, c *collector) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		sm.sampleNetwork(ctx, c)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sampleNetwork interroge les nodes en parallèle puis publie leurs métriques
+func (sm *SystemMonitor) sampleNetwork(ctx context.Context, c *collector) {
+	// Un node qui ne répond pas ne doit pas retarder le tour suivant
+	sampleCtx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+	startedAt := time.Now()
+
+	samples := make([]*ports.NodeMetrics, len(c.nodes))
+	var wg sync.WaitGroup
+	for i, node := range c.nodes {
+		previous, _ := sm.latestMetrics(c.network, node.Name)
+		wg.Add(1)
+		go func(i int, node *entities.Node, previous *ports.NodeMetrics) {
+			defer wg.Done()
+			samples[i] = sm.sampleNode(sampleCtx, c, node, previous)
+		}(i, node, previous)
+	}
+	wg.Wait()
+
+	// Un tour interrompu par StopMonitoring n'est pas publié
+	if ctx.Err() != nil {
+		return
+	}
+	sm.mu.Lock()
+	for _, metrics := range samples {
+		sm.nodeMetrics[nodeKey{c.network, metrics.NodeName}] = metrics
+	}
+	sm.evaluateAlerts(c, samples, startedAt)
+	sm.mu.Unlock()
+
+	if sm.history != nil {
+		networkMetrics, _ := sm.GetNetworkMetrics(ctx, c.network)
+		sm.history.Record(samples, networkMetrics)
+	}
+	c.signalSample(startedAt, samples)
+
+	// Une écriture ratée est retentée au tour suivant puis signalée par StopMonitoring
+	if sm.history != nil {
+		_ = sm.history.saveIfDue()
+	}
+}
+
+// sampleNode mesure un node : stats du container, tête de chaîne, peers, txpool et latence RPC
+func (sm *SystemMonitor) sampleNode(ctx context.Context, c *collector, node *entities.Node, previous *ports.NodeMetrics) *ports.NodeMetrics {
+	metrics := &ports.NodeMetrics{
+		NodeName:   node.Name,
+		Timestamp:  time.Now(),
+		SyncStatus: SyncStatusOffline,
+		BlockTime:  c.blockTime,
+	}
+	if previous != nil {
+		metrics.LastSeen = previous.LastSeen
+	}
+
+	if sm.dockerService != nil && node.ContainerID != "" {
+		if stats, err := sm.dockerService.GetContainerStats(ctx, node.ContainerID); err == nil {
+			metrics.CPUUsage = stats.CPUUsage
+			metrics.MemoryBytes = stats.MemoryUsage
+			metrics.MemoryLimit = stats.MemoryLimit
+			if stats.MemoryLimit > 0 {
+				metrics.MemoryUsage = float64(stats.MemoryUsage) / float64(stats.MemoryLimit) * 100
+			}
+			metrics.NetworkIO = ports.NetworkIOMetrics{
+				BytesReceived: stats.NetworkRX,
+				BytesSent:     stats.NetworkTX,
+			}
+		}
+	}
+
+	// eth_blockNumber sert à la fois de test de disponibilité et de mesure de latence
+	nodeURL := fmt.Sprintf("http://localhost:%d", node.RPCPort)
+	start := time.Now()
+	latestBlock, err := sm.ethService.GetLatestBlockNumber(ctx, nodeURL)
+	if err != nil {
+		return metrics
+	}
+	metrics.ResponseTime = time.Since(start)
+	metrics.IsOnline = true
+	metrics.LastSeen = metrics.Timestamp
+	metrics.LatestBlock = latestBlock
+
+	if peers, err := sm.ethService.GetPeerCount(ctx, nodeURL); err == nil {
+		metrics.ConnectedPeers = peers
+	}
+	metrics.PendingTxs, metrics.QueuedTxs = sm.txPool(ctx, node, nodeURL)
+
+	switch {
+	case metrics.ConnectedPeers > 0:
+		metrics.SyncStatus = SyncStatusSynced
+	case metrics.LatestBlock > 0:
+		metrics.SyncStatus = SyncStatusSyncing
+	default:
+		metrics.SyncStatus = SyncStatusStarting
+	}
+	return metrics
+}
+
+// txPool compte les transactions du txpool via l'adapter du client,
+// ou celles du bloc pending si le client du node est inconnu
+func (sm *SystemMonitor) txPool(ctx context.Context, node *entities.Node, nodeURL string) (pending, queued int) {
+	if sm.clients != nil {
+		if adapter, err := sm.clients.Adapter(node.Client); err == nil {
+			if status, err := adapter.TxPoolStatus(ctx, nodeURL); err == nil {
+				return status.Pending, status.Queued
+			}
+		}
+	}
+	pending, _ = sm.ethService.GetPendingTransactionCount(ctx, nodeURL)
+	return pending, 0
+}
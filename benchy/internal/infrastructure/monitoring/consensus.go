@@ -0,0 +1,221 @@
+package monitoring
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Statuts de consensus calculés par l'analyseur
+const (
+	ConsensusStatusHealthy  = "healthy"
+	ConsensusStatusDegraded = "degraded"
+	ConsensusStatusUnknown  = "unknown"
+)
+
+// minHealthyInTurnRatio est le taux minimal de blocs scellés à leur tour
+// pour considérer le consensus comme sain
+const minHealthyInTurnRatio = 90.0
+
+// ConsensusReport représente l'analyse du scellement sur une fenêtre de blocs
+type ConsensusReport struct {
+	NetworkName     string
+	FromBlock       uint64
+	ToBlock         uint64
+	Blocks          int
+	InTurnBlocks    int
+	OutOfTurnBlocks int
+	MissedBlocks    int
+	AvgBlockTime    time.Duration
+	Status          string
+	Validators      []ports.ValidatorMetrics
+
+	// Timeline associe à chaque validateur les blocs qu'il a scellés
+	// (true si le bloc a été scellé à son tour)
+	Timeline    map[common.Address]map[uint64]bool
+	GeneratedAt time.Time
+}
+
+// SignerSet est la liste des validateurs Clique en vigueur à partir d'un bloc
+type SignerSet struct {
+	FromBlock uint64
+	Signers   []common.Address
+}
+
+// ConsensusAnalyzer calcule les statistiques de scellement Clique
+type ConsensusAnalyzer struct{}
+
+// NewConsensusAnalyzer crée un nouvel analyseur de consensus
+func NewConsensusAnalyzer() *ConsensusAnalyzer {
+	return &ConsensusAnalyzer{}
+}
+
+// Analyze parcourt les blocs (triés par numéro croissant) et calcule pour chaque
+// validateur les blocs scellés, le taux de blocs à son tour, les tours manqués
+// et la plus longue absence. sets donne les validateurs en vigueur sur la fenêtre, triés
+// par premier bloc : l'ordre des tours est recalculé à chaque changement de la liste.
+func (ca *ConsensusAnalyzer) Analyze(networkName string, blocks []*ports.BlockInfo, sets []SignerSet) *ConsensusReport {
+	report := &ConsensusReport{
+		NetworkName: networkName,
+		Status:      ConsensusStatusUnknown,
+		Timeline:    make(map[common.Address]map[uint64]bool),
+		GeneratedAt: time.Now(),
+	}
+
+	// Clique ordonne les validateurs par adresse croissante pour déterminer les tours
+	ordered := make([][]common.Address, len(sets))
+	for i, set := range sets {
+		ordered[i] = sortedSigners(set.Signers)
+	}
+	var signers []common.Address
+	if len(ordered) > 0 {
+		signers = ordered[len(ordered)-1]
+	}
+
+	stats := make(map[common.Address]*ports.ValidatorMetrics)
+	lastSealed := make(map[common.Address]uint64)
+	turns := make(map[common.Address]int)
+	track := func(address common.Address) *ports.ValidatorMetrics {
+		metrics, known := stats[address]
+		if !known {
+			metrics = &ports.ValidatorMetrics{Address: address}
+			stats[address] = metrics
+			report.Timeline[address] = make(map[uint64]bool)
+		}
+		return metrics
+	}
+	for _, signer := range signers {
+		track(signer)
+	}
+
+	if len(blocks) == 0 {
+		report.Validators = ca.sortedMetrics(signers, stats)
+		return report
+	}
+
+	report.FromBlock = blocks[0].Number
+	report.ToBlock = blocks[len(blocks)-1].Number
+	report.Blocks = len(blocks)
+
+	current := 0
+	for _, block := range blocks {
+		for current+1 < len(sets) && sets[current+1].FromBlock <= block.Number {
+			current++
+		}
+		var active []common.Address
+		if len(ordered) > 0 {
+			active = ordered[current]
+		}
+
+		// Validateur attendu à ce bloc dans la liste en vigueur
+		var expected common.Address
+		if len(active) > 0 {
+			expected = active[block.Number%uint64(len(active))]
+			turns[expected]++
+		}
+
+		// Un signataire hors de la liste finale (retiré par vote par exemple) reste suivi
+		metrics := track(block.Signer)
+		metrics.SealedBlocks++
+		report.Timeline[block.Signer][block.Number] = ethereum.IsInTurn(block.Difficulty)
+
+		if ethereum.IsInTurn(block.Difficulty) {
+			metrics.InTurnBlocks++
+			report.InTurnBlocks++
+		} else {
+			metrics.OutOfTurnBlocks++
+			report.OutOfTurnBlocks++
+
+			// Le validateur dont c'était le tour n'a pas scellé ce bloc
+			if len(active) > 0 && expected != block.Signer {
+				track(expected).MissedTurns++
+				report.MissedBlocks++
+			}
+		}
+
+		// Mesurer l'absence depuis le dernier sceau (ou le début de la fenêtre)
+		start, sealedBefore := lastSealed[block.Signer]
+		gap := block.Number - report.FromBlock
+		if sealedBefore {
+			gap = block.Number - start - 1
+		}
+		if gap > metrics.LongestGap {
+			metrics.LongestGap = gap
+		}
+		lastSealed[block.Signer] = block.Number
+		metrics.LastSealedBlock = block.Number
+	}
+
+	// Absence en cours jusqu'à la fin de la fenêtre
+	for address, metrics := range stats {
+		gap := report.ToBlock - report.FromBlock + 1
+		if last, sealed := lastSealed[address]; sealed {
+			gap = report.ToBlock - last
+		}
+		if gap > metrics.LongestGap {
+			metrics.LongestGap = gap
+		}
+		if metrics.SealedBlocks > 0 {
+			metrics.InTurnRatio = float64(metrics.InTurnBlocks) / float64(metrics.SealedBlocks) * 100
+		}
+	}
+
+	if len(blocks) > 1 {
+		first, last := blocks[0], blocks[len(blocks)-1]
+		report.AvgBlockTime = time.Duration(last.Timestamp-first.Timestamp) * time.Second / time.Duration(len(blocks)-1)
+	}
+
+	report.Status = ConsensusStatusHealthy
+	if float64(report.InTurnBlocks)/float64(report.Blocks)*100 < minHealthyInTurnRatio {
+		report.Status = ConsensusStatusDegraded
+	}
+	// Un validateur actuel qui n'a rien scellé alors que son tour est venu
+	for _, signer := range signers {
+		if turns[signer] > 0 && stats[signer].SealedBlocks == 0 {
+			report.Status = ConsensusStatusDegraded
+		}
+	}
+
+	report.Validators = ca.sortedMetrics(signers, stats)
+	return report
+}
+
+// sortedSigners retourne une copie des validateurs dans l'ordre Clique
+func sortedSigners(validators []common.Address) []common.Address {
+	signers := make([]common.Address, len(validators))
+	copy(signers, validators)
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i][:], signers[j][:]) < 0
+	})
+	return signers
+}
+
+// sortedMetrics retourne les métriques dans l'ordre Clique, puis les signataires inconnus
+func (ca *ConsensusAnalyzer) sortedMetrics(signers []common.Address, stats map[common.Address]*ports.ValidatorMetrics) []ports.ValidatorMetrics {
+	result := make([]ports.ValidatorMetrics, 0, len(stats))
+	seen := make(map[common.Address]bool)
+
+	for _, signer := range signers {
+		result = append(result, *stats[signer])
+		seen[signer] = true
+	}
+
+	var extra []common.Address
+	for address := range stats {
+		if !seen[address] {
+			extra = append(extra, address)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool {
+		return bytes.Compare(extra[i][:], extra[j][:]) < 0
+	})
+	for _, address := range extra {
+		result = append(result, *stats[address])
+	}
+
+	return result
+}
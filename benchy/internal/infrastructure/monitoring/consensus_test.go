@@ -0,0 +1,159 @@
+package monitoring
+
+import (
+	"testing"
+
+	"benchy/internal/domain/ports"
+	"benchy/internal/infrastructure/ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Validateurs de test, déjà dans l'ordre Clique
+var (
+	signerA = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	signerB = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	signerC = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+)
+
+// sealed décrit un bloc synthétique : son signataire et s'il a été scellé à son tour
+type sealed struct {
+	signer common.Address
+	inTurn bool
+}
+
+// syntheticBlocks numérote les blocs à partir de 1, à 5 secondes d'intervalle
+func syntheticBlocks(seals ...sealed) []*ports.BlockInfo {
+	blocks := make([]*ports.BlockInfo, len(seals))
+	for i, seal := range seals {
+		difficulty := ethereum.DifficultyNoTurn
+		if seal.inTurn {
+			difficulty = ethereum.DifficultyInTurn
+		}
+		blocks[i] = &ports.BlockInfo{
+			Number:     uint64(i + 1),
+			Timestamp:  uint64(i * 5),
+			Difficulty: difficulty,
+			Signer:     seal.signer,
+		}
+	}
+	return blocks
+}
+
+func TestConsensusAnalyzerAnalyze(t *testing.T) {
+	allThree := []SignerSet{{FromBlock: 1, Signers: []common.Address{signerC, signerA, signerB}}}
+
+	tests := []struct {
+		name       string
+		blocks     []*ports.BlockInfo
+		sets       []SignerSet
+		wantInTurn int
+		wantMissed int
+		wantStatus string
+		wantSealed map[common.Address]int
+		wantTurns  map[common.Address]int // Tours manqués par validateur
+	}{
+		{
+			// Bloc n : tour de sorted[n % 3], soit B, C, A, B, C, A
+			name: "every block in turn",
+			blocks: syntheticBlocks(
+				sealed{signerB, true}, sealed{signerC, true}, sealed{signerA, true},
+				sealed{signerB, true}, sealed{signerC, true}, sealed{signerA, true},
+			),
+			sets:       allThree,
+			wantInTurn: 6,
+			wantStatus: ConsensusStatusHealthy,
+			wantSealed: map[common.Address]int{signerA: 2, signerB: 2, signerC: 2},
+			wantTurns:  map[common.Address]int{},
+		},
+		{
+			// C est arrêté : A et B scellent ses blocs hors tour
+			name: "offline validator misses its turns",
+			blocks: syntheticBlocks(
+				sealed{signerB, true}, sealed{signerA, false}, sealed{signerA, true},
+				sealed{signerB, true}, sealed{signerB, false}, sealed{signerA, true},
+			),
+			sets:       allThree,
+			wantInTurn: 4,
+			wantMissed: 2,
+			wantStatus: ConsensusStatusDegraded,
+			wantSealed: map[common.Address]int{signerA: 3, signerB: 3, signerC: 0},
+			wantTurns:  map[common.Address]int{signerC: 2},
+		},
+		{
+			// C est ajouté par vote au bloc 5 : l'ordre des tours passe de A, B à A, B, C
+			name: "signer added during the window",
+			blocks: syntheticBlocks(
+				sealed{signerB, true}, sealed{signerA, true}, sealed{signerB, true}, sealed{signerA, true},
+				sealed{signerC, true}, sealed{signerA, true}, sealed{signerB, true},
+			),
+			sets: []SignerSet{
+				{FromBlock: 1, Signers: []common.Address{signerB, signerA}},
+				{FromBlock: 5, Signers: []common.Address{signerA, signerB, signerC}},
+			},
+			wantInTurn: 7,
+			wantStatus: ConsensusStatusHealthy,
+			wantSealed: map[common.Address]int{signerA: 3, signerB: 3, signerC: 1},
+			wantTurns:  map[common.Address]int{},
+		},
+		{
+			// C est retiré au bloc 4 : il n'est plus attendu et ne dégrade pas le consensus
+			name: "signer removed during the window",
+			blocks: syntheticBlocks(
+				sealed{signerB, true}, sealed{signerC, true}, sealed{signerA, true},
+				sealed{signerA, true}, sealed{signerB, true}, sealed{signerA, true},
+			),
+			sets: []SignerSet{
+				{FromBlock: 1, Signers: []common.Address{signerA, signerB, signerC}},
+				{FromBlock: 4, Signers: []common.Address{signerA, signerB}},
+			},
+			wantInTurn: 6,
+			wantStatus: ConsensusStatusHealthy,
+			wantSealed: map[common.Address]int{signerA: 3, signerB: 2, signerC: 1},
+			wantTurns:  map[common.Address]int{},
+		},
+		{
+			name:       "no blocks",
+			sets:       allThree,
+			wantStatus: ConsensusStatusUnknown,
+			wantSealed: map[common.Address]int{signerA: 0, signerB: 0, signerC: 0},
+			wantTurns:  map[common.Address]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewConsensusAnalyzer().Analyze("benchy", tt.blocks, tt.sets)
+
+			if report.InTurnBlocks != tt.wantInTurn {
+				t.Errorf("InTurnBlocks = %d, want %d", report.InTurnBlocks, tt.wantInTurn)
+			}
+			if report.MissedBlocks != tt.wantMissed {
+				t.Errorf("MissedBlocks = %d, want %d", report.MissedBlocks, tt.wantMissed)
+			}
+			if report.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", report.Status, tt.wantStatus)
+			}
+
+			validators := make(map[common.Address]ports.ValidatorMetrics)
+			for _, metrics := range report.Validators {
+				validators[metrics.Address] = metrics
+			}
+			if len(validators) != len(tt.wantSealed) {
+				t.Errorf("%d validators reported, want %d", len(validators), len(tt.wantSealed))
+			}
+			for address, want := range tt.wantSealed {
+				metrics, exists := validators[address]
+				if !exists {
+					t.Errorf("validator %s missing from the report", address.Hex())
+					continue
+				}
+				if metrics.SealedBlocks != want {
+					t.Errorf("%s sealed %d blocks, want %d", address.Hex(), metrics.SealedBlocks, want)
+				}
+				if metrics.MissedTurns != tt.wantTurns[address] {
+					t.Errorf("%s missed %d turns, want %d", address.Hex(), metrics.MissedTurns, tt.wantTurns[address])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,532 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"benchy/internal/domain/ports"
+)
+
+// Version du format du fichier d'historique
+const historyVersion = 1
+
+// Durée pendant laquelle les échantillons sont gardés à pleine résolution
+const recentWindow = 15 * time.Minute
+
+// Attente maximale du verrou de l'historique, et âge au-delà duquel un verrou est
+// considéré comme laissé par un processus interrompu
+const (
+	historyLockTimeout = 5 * time.Second
+	historyLockStale   = 30 * time.Second
+)
+
+// HistoryPath retourne le fichier d'historique des métriques d'un réseau
+func HistoryPath(baseDir string) string {
+	return filepath.Join(baseDir, "metrics", "history.json")
+}
+
+// MetricsHistory conserve les séries de métriques d'un réseau : les derniers échantillons
+// à pleine résolution, les plus anciens moyennés par pas de resolution jusqu'à retention
+type MetricsHistory struct {
+	path       string
+	interval   time.Duration
+	resolution time.Duration
+	retention  time.Duration
+
+	mu      sync.RWMutex
+	nodes   map[string]*series[ports.NodeMetrics]
+	network *series[ports.NetworkMetrics]
+	savedAt time.Time
+}
+
+// NewMetricsHistory crée un historique vide, enregistré dans path
+func NewMetricsHistory(path string, interval, resolution, retention time.Duration) *MetricsHistory {
+	return &MetricsHistory{
+		path:       path,
+		interval:   interval,
+		resolution: resolution,
+		retention:  retention,
+		nodes:      make(map[string]*series[ports.NodeMetrics]),
+		network:    newNetworkSeries(interval, resolution, retention),
+	}
+}
+
+// historyFile est le contenu du fichier d'historique
+type historyFile struct {
+	Version int                                      `json:"version"`
+	SavedAt time.Time                                `json:"saved_at"`
+	Nodes   map[string]seriesFile[ports.NodeMetrics] `json:"nodes"`
+	Network seriesFile[ports.NetworkMetrics]         `json:"network"`
+}
+
+// readHistoryFile lit le fichier d'historique, nil s'il n'existe pas encore
+func readHistoryFile(path string) (*historyFile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metrics history: %w", err)
+	}
+
+	var file historyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse metrics history %s: %w", path, err)
+	}
+	if file.Version != historyVersion {
+		return nil, fmt.Errorf("unsupported metrics history version %d in %s", file.Version, path)
+	}
+	return &file, nil
+}
+
+// Load remplace l'historique en mémoire par celui du disque, sans ce qui dépasse la rétention
+func (h *MetricsHistory) Load() error {
+	file, err := readHistoryFile(h.path)
+	if err != nil || file == nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-h.retention)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nodes = make(map[string]*series[ports.NodeMetrics], len(file.Nodes))
+	for name, saved := range file.Nodes {
+		nodeSeries := newNodeSeries(h.interval, h.resolution, h.retention)
+		nodeSeries.restore(saved, cutoff)
+		h.nodes[name] = nodeSeries
+	}
+	h.network = newNetworkSeries(h.interval, h.resolution, h.retention)
+	h.network.restore(file.Network, cutoff)
+	h.savedAt = file.SavedAt
+	return nil
+}
+
+// Save écrit l'historique sur disque. Plusieurs processus suivent le même réseau
+// (serve, infos --update) : sous verrou, les points enregistrés par les autres depuis
+// la dernière lecture sont fusionnés avant l'écriture.
+func (h *MetricsHistory) Save() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create metrics directory: %w", err)
+	}
+	unlock, err := lockFile(h.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cutoff := time.Now().Add(-h.retention)
+	// Un fichier illisible a déjà été signalé par Load : il est remplacé. Un fichier daté
+	// de notre dernière lecture ou écriture est déjà en mémoire.
+	onDisk, err := readHistoryFile(h.path)
+	if err == nil && onDisk != nil && !onDisk.SavedAt.Equal(h.savedAt) {
+		h.merge(onDisk, cutoff)
+	}
+
+	file := historyFile{
+		Version: historyVersion,
+		SavedAt: time.Now().UTC(),
+		Nodes:   make(map[string]seriesFile[ports.NodeMetrics], len(h.nodes)),
+	}
+	for name, nodeSeries := range h.nodes {
+		nodeSeries.prune(cutoff)
+		file.Nodes[name] = nodeSeries.snapshot()
+	}
+	h.network.prune(cutoff)
+	file.Network = h.network.snapshot()
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics history: %w", err)
+	}
+	// Écrire à côté puis renommer : un lecteur ne voit jamais un fichier à moitié écrit
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write metrics history: %w", err)
+	}
+	if err := os.Rename(tmp, h.path); err != nil {
+		return fmt.Errorf("failed to write metrics history: %w", err)
+	}
+	h.savedAt = file.SavedAt
+	return nil
+}
+
+// merge ajoute à l'historique en mémoire les séries d'un fichier écrit par un autre processus
+func (h *MetricsHistory) merge(file *historyFile, cutoff time.Time) {
+	for name, saved := range file.Nodes {
+		nodeSeries, exists := h.nodes[name]
+		if !exists {
+			nodeSeries = newNodeSeries(h.interval, h.resolution, h.retention)
+			h.nodes[name] = nodeSeries
+		}
+		nodeSeries.mergeFile(saved, cutoff)
+	}
+	h.network.mergeFile(file.Network, cutoff)
+}
+
+// lockFile prend un verrou exclusif en créant path et retourne la fonction qui le libère
+func lockFile(path string) (func(), error) {
+	deadline := time.Now().Add(historyLockTimeout)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock metrics history: %w", err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > historyLockStale {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to lock metrics history: %s is held by another process", path)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// saveIfDue enregistre l'historique au plus une fois par pas de résolution
+func (h *MetricsHistory) saveIfDue() error {
+	h.mu.RLock()
+	due := time.Since(h.savedAt) >= h.resolution
+	h.mu.RUnlock()
+	if !due {
+		return nil
+	}
+	return h.Save()
+}
+
+// Record ajoute un tour d'échantillonnage aux séries des nodes et du réseau
+func (h *MetricsHistory) Record(nodes []*ports.NodeMetrics, network *ports.NetworkMetrics) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, metrics := range nodes {
+		nodeSeries, exists := h.nodes[metrics.NodeName]
+		if !exists {
+			nodeSeries = newNodeSeries(h.interval, h.resolution, h.retention)
+			h.nodes[metrics.NodeName] = nodeSeries
+		}
+		nodeSeries.push(*metrics)
+	}
+	if network != nil {
+		h.network.push(*network)
+	}
+}
+
+// NodeHistory retourne les métriques d'un node depuis since, de la plus ancienne à la plus récente
+func (h *MetricsHistory) NodeHistory(nodeName string, since time.Time) []*ports.NodeMetrics {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	nodeSeries, exists := h.nodes[nodeName]
+	if !exists {
+		return nil
+	}
+	points := nodeSeries.since(since)
+	history := make([]*ports.NodeMetrics, len(points))
+	for i := range points {
+		history[i] = &points[i]
+	}
+	return history
+}
+
+// NetworkHistory retourne les métriques du réseau depuis since, de la plus ancienne à la plus récente
+func (h *MetricsHistory) NetworkHistory(since time.Time) []*ports.NetworkMetrics {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	points := h.network.since(since)
+	history := make([]*ports.NetworkMetrics, len(points))
+	for i := range points {
+		history[i] = &points[i]
+	}
+	return history
+}
+
+// newNodeSeries crée la série d'un node
+func newNodeSeries(interval, resolution, retention time.Duration) *series[ports.NodeMetrics] {
+	return newSeries(interval, resolution, retention,
+		func(m ports.NodeMetrics) time.Time { return m.Timestamp }, mergeNodeMetrics)
+}
+
+// newNetworkSeries crée la série du réseau
+func newNetworkSeries(interval, resolution, retention time.Duration) *series[ports.NetworkMetrics] {
+	return newSeries(interval, resolution, retention,
+		func(m ports.NetworkMetrics) time.Time { return m.Timestamp }, mergeNetworkMetrics)
+}
+
+// mergeNodeMetrics résume les échantillons d'un pas de résolution. Les pertes de peers
+// et les arrêts restent visibles : minimum de peers, en ligne seulement si toujours en ligne.
+func mergeNodeMetrics(start time.Time, samples []ports.NodeMetrics) ports.NodeMetrics {
+	merged := samples[len(samples)-1]
+	merged.Timestamp = start
+
+	var cpu, memory float64
+	var memoryBytes uint64
+	var responseTime time.Duration
+	online := 0
+	for _, sample := range samples {
+		cpu += sample.CPUUsage
+		memory += sample.MemoryUsage
+		memoryBytes += sample.MemoryBytes
+		if sample.LatestBlock > merged.LatestBlock {
+			merged.LatestBlock = sample.LatestBlock
+		}
+		if sample.ConnectedPeers < merged.ConnectedPeers {
+			merged.ConnectedPeers = sample.ConnectedPeers
+		}
+		if sample.PendingTxs > merged.PendingTxs {
+			merged.PendingTxs = sample.PendingTxs
+		}
+		if sample.QueuedTxs > merged.QueuedTxs {
+			merged.QueuedTxs = sample.QueuedTxs
+		}
+		if sample.IsOnline {
+			online++
+			responseTime += sample.ResponseTime
+		}
+	}
+	count := len(samples)
+	merged.CPUUsage = cpu / float64(count)
+	merged.MemoryUsage = memory / float64(count)
+	merged.MemoryBytes = memoryBytes / uint64(count)
+	merged.IsOnline = online == count
+	merged.ResponseTime = 0
+	if online > 0 {
+		merged.ResponseTime = responseTime / time.Duration(online)
+	}
+	return merged
+}
+
+// mergeNetworkMetrics résume les échantillons réseau d'un pas de résolution
+func mergeNetworkMetrics(start time.Time, samples []ports.NetworkMetrics) ports.NetworkMetrics {
+	merged := samples[len(samples)-1]
+	merged.Timestamp = start
+
+	var latency time.Duration
+	for _, sample := range samples {
+		latency += sample.NetworkLatency
+		if sample.OnlineNodes < merged.OnlineNodes {
+			merged.OnlineNodes = sample.OnlineNodes
+		}
+		if sample.LatestBlock > merged.LatestBlock {
+			merged.LatestBlock = sample.LatestBlock
+		}
+	}
+	merged.NetworkLatency = latency / time.Duration(len(samples))
+	return merged
+}
+
+// series est une série temporelle à deux niveaux : un anneau d'échantillons récents et un
+// anneau de points moyennés ; un échantillon qui sort du premier rejoint son pas de résolution
+type series[T any] struct {
+	resolution time.Duration
+	timestamp  func(T) time.Time
+	merge      func(start time.Time, samples []T) T
+
+	recent  *ring[T]
+	older   *ring[T]
+	pending []T // Échantillons sortis de recent dont le pas de résolution n'est pas terminé
+}
+
+// seriesFile est la forme enregistrée d'une série
+type seriesFile[T any] struct {
+	Older   []T `json:"older"`
+	Pending []T `json:"pending"`
+	Recent  []T `json:"recent"`
+}
+
+// newSeries dimensionne les anneaux d'après l'intervalle, la résolution et la rétention
+func newSeries[T any](interval, resolution, retention time.Duration, timestamp func(T) time.Time, merge func(time.Time, []T) T) *series[T] {
+	window := recentWindow
+	if retention < window {
+		window = retention
+	}
+	return &series[T]{
+		resolution: resolution,
+		timestamp:  timestamp,
+		merge:      merge,
+		recent:     newRing[T](steps(window, interval)),
+		older:      newRing[T](steps(retention, resolution)),
+	}
+}
+
+// steps retourne le nombre de pas nécessaires pour couvrir une durée
+func steps(window, step time.Duration) int {
+	if step <= 0 {
+		return 1
+	}
+	n := int((window + step - 1) / step)
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// push ajoute un échantillon ; le plus ancien échantillon récent est sous-échantillonné
+func (s *series[T]) push(sample T) {
+	evicted, full := s.recent.push(sample)
+	if !full {
+		return
+	}
+	if len(s.pending) > 0 && !s.sameStep(s.pending[0], evicted) {
+		s.flushPending()
+	}
+	s.pending = append(s.pending, evicted)
+}
+
+// sameStep indique si deux échantillons tombent dans le même pas de résolution
+func (s *series[T]) sameStep(a, b T) bool {
+	return s.timestamp(a).Truncate(s.resolution).Equal(s.timestamp(b).Truncate(s.resolution))
+}
+
+// flushPending moyenne les échantillons en attente en un point de la série ancienne
+func (s *series[T]) flushPending() {
+	start := s.timestamp(s.pending[0]).Truncate(s.resolution)
+	s.older.push(s.merge(start, s.pending))
+	s.pending = nil
+}
+
+// since retourne les points postérieurs à since, de l'ancien au récent
+func (s *series[T]) since(since time.Time) []T {
+	var points []T
+	for _, part := range [][]T{s.older.items(), s.pending, s.recent.items()} {
+		for _, point := range part {
+			if !s.timestamp(point).Before(since) {
+				points = append(points, point)
+			}
+		}
+	}
+	return points
+}
+
+// prune oublie les points antérieurs à cutoff
+func (s *series[T]) prune(cutoff time.Time) {
+	s.older.dropWhile(func(point T) bool { return s.timestamp(point).Before(cutoff) })
+	for len(s.pending) > 0 && s.timestamp(s.pending[0]).Before(cutoff) {
+		s.pending = s.pending[1:]
+	}
+	s.recent.dropWhile(func(point T) bool { return s.timestamp(point).Before(cutoff) })
+}
+
+// snapshot retourne la forme enregistrée de la série
+func (s *series[T]) snapshot() seriesFile[T] {
+	return seriesFile[T]{
+		Older:   s.older.items(),
+		Pending: append([]T(nil), s.pending...),
+		Recent:  s.recent.items(),
+	}
+}
+
+// mergeFile fusionne une série enregistrée par un autre processus. Un pas déjà résumé garde
+// son point, le nôtre en cas de doublon ; les échantillons bruts des deux séries qui
+// suivent le dernier pas résumé sont triés puis rejoués.
+func (s *series[T]) mergeFile(saved seriesFile[T], cutoff time.Time) {
+	mine := s.snapshot()
+
+	older := make(map[int64]T)
+	for _, part := range [][]T{saved.Older, mine.Older} {
+		for _, point := range part {
+			older[s.timestamp(point).UnixNano()] = point
+		}
+	}
+	steps := make([]int64, 0, len(older))
+	for step := range older {
+		steps = append(steps, step)
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i] < steps[j] })
+
+	raw := make(map[int64]T)
+	for _, part := range [][]T{saved.Pending, saved.Recent, mine.Pending, mine.Recent} {
+		for _, point := range part {
+			step := s.timestamp(point).Truncate(s.resolution).UnixNano()
+			if len(steps) > 0 && step <= steps[len(steps)-1] {
+				continue // Pas déjà résumé
+			}
+			raw[s.timestamp(point).UnixNano()] = point
+		}
+	}
+	samples := make([]int64, 0, len(raw))
+	for at := range raw {
+		samples = append(samples, at)
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	s.older = newRing[T](len(s.older.buffer))
+	s.recent = newRing[T](len(s.recent.buffer))
+	s.pending = nil
+	for _, step := range steps {
+		s.older.push(older[step])
+	}
+	for _, at := range samples {
+		s.push(raw[at])
+	}
+	s.prune(cutoff)
+}
+
+// restore recharge une série enregistrée ; les anneaux ont pu changer de taille entre-temps
+func (s *series[T]) restore(saved seriesFile[T], cutoff time.Time) {
+	for _, point := range saved.Older {
+		s.older.push(point)
+	}
+	s.pending = append(s.pending, saved.Pending...)
+	for _, point := range saved.Recent {
+		s.push(point)
+	}
+	s.prune(cutoff)
+}
+
+// ring est un tampon circulaire de taille fixe
+type ring[T any] struct {
+	buffer []T
+	start  int
+	size   int
+}
+
+// newRing crée un anneau de capacité fixe
+func newRing[T any](capacity int) *ring[T] {
+	return &ring[T]{buffer: make([]T, capacity)}
+}
+
+// push ajoute un élément ; un anneau plein retourne l'élément le plus ancien qu'il remplace
+func (r *ring[T]) push(item T) (evicted T, full bool) {
+	if r.size < len(r.buffer) {
+		r.buffer[(r.start+r.size)%len(r.buffer)] = item
+		r.size++
+		return evicted, false
+	}
+	evicted = r.buffer[r.start]
+	r.buffer[r.start] = item
+	r.start = (r.start + 1) % len(r.buffer)
+	return evicted, true
+}
+
+// items retourne une copie des éléments, du plus ancien au plus récent
+func (r *ring[T]) items() []T {
+	items := make([]T, r.size)
+	for i := range items {
+		items[i] = r.buffer[(r.start+i)%len(r.buffer)]
+	}
+	return items
+}
+
+// dropWhile retire les plus anciens éléments tant que drop est vrai
+func (r *ring[T]) dropWhile(drop func(T) bool) {
+	var zero T
+	for r.size > 0 && drop(r.buffer[r.start]) {
+		r.buffer[r.start] = zero
+		r.start = (r.start + 1) % len(r.buffer)
+		r.size--
+	}
+}
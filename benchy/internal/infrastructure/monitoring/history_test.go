@@ -0,0 +1,181 @@
+package monitoring
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"benchy/internal/domain/ports"
+)
+
+// point est un échantillon minimal : son heure et une valeur
+type point struct {
+	At    time.Time
+	Value int
+}
+
+// Début des séries de test, aligné sur le pas de résolution de 2 secondes
+var seriesStart = time.Unix(1000, 0)
+
+// newTestSeries crée une série d'échantillons à la seconde, résumés par pas de 2 secondes
+// en sommant leurs valeurs : 4 échantillons récents et 2 pas résumés
+func newTestSeries() *series[point] {
+	return newSeries(time.Second, 2*time.Second, 4*time.Second,
+		func(p point) time.Time { return p.At },
+		func(start time.Time, samples []point) point {
+			merged := point{At: start}
+			for _, sample := range samples {
+				merged.Value += sample.Value
+			}
+			return merged
+		})
+}
+
+// samplesAt retourne les échantillons de valeur v à seriesStart + v secondes
+func samplesAt(values ...int) []point {
+	points := make([]point, len(values))
+	for i, v := range values {
+		points[i] = point{At: seriesStart.Add(time.Duration(v) * time.Second), Value: v}
+	}
+	return points
+}
+
+// values extrait les valeurs d'une liste de points
+func values(points []point) []int {
+	result := make([]int, len(points))
+	for i, p := range points {
+		result[i] = p.Value
+	}
+	return result
+}
+
+func TestSeriesPush(t *testing.T) {
+	tests := []struct {
+		name        string
+		pushed      int
+		wantRecent  []int
+		wantPending []int
+		wantOlder   []int // Sommes des pas résumés
+	}{
+		{name: "recent not full", pushed: 3, wantRecent: []int{0, 1, 2}},
+		{name: "recent full", pushed: 4, wantRecent: []int{0, 1, 2, 3}},
+		{name: "first eviction waits for its step", pushed: 6, wantRecent: []int{2, 3, 4, 5}, wantPending: []int{0, 1}},
+		{name: "next step flushes the previous one", pushed: 7, wantRecent: []int{3, 4, 5, 6}, wantPending: []int{2}, wantOlder: []int{0 + 1}},
+		{name: "older ring evicts the oldest step", pushed: 12, wantRecent: []int{8, 9, 10, 11}, wantPending: []int{6, 7}, wantOlder: []int{2 + 3, 4 + 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSeries()
+			for _, sample := range samplesAt(seq(tt.pushed)...) {
+				s.push(sample)
+			}
+			snapshot := s.snapshot()
+			if got := values(snapshot.Recent); !equalInts(got, tt.wantRecent) {
+				t.Errorf("recent = %v, want %v", got, tt.wantRecent)
+			}
+			if got := values(snapshot.Pending); !equalInts(got, tt.wantPending) {
+				t.Errorf("pending = %v, want %v", got, tt.wantPending)
+			}
+			if got := values(snapshot.Older); !equalInts(got, tt.wantOlder) {
+				t.Errorf("older = %v, want %v", got, tt.wantOlder)
+			}
+		})
+	}
+}
+
+func TestSeriesMergeFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		mine  []int
+		saved seriesFile[point]
+		want  []int // Valeurs retournées par since, de l'ancien au récent
+	}{
+		{
+			name:  "interleaved samples of two writers",
+			mine:  []int{0, 2, 4},
+			saved: seriesFile[point]{Recent: samplesAt(1, 3, 5)},
+			want:  []int{0, 1, 2, 3, 4, 5},
+		},
+		{
+			name:  "duplicate samples are kept once",
+			mine:  []int{0, 1, 2},
+			saved: seriesFile[point]{Recent: samplesAt(1, 2, 3)},
+			want:  []int{0, 1, 2, 3},
+		},
+		{
+			name: "summarised step keeps its point",
+			mine: []int{4, 5, 6},
+			saved: seriesFile[point]{
+				Older:  []point{{At: seriesStart, Value: 100}},
+				Recent: samplesAt(0, 1, 7),
+			},
+			want: []int{100, 4, 5, 6, 7},
+		},
+		{
+			name:  "empty file changes nothing",
+			mine:  []int{0, 1},
+			saved: seriesFile[point]{},
+			want:  []int{0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSeries()
+			for _, sample := range samplesAt(tt.mine...) {
+				s.push(sample)
+			}
+			s.mergeFile(tt.saved, time.Time{})
+			if got := values(s.since(time.Time{})); !equalInts(got, tt.want) {
+				t.Errorf("since = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricsHistorySaveMergesOtherWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	now := time.Now()
+	first := NewMetricsHistory(path, time.Second, time.Minute, time.Hour)
+	second := NewMetricsHistory(path, time.Second, time.Minute, time.Hour)
+	first.Record([]*ports.NodeMetrics{{NodeName: "alice", Timestamp: now}}, &ports.NetworkMetrics{Timestamp: now})
+	second.Record([]*ports.NodeMetrics{{NodeName: "bob", Timestamp: now.Add(time.Second)}}, &ports.NetworkMetrics{Timestamp: now.Add(time.Second)})
+
+	for _, history := range []*MetricsHistory{first, second, first} {
+		if err := history.Save(); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	loaded := NewMetricsHistory(path, time.Second, time.Minute, time.Hour)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for _, name := range []string{"alice", "bob"} {
+		if got := len(loaded.NodeHistory(name, time.Time{})); got != 1 {
+			t.Errorf("%s has %d samples, want 1", name, got)
+		}
+	}
+	if got := len(loaded.NetworkHistory(time.Time{})); got != 2 {
+		t.Errorf("network has %d samples, want 2", got)
+	}
+}
+
+// seq retourne 0, 1, ..., n-1
+func seq(n int) []int {
+	result := make([]int, n)
+	for i := range result {
+		result[i] = i
+	}
+	return result
+}
+
+// equalInts compare deux listes, nil et vide étant équivalentes
+func equalInts(a, b []int) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,273 @@
+package monitoring
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+)
+
+// Content-Type du format texte d'exposition de Prometheus
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Types de métriques Prometheus
+const (
+	gauge   = "gauge"
+	counter = "counter"
+)
+
+// nodeFamily est une métrique exportée pour chaque node, étiquetée par node, client et rôle
+type nodeFamily struct {
+	Name string
+	Type string
+	Help string
+	// Value retourne la valeur du node, false si elle n'a pas de sens pour cet échantillon
+	Value func(metrics *ports.NodeMetrics, counters collectorCounters) (float64, bool)
+}
+
+// onlineOnly n'exporte une valeur de la chaîne que si le node a répondu au dernier tour
+func onlineOnly(value func(metrics *ports.NodeMetrics) float64) func(*ports.NodeMetrics, collectorCounters) (float64, bool) {
+	return func(metrics *ports.NodeMetrics, _ collectorCounters) (float64, bool) {
+		return value(metrics), metrics.IsOnline
+	}
+}
+
+// nodeFamilies sont les métriques exportées par node, dans l'ordre d'exposition
+var nodeFamilies = []nodeFamily{
+	{"benchy_node_up", gauge, "Whether the node answered its last RPC probe (1) or not (0).",
+		func(metrics *ports.NodeMetrics, _ collectorCounters) (float64, bool) {
+			return boolValue(metrics.IsOnline), true
+		}},
+	{"benchy_node_head_block", gauge, "Latest block number seen by the node.",
+		onlineOnly(func(metrics *ports.NodeMetrics) float64 { return float64(metrics.LatestBlock) })},
+	{"benchy_node_peers", gauge, "Number of peers connected to the node.",
+		onlineOnly(func(metrics *ports.NodeMetrics) float64 { return float64(metrics.ConnectedPeers) })},
+	{"benchy_node_pending_txs", gauge, "Pending transactions in the node txpool.",
+		onlineOnly(func(metrics *ports.NodeMetrics) float64 { return float64(metrics.PendingTxs) })},
+	{"benchy_node_queued_txs", gauge, "Queued (non-executable) transactions in the node txpool.",
+		onlineOnly(func(metrics *ports.NodeMetrics) float64 { return float64(metrics.QueuedTxs) })},
+	{"benchy_node_rpc_latency_seconds", gauge, "Duration of the last eth_blockNumber call.",
+		onlineOnly(func(metrics *ports.NodeMetrics) float64 { return metrics.ResponseTime.Seconds() })},
+	{"benchy_node_cpu_usage_percent", gauge, "CPU usage of the node container, in percent of one core.",
+		func(metrics *ports.NodeMetrics, _ collectorCounters) (float64, bool) {
+			return metrics.CPUUsage, metrics.SyncStatus != SyncStatusUnknown
+		}},
+	{"benchy_node_memory_bytes", gauge, "Memory used by the node container.",
+		func(metrics *ports.NodeMetrics, _ collectorCounters) (float64, bool) {
+			return float64(metrics.MemoryBytes), metrics.MemoryBytes > 0
+		}},
+	{"benchy_node_memory_limit_bytes", gauge, "Memory limit of the node container, absent when unlimited.",
+		func(metrics *ports.NodeMetrics, _ collectorCounters) (float64, bool) {
+			return float64(metrics.MemoryLimit), metrics.MemoryLimit > 0
+		}},
+	{"benchy_node_network_receive_bytes_total", counter, "Bytes received by the node container.",
+		func(metrics *ports.NodeMetrics, _ collectorCounters) (float64, bool) {
+			return float64(metrics.NetworkIO.BytesReceived), metrics.NetworkIO.BytesReceived > 0
+		}},
+	{"benchy_node_network_transmit_bytes_total", counter, "Bytes sent by the node container.",
+		func(metrics *ports.NodeMetrics, _ collectorCounters) (float64, bool) {
+			return float64(metrics.NetworkIO.BytesSent), metrics.NetworkIO.BytesSent > 0
+		}},
+	{"benchy_node_last_seen_timestamp_seconds", gauge, "Unix time of the last successful RPC probe of the node.",
+		func(metrics *ports.NodeMetrics, _ collectorCounters) (float64, bool) {
+			return float64(metrics.LastSeen.Unix()), !metrics.LastSeen.IsZero()
+		}},
+	{"benchy_node_probe_failures_total", counter, "Collector rounds where the node did not answer, since benchy started.",
+		func(metrics *ports.NodeMetrics, counters collectorCounters) (float64, bool) {
+			return float64(counters.Failures[metrics.NodeName]), true
+		}},
+}
+
+// PrometheusExporter expose les métriques d'un réseau suivi au format texte de Prometheus
+type PrometheusExporter struct {
+	monitor *SystemMonitor
+	network string
+}
+
+// NewPrometheusExporter crée l'exporteur des métriques du réseau networkName,
+// dont le collecteur doit être démarré sur monitor
+func NewPrometheusExporter(monitor *SystemMonitor, networkName string) *PrometheusExporter {
+	return &PrometheusExporter{monitor: monitor, network: networkName}
+}
+
+// ServeHTTP répond à un scrape de Prometheus
+func (e *PrometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", prometheusContentType)
+	if err := e.WriteMetrics(r.Context(), w); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	}
+}
+
+// WriteMetrics écrit les dernières métriques du collecteur : une série par node et par
+// métrique, puis les métriques du réseau, des validateurs et des alertes actives
+func (e *PrometheusExporter) WriteMetrics(ctx context.Context, w io.Writer) error {
+	nodes, counters, exists := e.monitor.monitoredNodes(e.network)
+	if !exists {
+		return fmt.Errorf("network %s is not monitored", e.network)
+	}
+	networkMetrics, err := e.monitor.GetNetworkMetrics(ctx, e.network)
+	if err != nil {
+		return fmt.Errorf("failed to get network metrics: %w", err)
+	}
+	alerts, err := e.monitor.GetActiveAlerts(ctx, e.network)
+	if err != nil {
+		return fmt.Errorf("failed to get active alerts: %w", err)
+	}
+
+	out := &expositionWriter{w: bufio.NewWriter(w)}
+	network := label("network", e.network)
+
+	nodeMetrics := make([]*ports.NodeMetrics, len(nodes))
+	for i, node := range nodes {
+		nodeMetrics[i], _ = e.monitor.latestMetrics(e.network, node.Name)
+	}
+	for _, family := range nodeFamilies {
+		out.family(family.Name, family.Type, family.Help)
+		for i, node := range nodes {
+			if nodeMetrics[i] == nil {
+				continue
+			}
+			if value, ok := family.Value(nodeMetrics[i], counters); ok {
+				out.sample(family.Name, nodeLabels(network, node), value)
+			}
+		}
+	}
+
+	out.family("benchy_network_nodes", gauge, "Nodes declared in the network manifest.")
+	out.sample("benchy_network_nodes", network, float64(networkMetrics.TotalNodes))
+	out.family("benchy_network_online_nodes", gauge, "Nodes that answered the last collector round.")
+	out.sample("benchy_network_online_nodes", network, float64(networkMetrics.OnlineNodes))
+	out.family("benchy_network_validators", gauge, "Clique validators of the network.")
+	out.sample("benchy_network_validators", network, float64(networkMetrics.ValidatorNodes))
+	out.family("benchy_network_head_block", gauge, "Highest block number seen on the network.")
+	out.sample("benchy_network_head_block", network, float64(networkMetrics.LatestBlock))
+	out.family("benchy_network_block_time_seconds", gauge, "Average block time over the analyzed blocks, or the Clique period before any analysis.")
+	out.sample("benchy_network_block_time_seconds", network, networkMetrics.AvgBlockTime.Seconds())
+	out.family("benchy_network_rpc_latency_seconds", gauge, "Average RPC latency of the online nodes.")
+	out.sample("benchy_network_rpc_latency_seconds", network, networkMetrics.NetworkLatency.Seconds())
+	out.family("benchy_network_missed_blocks", gauge, "In-turn slots sealed out of turn over the analyzed blocks.")
+	out.sample("benchy_network_missed_blocks", network, float64(networkMetrics.MissedBlocks))
+	out.family("benchy_network_consensus_status", gauge, "Consensus status of the network from the last sealing analysis (1 for the current status).")
+	out.sample("benchy_network_consensus_status", network+","+label("status", networkMetrics.ConsensusStatus), 1)
+	out.family("benchy_collector_rounds_total", counter, "Collector rounds published since benchy started.")
+	out.sample("benchy_collector_rounds_total", network, float64(counters.Rounds))
+
+	// Activité de scellement, connue après une première analyse des blocs
+	if len(networkMetrics.Validators) > 0 {
+		validatorFamilies := []struct {
+			name, help string
+			value      func(ports.ValidatorMetrics) float64
+		}{
+			{"benchy_validator_sealed_blocks", "Blocks sealed by the validator over the analyzed blocks.",
+				func(v ports.ValidatorMetrics) float64 { return float64(v.SealedBlocks) }},
+			{"benchy_validator_in_turn_ratio", "Share of the validator blocks sealed in turn (0-1).",
+				func(v ports.ValidatorMetrics) float64 { return v.InTurnRatio / 100 }},
+			{"benchy_validator_missed_turns", "In-turn slots of the validator sealed by another validator.",
+				func(v ports.ValidatorMetrics) float64 { return float64(v.MissedTurns) }},
+			{"benchy_validator_last_sealed_block", "Last block sealed by the validator.",
+				func(v ports.ValidatorMetrics) float64 { return float64(v.LastSealedBlock) }},
+		}
+		for _, family := range validatorFamilies {
+			out.family(family.name, gauge, family.help)
+			for _, validator := range networkMetrics.Validators {
+				labels := network + "," + label("validator", validator.Name) + "," + label("address", validator.Address.Hex())
+				out.sample(family.name, labels, family.value(validator))
+			}
+		}
+	}
+
+	// Une série par type d'alerte, à 0 quand aucune n'est active
+	counts := make(map[ports.AlertType]map[ports.AlertSeverity]int)
+	for alertType, severity := range AlertTypes() {
+		counts[alertType] = map[ports.AlertSeverity]int{severity: 0}
+	}
+	for _, alert := range alerts {
+		if counts[alert.Type] == nil {
+			counts[alert.Type] = make(map[ports.AlertSeverity]int)
+		}
+		counts[alert.Type][alert.Severity]++
+	}
+	types := make([]string, 0, len(counts))
+	for alertType := range counts {
+		types = append(types, string(alertType))
+	}
+	sort.Strings(types)
+	out.family("benchy_alerts_active", gauge, "Active alerts of the network by type and severity.")
+	for _, alertType := range types {
+		severities := counts[ports.AlertType(alertType)]
+		names := make([]string, 0, len(severities))
+		for severity := range severities {
+			names = append(names, string(severity))
+		}
+		sort.Strings(names)
+		for _, severity := range names {
+			labels := network + "," + label("type", alertType) + "," + label("severity", severity)
+			out.sample("benchy_alerts_active", labels, float64(severities[ports.AlertSeverity(severity)]))
+		}
+	}
+
+	return out.flush()
+}
+
+// nodeLabels retourne les labels d'une série de node
+func nodeLabels(network string, node *entities.Node) string {
+	return strings.Join([]string{
+		network,
+		label("node", node.Name),
+		label("client", string(node.Client)),
+		label("validator", strconv.FormatBool(node.IsValidator)),
+	}, ",")
+}
+
+// labelEscaper échappe une valeur de label selon le format d'exposition
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// label formate une paire nom="valeur"
+func label(name, value string) string {
+	return name + `="` + labelEscaper.Replace(value) + `"`
+}
+
+// boolValue convertit un booléen en valeur de gauge
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// expositionWriter écrit le format texte de Prometheus en gardant la première erreur
+type expositionWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// family écrit les lignes HELP et TYPE d'une métrique
+func (e *expositionWriter) family(name, metricType, help string) {
+	e.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+// sample écrit une série de la métrique
+func (e *expositionWriter) sample(name, labels string, value float64) {
+	e.printf("%s{%s} %s\n", name, labels, strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func (e *expositionWriter) printf(format string, args ...interface{}) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
+
+// flush vide le tampon et retourne la première erreur d'écriture
+func (e *expositionWriter) flush() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
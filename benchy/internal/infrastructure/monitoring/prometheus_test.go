@@ -0,0 +1,228 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"benchy/internal/domain/entities"
+	"benchy/internal/domain/ports"
+)
+
+// Labels des séries des deux nodes du réseau de test
+const (
+	aliceLabels = `network="lab",node="alice",client="geth",validator="true"`
+	bobLabels   = `network="lab",node="bob",client="nethermind",validator="false"`
+)
+
+// monitoredNetwork retourne un moniteur qui suit le réseau lab comme après 7 tours du
+// collecteur : alice, validateur, répond et bob, arrêté, a manqué 3 tours
+func monitoredNetwork() *SystemMonitor {
+	network := entities.NewNetwork("lab", entities.ChainParams{BlockTime: 5 * time.Second})
+	network.AddNode(&entities.Node{Name: "alice", Client: entities.ClientGeth, IsValidator: true})
+	network.AddNode(&entities.Node{Name: "bob", Client: entities.ClientNethermind})
+
+	sm := NewSystemMonitor(nil, nil, nil)
+	c := newCollector(network, time.Second)
+	c.rounds = 7
+	c.failures["bob"] = 3
+	sm.collectors[network.Name] = c
+	sm.nodeMetrics[nodeKey{"lab", "alice"}] = &ports.NodeMetrics{
+		NodeName:       "alice",
+		IsOnline:       true,
+		SyncStatus:     SyncStatusSynced,
+		LatestBlock:    42,
+		ConnectedPeers: 1,
+		PendingTxs:     2,
+		ResponseTime:   5 * time.Millisecond,
+		CPUUsage:       12.5,
+		MemoryBytes:    1 << 20,
+		NetworkIO:      ports.NetworkIOMetrics{BytesReceived: 100},
+		LastSeen:       time.Unix(1700000000, 0),
+	}
+	sm.nodeMetrics[nodeKey{"lab", "bob"}] = &ports.NodeMetrics{NodeName: "bob", SyncStatus: SyncStatusOffline}
+	sm.alerts["lab"] = []*ports.Alert{
+		{ID: "1", Type: ports.AlertTypeNodeDown, Severity: ports.AlertSeverityCritical, NodeName: "bob"},
+		{ID: "2", Type: ports.AlertTypeHighCPU, Severity: ports.AlertSeverityWarning, NodeName: "alice", Resolved: true},
+	}
+	return sm
+}
+
+func TestPrometheusExporterWriteMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		report     *ConsensusReport // Analyse de scellement enregistrée, nil sans analyse
+		wantLines  []string
+		wantAbsent []string // Préfixes de séries qui ne doivent pas apparaître
+	}{
+		{
+			name: "collector round without sealing analysis",
+			wantLines: []string{
+				`benchy_node_up{` + aliceLabels + `} 1`,
+				`benchy_node_up{` + bobLabels + `} 0`,
+				`benchy_node_head_block{` + aliceLabels + `} 42`,
+				`benchy_node_peers{` + aliceLabels + `} 1`,
+				`benchy_node_pending_txs{` + aliceLabels + `} 2`,
+				`benchy_node_rpc_latency_seconds{` + aliceLabels + `} 0.005`,
+				`benchy_node_cpu_usage_percent{` + aliceLabels + `} 12.5`,
+				`benchy_node_memory_bytes{` + aliceLabels + `} 1048576`,
+				`benchy_node_network_receive_bytes_total{` + aliceLabels + `} 100`,
+				`benchy_node_last_seen_timestamp_seconds{` + aliceLabels + `} 1700000000`,
+				`benchy_node_probe_failures_total{` + aliceLabels + `} 0`,
+				`benchy_node_probe_failures_total{` + bobLabels + `} 3`,
+				`benchy_network_nodes{network="lab"} 2`,
+				`benchy_network_online_nodes{network="lab"} 1`,
+				`benchy_network_validators{network="lab"} 1`,
+				`benchy_network_head_block{network="lab"} 42`,
+				`benchy_network_block_time_seconds{network="lab"} 5`,
+				`benchy_network_rpc_latency_seconds{network="lab"} 0.005`,
+				`benchy_network_consensus_status{network="lab",status="healthy"} 1`,
+				`benchy_collector_rounds_total{network="lab"} 7`,
+				`benchy_alerts_active{network="lab",type="node_down",severity="critical"} 1`,
+				`benchy_alerts_active{network="lab",type="high_cpu",severity="warning"} 0`,
+			},
+			wantAbsent: []string{
+				`benchy_node_head_block{` + bobLabels,
+				`benchy_node_rpc_latency_seconds{` + bobLabels,
+				`benchy_node_last_seen_timestamp_seconds{` + bobLabels,
+				`benchy_node_memory_limit_bytes{`,
+				`benchy_node_network_transmit_bytes_total{`,
+				`benchy_validator_`,
+			},
+		},
+		{
+			name: "sealing analysis recorded",
+			report: &ConsensusReport{
+				NetworkName:  "lab",
+				ToBlock:      50,
+				MissedBlocks: 2,
+				AvgBlockTime: 6 * time.Second,
+				Status:       ConsensusStatusDegraded,
+				Validators: []ports.ValidatorMetrics{
+					{Address: signerA, Name: "alice", SealedBlocks: 8, InTurnRatio: 75, MissedTurns: 1, LastSealedBlock: 49},
+				},
+			},
+			wantLines: []string{
+				`benchy_network_head_block{network="lab"} 50`,
+				`benchy_network_block_time_seconds{network="lab"} 6`,
+				`benchy_network_missed_blocks{network="lab"} 2`,
+				`benchy_network_consensus_status{network="lab",status="degraded"} 1`,
+				`benchy_validator_sealed_blocks{network="lab",validator="alice",address="` + signerA.Hex() + `"} 8`,
+				`benchy_validator_in_turn_ratio{network="lab",validator="alice",address="` + signerA.Hex() + `"} 0.75`,
+				`benchy_validator_missed_turns{network="lab",validator="alice",address="` + signerA.Hex() + `"} 1`,
+				`benchy_validator_last_sealed_block{network="lab",validator="alice",address="` + signerA.Hex() + `"} 49`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := monitoredNetwork()
+			if tt.report != nil {
+				sm.RecordConsensusReport(tt.report)
+			}
+			var out bytes.Buffer
+			if err := NewPrometheusExporter(sm, "lab").WriteMetrics(context.Background(), &out); err != nil {
+				t.Fatalf("WriteMetrics: %v", err)
+			}
+			lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+
+			for _, want := range tt.wantLines {
+				if !containsLine(lines, want) {
+					t.Errorf("missing series %s", want)
+				}
+			}
+			for _, prefix := range tt.wantAbsent {
+				for _, line := range lines {
+					if strings.HasPrefix(line, prefix) {
+						t.Errorf("unexpected series %s", line)
+					}
+				}
+			}
+			checkExposition(t, lines)
+		})
+	}
+}
+
+// checkExposition vérifie que chaque métrique a une seule ligne HELP et TYPE, placées
+// avant ses séries
+func checkExposition(t *testing.T, lines []string) {
+	t.Helper()
+	declared := make(map[string]int)
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "# HELP "):
+		case strings.HasPrefix(line, "# TYPE "):
+			fields := strings.Fields(line)
+			if len(fields) != 4 || (fields[3] != gauge && fields[3] != counter) {
+				t.Errorf("malformed TYPE line %q", line)
+				continue
+			}
+			declared[fields[2]]++
+		default:
+			name := line[:strings.IndexAny(line, "{ ")]
+			if declared[name] == 0 {
+				t.Errorf("series %s written before its TYPE line", line)
+			}
+		}
+	}
+	for name, count := range declared {
+		if count != 1 {
+			t.Errorf("%s declared %d times", name, count)
+		}
+	}
+}
+
+// containsLine indique si lines contient exactement want
+func containsLine(lines []string, want string) bool {
+	for _, line := range lines {
+		if line == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPrometheusExporterServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		network    string
+		wantStatus int
+	}{
+		{name: "monitored network", network: "lab", wantStatus: http.StatusOK},
+		{name: "network without collector", network: "other", wantStatus: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			NewPrometheusExporter(monitoredNetwork(), tt.network).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && recorder.Header().Get("Content-Type") != prometheusContentType {
+				t.Errorf("Content-Type = %q, want %q", recorder.Header().Get("Content-Type"), prometheusContentType)
+			}
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "alice", want: `node="alice"`},
+		{value: `a"b`, want: `node="a\"b"`},
+		{value: `C:\data`, want: `node="C:\\data"`},
+		{value: "two\nlines", want: `node="two\nlines"`},
+	}
+	for _, tt := range tests {
+		if got := label("node", tt.value); got != tt.want {
+			t.Errorf("label(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
@@ -3,175 +3,293 @@ package monitoring
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"benchy/internal/domain/entities"
 	"benchy/internal/domain/ports"
-	"github.com/shirou/gopsutil/v3/cpu"
-	"github.com/shirou/gopsutil/v3/mem"
-	"github.com/shirou/gopsutil/v3/net"
 )
 
-// SystemMonitor implémente l'interface MonitoringService
+// SystemMonitor implémente l'interface MonitoringService : un collecteur par réseau
+// échantillonne les nodes en arrière-plan et les vues lisent ses dernières métriques
 type SystemMonitor struct {
-	nodeMetrics map[string]*ports.NodeMetrics
-	alerts      map[string][]*ports.Alert
+	dockerService ports.DockerService // nil : pas de stats de containers
+	ethService    ports.EthereumService
+	clients       ports.ClientAdapterProvider
+	interval      time.Duration
+	history       *MetricsHistory // nil : les tours ne sont pas conservés
+
+	mu               sync.RWMutex // Protège les maps, alimentées par les collecteurs
+	collectors       map[string]*collector
+	nodeMetrics      map[nodeKey]*ports.NodeMetrics
+	alerts           map[string][]*ports.Alert
+	consensusReports map[string]*ConsensusReport
+}
+
+var _ ports.MonitoringService = (*SystemMonitor)(nil)
+
+// nodeKey identifie un node suivi : deux réseaux peuvent avoir des nodes de même nom
+type nodeKey struct {
+	network string
+	node    string
 }
 
 // NewSystemMonitor crée un nouveau moniteur système
-func NewSystemMonitor() *SystemMonitor {
+func NewSystemMonitor(dockerService ports.DockerService, ethService ports.EthereumService, clients ports.ClientAdapterProvider) *SystemMonitor {
 	return &SystemMonitor{
-		nodeMetrics: make(map[string]*ports.NodeMetrics),
-		alerts:      make(map[string][]*ports.Alert),
+		dockerService:    dockerService,
+		ethService:       ethService,
+		clients:          clients,
+		interval:         DefaultSampleInterval,
+		collectors:       make(map[string]*collector),
+		nodeMetrics:      make(map[nodeKey]*ports.NodeMetrics),
+		alerts:           make(map[string][]*ports.Alert),
+		consensusReports: make(map[string]*ConsensusReport),
+	}
+}
+
+// SetSampleInterval change l'intervalle des collecteurs démarrés ensuite
+func (sm *SystemMonitor) SetSampleInterval(interval time.Duration) {
+	if interval > 0 {
+		sm.interval = interval
 	}
 }
 
-// StartMonitoring démarre le monitoring d'un réseau
+// SetHistory conserve les tours des collecteurs dans history, enregistré sur disque
+func (sm *SystemMonitor) SetHistory(history *MetricsHistory) {
+	sm.history = history
+}
+
+// StartMonitoring démarre le collecteur d'un réseau. Un collecteur déjà lancé pour
+// ce réseau est remplacé, pour suivre les nodes ajoutés ou retirés.
 func (sm *SystemMonitor) StartMonitoring(ctx context.Context, network *entities.Network) error {
-	// Pour l'instant, on initialise juste le monitoring
-	// TODO: Implémenter une goroutine de monitoring continu
-	
-	for _, node := range network.Nodes {
-		sm.nodeMetrics[node.Name] = &ports.NodeMetrics{
-			NodeName:  node.Name,
-			Timestamp: time.Now(),
-			IsOnline:  false,
+	previous, err := sm.stopCollector(ctx, network.Name)
+	if err != nil {
+		return err
+	}
+
+	c := newCollector(network, sm.interval)
+	collectCtx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+
+	sm.mu.Lock()
+	if previous != nil {
+		for _, node := range previous.nodes {
+			if !c.hasNode(node.Name) {
+				delete(sm.nodeMetrics, nodeKey{network.Name, node.Name})
+			}
+		}
+	}
+	for _, node := range c.nodes {
+		key := nodeKey{network.Name, node.Name}
+		if _, exists := sm.nodeMetrics[key]; !exists {
+			sm.nodeMetrics[key] = &ports.NodeMetrics{
+				NodeName:   node.Name,
+				Timestamp:  time.Now(),
+				IsOnline:   false,
+				SyncStatus: SyncStatusUnknown,
+			}
 		}
 	}
+	sm.collectors[network.Name] = c
+	sm.mu.Unlock()
 
+	go sm.collect(collectCtx, c)
 	return nil
 }
 
-// StopMonitoring arrête le monitoring d'un réseau
+// StopMonitoring arrête le collecteur d'un réseau, attend la fin de sa goroutine,
+// enregistre l'historique et oublie les métriques de ses nodes
 func (sm *SystemMonitor) StopMonitoring(ctx context.Context, networkName string) error {
-	// Nettoyer les métriques
-	// TODO: Implémenter l'arrêt propre du monitoring
+	c, err := sm.stopCollector(ctx, networkName)
+	if err != nil || c == nil {
+		return err
+	}
+
+	sm.mu.Lock()
+	for _, node := range c.nodes {
+		delete(sm.nodeMetrics, nodeKey{networkName, node.Name})
+	}
+	sm.mu.Unlock()
+
+	if sm.history != nil {
+		if err := sm.history.Save(); err != nil {
+			return fmt.Errorf("failed to save metrics history: %w", err)
+		}
+	}
 	return nil
 }
 
-// GetNodeMetrics récupère les métriques d'un node
-func (sm *SystemMonitor) GetNodeMetrics(ctx context.Context, nodeName string) (*ports.NodeMetrics, error) {
-	// Récupérer les métriques système actuelles
-	cpuUsage, err := sm.getCPUUsage()
-	if err != nil {
-		cpuUsage = 0.0
+// stopCollector arrête le collecteur d'un réseau s'il existe et le retourne
+func (sm *SystemMonitor) stopCollector(ctx context.Context, networkName string) (*collector, error) {
+	sm.mu.Lock()
+	c, exists := sm.collectors[networkName]
+	delete(sm.collectors, networkName)
+	sm.mu.Unlock()
+	if !exists {
+		return nil, nil
 	}
 
-	memoryUsage, err := sm.getMemoryUsage()
-	if err != nil {
-		memoryUsage = 0.0
+	c.cancel()
+	select {
+	case <-c.done:
+		return c, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
+}
 
-	diskUsage, err := sm.getDiskUsage()
-	if err != nil {
-		diskUsage = 0.0
+// WaitForSample attend qu'un tour d'échantillonnage commencé après since soit publié
+// et retourne son heure ; un since nul attend seulement le premier tour
+func (sm *SystemMonitor) WaitForSample(ctx context.Context, networkName string, since time.Time) (time.Time, error) {
+	sm.mu.RLock()
+	c, exists := sm.collectors[networkName]
+	sm.mu.RUnlock()
+	if !exists {
+		return time.Time{}, fmt.Errorf("network %s is not monitored", networkName)
 	}
 
-	networkIO, err := sm.getNetworkIO()
-	if err != nil {
-		networkIO = ports.NetworkIOMetrics{}
-	}
-
-	metrics := &ports.NodeMetrics{
-		NodeName:    nodeName,
-		Timestamp:   time.Now(),
-		CPUUsage:    cpuUsage,
-		MemoryUsage: memoryUsage,
-		DiskUsage:   diskUsage,
-		NetworkIO:   networkIO,
-		IsOnline:    true,
-		LastSeen:    time.Now(),
-		
-		// Métriques blockchain (seront mises à jour par d'autres services)
-		LatestBlock:    0,
-		ConnectedPeers: 0,
-		PendingTxs:     0,
-		SyncStatus:     "unknown",
-		
-		// Métriques performance
-		BlockTime:    5 * time.Second,
-		TxThroughput: 0.0,
-		ResponseTime: 100 * time.Millisecond,
-	}
-
-	// Stocker dans le cache
-	sm.nodeMetrics[nodeName] = metrics
+	for {
+		sampledAt, next := c.sampleAfter(since)
+		if next == nil {
+			return sampledAt, nil
+		}
+		select {
+		case <-next:
+		case <-c.done:
+			return time.Time{}, fmt.Errorf("monitoring of network %s was stopped", networkName)
+		case <-ctx.Done():
+			return time.Time{}, ctx.Err()
+		}
+	}
+}
+
+// monitoredNodes retourne les nodes suivis par le collecteur d'un réseau et ses compteurs
+func (sm *SystemMonitor) monitoredNodes(networkName string) ([]*entities.Node, collectorCounters, bool) {
+	sm.mu.RLock()
+	c, exists := sm.collectors[networkName]
+	sm.mu.RUnlock()
+	if !exists {
+		return nil, collectorCounters{}, false
+	}
+	return c.nodes, c.counters(), true
+}
 
+// GetNodeMetrics retourne les dernières métriques collectées pour un node du réseau
+func (sm *SystemMonitor) GetNodeMetrics(ctx context.Context, networkName, nodeName string) (*ports.NodeMetrics, error) {
+	metrics, exists := sm.latestMetrics(networkName, nodeName)
+	if !exists {
+		return nil, fmt.Errorf("no metrics for node %s: network %s is not monitored", nodeName, networkName)
+	}
 	return metrics, nil
 }
 
-// GetNetworkMetrics récupère les métriques du réseau
-func (sm *SystemMonitor) GetNetworkMetrics(ctx context.Context, networkName string) (*ports.NetworkMetrics, error) {
-	// Calculer les métriques agrégées depuis les nodes
-	totalNodes := len(sm.nodeMetrics)
-	onlineNodes := 0
-	var latestBlock uint64 = 0
-	var totalTxs uint64 = 0
-
-	for _, metrics := range sm.nodeMetrics {
-		if metrics.IsOnline {
-			onlineNodes++
-		}
-		if metrics.LatestBlock > latestBlock {
-			latestBlock = metrics.LatestBlock
-		}
+// latestMetrics retourne une copie des dernières métriques d'un node
+func (sm *SystemMonitor) latestMetrics(networkName, nodeName string) (*ports.NodeMetrics, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	metrics, exists := sm.nodeMetrics[nodeKey{networkName, nodeName}]
+	if !exists {
+		return nil, false
 	}
+	snapshot := *metrics
+	return &snapshot, true
+}
+
+// GetNetworkMetrics agrège les dernières métriques des nodes du réseau
+func (sm *SystemMonitor) GetNetworkMetrics(ctx context.Context, networkName string) (*ports.NetworkMetrics, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
 
 	networkMetrics := &ports.NetworkMetrics{
 		NetworkName:     networkName,
 		Timestamp:       time.Now(),
-		TotalNodes:      totalNodes,
-		OnlineNodes:     onlineNodes,
-		ValidatorNodes:  3, // Alice, Bob, Cassandra
-		LatestBlock:     latestBlock,
-		TotalTxs:        totalTxs,
-		AvgBlockTime:    5 * time.Second,
-		TxThroughput:    0.0,
-		NetworkLatency:  50 * time.Millisecond,
 		ConsensusStatus: "healthy",
-		MissedBlocks:    0,
-		ForkCount:       0,
+	}
+
+	if c, exists := sm.collectors[networkName]; exists {
+		networkMetrics.TotalNodes = len(c.nodes)
+		networkMetrics.ValidatorNodes = c.validators()
+		networkMetrics.AvgBlockTime = c.blockTime
+
+		var totalLatency time.Duration
+		for _, node := range c.nodes {
+			metrics, exists := sm.nodeMetrics[nodeKey{networkName, node.Name}]
+			if !exists || !metrics.IsOnline {
+				continue
+			}
+			networkMetrics.OnlineNodes++
+			totalLatency += metrics.ResponseTime
+			if metrics.LatestBlock > networkMetrics.LatestBlock {
+				networkMetrics.LatestBlock = metrics.LatestBlock
+			}
+		}
+		if networkMetrics.OnlineNodes > 0 {
+			networkMetrics.NetworkLatency = totalLatency / time.Duration(networkMetrics.OnlineNodes)
+		}
+	}
+
+	// Utiliser la dernière analyse de scellement si elle existe
+	if report, exists := sm.consensusReports[networkName]; exists {
+		networkMetrics.ConsensusStatus = report.Status
+		networkMetrics.MissedBlocks = report.MissedBlocks
+		networkMetrics.Validators = report.Validators
+		networkMetrics.ValidatorNodes = len(report.Validators)
+		if report.AvgBlockTime > 0 {
+			networkMetrics.AvgBlockTime = report.AvgBlockTime
+		}
+		if report.ToBlock > networkMetrics.LatestBlock {
+			networkMetrics.LatestBlock = report.ToBlock
+		}
 	}
 
 	return networkMetrics, nil
 }
 
+// RecordConsensusReport enregistre la dernière analyse de scellement d'un réseau
+func (sm *SystemMonitor) RecordConsensusReport(report *ConsensusReport) {
+	sm.mu.Lock()
+	sm.consensusReports[report.NetworkName] = report
+	sm.mu.Unlock()
+}
+
 // RegisterAlert enregistre une alerte
 func (sm *SystemMonitor) RegisterAlert(ctx context.Context, alert *ports.Alert) error {
 	networkKey := "default" // Pour l'instant, on utilise une clé par défaut
-	
-	if sm.alerts[networkKey] == nil {
-		sm.alerts[networkKey] = make([]*ports.Alert, 0)
-	}
-	
+
+	sm.mu.Lock()
 	sm.alerts[networkKey] = append(sm.alerts[networkKey], alert)
+	sm.mu.Unlock()
 	return nil
 }
 
 // GetActiveAlerts récupère les alertes actives
 func (sm *SystemMonitor) GetActiveAlerts(ctx context.Context, networkName string) ([]*ports.Alert, error) {
-	alerts := sm.alerts[networkName]
-	if alerts == nil {
-		return []*ports.Alert{}, nil
-	}
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
 
-	// Filtrer les alertes non résolues
-	var activeAlerts []*ports.Alert
-	for _, alert := range alerts {
+	// Filtrer les alertes non résolues ; les collecteurs modifient les originaux
+	activeAlerts := make([]*ports.Alert, 0)
+	for _, alert := range sm.alerts[networkName] {
 		if !alert.Resolved {
-			activeAlerts = append(activeAlerts, alert)
+			snapshot := *alert
+			activeAlerts = append(activeAlerts, &snapshot)
 		}
 	}
 
 	return activeAlerts, nil
 }
 
-// GetMetricsHistory récupère l'historique des métriques
-func (sm *SystemMonitor) GetMetricsHistory(ctx context.Context, nodeName string, duration time.Duration) ([]*ports.NodeMetrics, error) {
-	// Pour l'instant, on retourne juste les métriques actuelles
-	// TODO: Implémenter un vrai système d'historique
-	
-	currentMetrics, err := sm.GetNodeMetrics(ctx, nodeName)
+// GetMetricsHistory récupère les métriques d'un node sur la durée demandée, de la plus
+// ancienne à la plus récente ; sans historique, seul le dernier échantillon est retourné
+func (sm *SystemMonitor) GetMetricsHistory(ctx context.Context, networkName, nodeName string, duration time.Duration) ([]*ports.NodeMetrics, error) {
+	if sm.history != nil {
+		if history := sm.history.NodeHistory(nodeName, time.Now().Add(-duration)); len(history) > 0 {
+			return history, nil
+		}
+	}
+
+	currentMetrics, err := sm.GetNodeMetrics(ctx, networkName, nodeName)
 	if err != nil {
 		return nil, err
 	}
@@ -179,12 +297,33 @@ func (sm *SystemMonitor) GetMetricsHistory(ctx context.Context, nodeName string,
 	return []*ports.NodeMetrics{currentMetrics}, nil
 }
 
-// CheckNodeHealth vérifie la santé d'un node
-func (sm *SystemMonitor) CheckNodeHealth(ctx context.Context, node *entities.Node) (*ports.HealthStatus, error) {
+// GetNetworkMetricsHistory récupère les métriques agrégées du réseau sur la durée demandée
+func (sm *SystemMonitor) GetNetworkMetricsHistory(ctx context.Context, networkName string, duration time.Duration) ([]*ports.NetworkMetrics, error) {
+	if sm.history != nil {
+		if history := sm.history.NetworkHistory(time.Now().Add(-duration)); len(history) > 0 {
+			return history, nil
+		}
+	}
+
+	currentMetrics, err := sm.GetNetworkMetrics(ctx, networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*ports.NetworkMetrics{currentMetrics}, nil
+}
+
+// CheckNodeHealth vérifie la santé d'un node à partir des dernières métriques collectées
+func (sm *SystemMonitor) CheckNodeHealth(ctx context.Context, networkName string, node *entities.Node) (*ports.HealthStatus, error) {
 	checks := make(map[string]bool)
 	issues := make([]string, 0)
 	score := 100.0
 
+	metrics, exists := sm.latestMetrics(networkName, node.Name)
+	if !exists {
+		metrics = &ports.NodeMetrics{}
+	}
+
 	// Vérifier si le node est en ligne
 	checks["online"] = node.IsOnline()
 	if !node.IsOnline() {
@@ -192,19 +331,17 @@ func (sm *SystemMonitor) CheckNodeHealth(ctx context.Context, node *entities.Nod
 		score -= 50.0
 	}
 
-	// Vérifier l'utilisation CPU
-	cpuUsage, _ := sm.getCPUUsage()
-	checks["cpu_ok"] = cpuUsage < 80.0
-	if cpuUsage >= 80.0 {
-		issues = append(issues, fmt.Sprintf("High CPU usage: %.1f%%", cpuUsage))
+	// Vérifier l'utilisation CPU du container
+	checks["cpu_ok"] = metrics.CPUUsage < 80.0
+	if metrics.CPUUsage >= 80.0 {
+		issues = append(issues, fmt.Sprintf("High CPU usage: %.1f%%", metrics.CPUUsage))
 		score -= 20.0
 	}
 
-	// Vérifier l'utilisation mémoire
-	memUsage, _ := sm.getMemoryUsage()
-	checks["memory_ok"] = memUsage < 80.0
-	if memUsage >= 80.0 {
-		issues = append(issues, fmt.Sprintf("High memory usage: %.1f%%", memUsage))
+	// Vérifier l'utilisation mémoire du container par rapport à sa limite
+	checks["memory_ok"] = metrics.MemoryUsage < 80.0
+	if metrics.MemoryUsage >= 80.0 {
+		issues = append(issues, fmt.Sprintf("High memory usage: %.1f%%", metrics.MemoryUsage))
 		score -= 20.0
 	}
 
@@ -270,51 +407,3 @@ func (sm *SystemMonitor) CheckNetworkHealth(ctx context.Context, network *entiti
 
 	return healthStatus, nil
 }
-
-// Méthodes utilitaires privées pour récupérer les métriques système
-
-func (sm *SystemMonitor) getCPUUsage() (float64, error) {
-	percentages, err := cpu.Percent(time.Second, false)
-	if err != nil {
-		return 0, err
-	}
-	
-	if len(percentages) > 0 {
-		return percentages[0], nil
-	}
-	
-	return 0, nil
-}
-
-func (sm *SystemMonitor) getMemoryUsage() (float64, error) {
-	vmem, err := mem.VirtualMemory()
-	if err != nil {
-		return 0, err
-	}
-	
-	return vmem.UsedPercent, nil
-}
-
-func (sm *SystemMonitor) getDiskUsage() (float64, error) {
-	// Pour l'instant, on retourne une valeur fixe
-	// TODO: Implémenter la vraie récupération de l'usage disque
-	return 45.0, nil
-}
-
-func (sm *SystemMonitor) getNetworkIO() (ports.NetworkIOMetrics, error) {
-	stats, err := net.IOCounters(false)
-	if err != nil {
-		return ports.NetworkIOMetrics{}, err
-	}
-	
-	if len(stats) > 0 {
-		return ports.NetworkIOMetrics{
-			BytesReceived:   stats[0].BytesRecv,
-			BytesSent:      stats[0].BytesSent,
-			PacketsReceived: stats[0].PacketsRecv,
-			PacketsSent:    stats[0].PacketsSent,
-		}, nil
-	}
-	
-	return ports.NetworkIOMetrics{}, nil
-}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// Node interrogé par la commande block
+var blockNode string
+
+// blockCmd représente la commande block
+var blockCmd = &cobra.Command{
+	Use:   "block [number|latest]",
+	Short: "Inspect a block",
+	Long: `Display a block as seen by a node:
+- Clique signer recovered from the seal in extraData
+- Difficulty (in-turn or out-of-turn)
+- Gas usage
+- Transactions with their receipt status`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Créer le handler
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		// Créer le contexte
+		ctx := context.Background()
+
+		// Afficher le bloc
+		return handler.HandleBlock(ctx, args[0], blockNode)
+	},
+}
+
+func init() {
+	blockCmd.Flags().StringVarP(&blockNode, "node", "n", "alice", "Node to query")
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// configCmd regroupe les commandes sur la configuration des nodes
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the node configuration",
+}
+
+// configRenderCmd affiche le fichier de configuration client d'un node
+var configRenderCmd = &cobra.Command{
+	Use:   "render [node]",
+	Short: "Show a node's effective client config file",
+	Long: `Render the client config file mounted into a node's container:
+- geth: TOML file passed with --config
+- Nethermind: JSON file replacing the mainnet preset
+- Besu: TOML file passed with --config-file, static nodes in the datadir
+- Erigon: TOML file passed with --config
+
+The client_config section of a node in .benchy.yaml is merged into the
+generated file, keys matching case-insensitively:
+
+  topology:
+    nodes:
+      - name: alice
+        client: geth
+        client_config:
+          Eth: {TrieTimeout: 60000000000}
+          Node: {P2P: {MaxPeers: 50}}`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleConfigRender(ctx, args[0])
+	},
+}
+
+// configValidateCmd vérifie .benchy.yaml sans rien lancer
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the topology, keys and genesis configuration",
+	Long: `Check .benchy.yaml without launching anything, reporting every problem at once:
+- node names, clients, resources and port ranges, duplicate ports and
+  ports already used by another launched network
+- validator quorum: Clique halts when a majority of validators is offline
+- keys: mnemonic or seed, and distinct derivation paths
+- genesis: chain ID, forks, gas limit, alloc file and the accounts it overrides
+- client compatibility (Besu cannot seal with period 0) and image references
+
+Errors make the command fail; warnings are only reported. The same checks
+run automatically before launch-network.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleConfigValidate(ctx)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configRenderCmd)
+	configCmd.AddCommand(configValidateCmd)
+}
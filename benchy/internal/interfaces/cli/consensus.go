@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// Options de la commande consensus : nombre de blocs récents analysés et node interrogé
+var (
+	consensusBlocks int
+	consensusNode   string
+)
+
+// consensusCmd représente la commande consensus
+var consensusCmd = &cobra.Command{
+	Use:   "consensus",
+	Short: "Analyze Clique block sealing per validator",
+	Long: `Walk recent blocks, recover their Clique signers and report per validator:
+- Sealed blocks, in-turn and out-of-turn (difficulty 2 vs 1)
+- In-turn ratio and missed turns
+- Longest gap without sealing
+- A sealing timeline showing exactly when a validator stopped producing blocks`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if consensusBlocks <= 0 {
+			return fmt.Errorf("invalid block count %d. Use a positive number", consensusBlocks)
+		}
+
+		// Créer le handler
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		// Créer le contexte
+		ctx := context.Background()
+
+		// Afficher le rapport de consensus
+		return handler.HandleConsensus(ctx, consensusNode, consensusBlocks)
+	},
+}
+
+func init() {
+	consensusCmd.Flags().IntVarP(&consensusBlocks, "blocks", "b", 100, "Number of recent blocks to analyze")
+	consensusCmd.Flags().StringVarP(&consensusNode, "node", "n", "alice", "Node to query")
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"benchy/internal/application/handlers"
+	"benchy/internal/infrastructure/contracts"
+	"github.com/spf13/cobra"
+)
+
+// Options partagées par les sous-commandes contract
+var (
+	contractArtifacts string
+	contractNode      string
+	contractFrom      string
+	contractAlias     string
+	contractValue     string
+)
+
+// contractCmd regroupe les commandes de gestion des contrats
+var contractCmd = &cobra.Command{
+	Use:   "contract",
+	Short: "Deploy and interact with smart contracts",
+	Long: `Manage compiled contracts (solc, Foundry or Hardhat JSON artifacts):
+- Artifacts are loaded from ~/.benchy/contracts/artifacts and --artifacts
+- Deployed addresses are remembered per network and referenced by name
+- Arguments are converted from the ABI: node names are accepted as addresses`,
+}
+
+// contractListCmd liste les artifacts et les déploiements
+var contractListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List contract artifacts and deployed contracts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleContractList(ctx, contractArtifacts)
+	},
+}
+
+// contractDeployCmd déploie un artifact
+var contractDeployCmd = &cobra.Command{
+	Use:     "deploy [artifact] [constructor args...]",
+	Short:   "Deploy a contract artifact",
+	Example: `  benchy contract deploy ERC20 "Benchy Token" BNY 1e24 --as token --from alice`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleContractDeploy(ctx, contractArtifacts, args[0], contractAlias, args[1:], contractFrom, contractNode)
+	},
+}
+
+// contractCallCmd appelle une méthode en lecture seule
+var contractCallCmd = &cobra.Command{
+	Use:     "call [contract] [method] [args...]",
+	Short:   "Call a read-only contract method",
+	Example: `  benchy contract call token balanceOf bob`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleContractCall(ctx, contractArtifacts, args[0], args[1], args[2:], contractNode)
+	},
+}
+
+// contractSendCmd envoie une transaction vers une méthode
+var contractSendCmd = &cobra.Command{
+	Use:     "send [contract] [method] [args...]",
+	Short:   "Send a transaction calling a contract method",
+	Example: `  benchy contract send token transfer bob 1e18 --from alice`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		value, err := contracts.ParseAmount(contractValue)
+		if err != nil {
+			return fmt.Errorf("invalid --value: %w", err)
+		}
+		if value.Sign() < 0 {
+			return fmt.Errorf("invalid --value: negative amount")
+		}
+
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleContractSend(ctx, contractArtifacts, args[0], args[1], args[2:], contractFrom, contractNode, value)
+	},
+}
+
+func init() {
+	contractCmd.PersistentFlags().StringVar(&contractArtifacts, "artifacts", "", "Additional directory of contract artifacts")
+	contractCmd.PersistentFlags().StringVarP(&contractNode, "node", "n", "alice", "Node to send requests to")
+
+	contractDeployCmd.Flags().StringVar(&contractAlias, "as", "", "Name to remember the deployment under (default: artifact name)")
+	contractDeployCmd.Flags().StringVar(&contractFrom, "from", "alice", "Node whose key signs the deployment")
+	contractSendCmd.Flags().StringVar(&contractFrom, "from", "alice", "Node whose key signs the transaction")
+	contractSendCmd.Flags().StringVar(&contractValue, "value", "0", "Wei sent with the transaction")
+
+	contractCmd.AddCommand(contractListCmd)
+	contractCmd.AddCommand(contractDeployCmd)
+	contractCmd.AddCommand(contractCallCmd)
+	contractCmd.AddCommand(contractSendCmd)
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// Adresse d'écoute du dashboard web
+var dashboardAddr string
+
+// dashboardCmd sert un dashboard web du réseau lancé
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Serve a live web dashboard of the network",
+	Long: `Start a local web server with a live dashboard of the launched network:
+- the node table of 'benchy infos' (status, block, peers, txpool, CPU,
+  memory, RPC latency, balance)
+- the head block progression over the last 10 minutes
+- the sealing activity of each validator over the last 100 blocks
+- active alerts (node down, high CPU or memory, no peers)
+- the transactions mined in the recent blocks
+
+The page is updated over Server-Sent Events after every collector round,
+every monitoring.interval (default 5s) or every -u seconds.`,
+	Example: `  benchy dashboard
+  benchy dashboard --addr :8080 -u 2`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Créer le handler
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		// Le serveur s'arrête proprement sur Ctrl+C ou à l'arrêt du service
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		return handler.HandleDashboard(ctx, dashboardAddr, updateInterval)
+	},
+}
+
+func init() {
+	dashboardCmd.Flags().StringVar(&dashboardAddr, "addr", "127.0.0.1:8080", "Address to serve the dashboard on")
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// Options de la commande events
+var (
+	eventsContract  string
+	eventsName      string
+	eventsFromBlock uint64
+	eventsNode      string
+)
+
+// eventsCmd représente la commande events
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Stream decoded contract events live",
+	Long: `Poll a node with eth_getLogs and print the events of a deployed contract,
+decoded with its ABI (e.g. Transfer(from=alice, to=bob, value=1000)).
+Known node and contract addresses are shown by name. The logs of an address
+that is not a known deployment are decoded against every registered ABI.`,
+	Example: `  benchy events --contract bny --event Transfer
+  benchy events --contract 0x5FbDB2315678afecb367f032d93F642f64180aa3 --from-block 0`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Créer le handler
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		// Le flux s'arrête proprement sur Ctrl+C
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		// --from-block 0 rejoue depuis la genèse, sans le flag le flux part du prochain bloc
+		var fromBlock *uint64
+		if cmd.Flags().Changed("from-block") {
+			fromBlock = &eventsFromBlock
+		}
+
+		return handler.HandleEvents(ctx, eventsContract, eventsName, fromBlock, eventsNode)
+	},
+}
+
+func init() {
+	eventsCmd.Flags().StringVarP(&eventsContract, "contract", "c", "", "Deployed contract name or address")
+	eventsCmd.Flags().StringVarP(&eventsName, "event", "e", "", "Only stream this event (default: all events)")
+	eventsCmd.Flags().Uint64Var(&eventsFromBlock, "from-block", 0, "Replay events from this block (default: next block)")
+	eventsCmd.Flags().StringVarP(&eventsNode, "node", "n", "alice", "Node to query")
+	eventsCmd.MarkFlagRequired("contract")
+}
@@ -11,7 +11,7 @@ import (
 
 // failureCmd représente la commande temporary-failure
 var failureCmd = &cobra.Command{
-	Use:   "temporary-failure [alice|bob|cassandra|driss|elena]",
+	Use:   "temporary-failure [node]",
 	Short: "Simulate node failure",
 	Long: `Simulate a temporary failure by stopping a node for 40 seconds:
 - The node will be stopped immediately
@@ -21,20 +21,6 @@ var failureCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		nodeName := strings.ToLower(args[0])
 		
-		// Valider le nom du node
-		validNodes := []string{"alice", "bob", "cassandra", "driss", "elena"}
-		isValid := false
-		for _, valid := range validNodes {
-			if nodeName == valid {
-				isValid = true
-				break
-			}
-		}
-		
-		if !isValid {
-			return fmt.Errorf("invalid node name '%s'. Valid nodes: alice, bob, cassandra, driss, elena", nodeName)
-		}
-		
 		// Créer le handler
 		handler, err := handlers.NewCLIHandler()
 		if err != nil {
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// genesisCmd regroupe les commandes sur le genesis du réseau
+var genesisCmd = &cobra.Command{
+	Use:   "genesis",
+	Short: "Inspect and verify the network genesis",
+}
+
+// genesisShowRaw affiche le fichier tel quel au lieu du résumé
+var genesisShowRaw bool
+
+// genesisShowCmd affiche un fichier genesis
+var genesisShowCmd = &cobra.Command{
+	Use:   "show [file]",
+	Short: "Show a genesis file and its block hash",
+	Long: `Show the chain ID, Clique parameters, forks, signers, allocations and the
+genesis block hash of a genesis file, by default the one of the launched network.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		var path string
+		if len(args) > 0 {
+			path = args[0]
+		}
+		ctx := context.Background()
+		return handler.HandleGenesisShow(ctx, path, genesisShowRaw)
+	},
+}
+
+// genesisDiffCmd compare deux fichiers genesis
+var genesisDiffCmd = &cobra.Command{
+	Use:   "diff <file> [other]",
+	Short: "Compare two genesis files field by field",
+	Long: `Compare two genesis files field by field (chain config, extraData, alloc...)
+and their genesis block hashes. With a single file, it is compared to the
+genesis of the launched network.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		var other string
+		if len(args) > 1 {
+			other = args[1]
+		}
+		ctx := context.Background()
+		return handler.HandleGenesisDiff(ctx, args[0], other)
+	},
+}
+
+// genesisVerifyCmd vérifie que tous les nodes partagent le même genesis
+var genesisVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check every node's block 0 against the expected genesis hash",
+	Long: `Compare the block 0 hash reported by every node with the genesis hash saved
+in the network manifest at launch. A node initialised from a stale or
+different genesis never peers with the others: remove its data directory
+and relaunch.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleGenesisVerify(ctx)
+	},
+}
+
+func init() {
+	genesisShowCmd.Flags().BoolVar(&genesisShowRaw, "raw", false, "Print the genesis file as is")
+	genesisCmd.AddCommand(genesisShowCmd)
+	genesisCmd.AddCommand(genesisDiffCmd)
+	genesisCmd.AddCommand(genesisVerifyCmd)
+}
@@ -3,11 +3,18 @@ package cli
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"benchy/internal/application/handlers"
 	"github.com/spf13/cobra"
 )
 
+// Afficher une colonne par token ERC20 déployé
+var infosTokens bool
+
+// Fenêtre de l'historique à afficher, 0 = état actuel
+var infosHistory time.Duration
+
 // infosCmd représente la commande infos
 var infosCmd = &cobra.Command{
 	Use:   "infos",
@@ -17,7 +24,15 @@ var infosCmd = &cobra.Command{
 - Connected peers
 - Mempool transactions count
 - CPU and memory consumption
-- Ethereum address and balance`,
+- Ethereum address and balance
+- ERC20 balances of every deployed token (--tokens)
+
+With --history, show the trend of each node over a time window instead
+(block height progression, peer drops, memory growth, uptime). Samples are
+recorded whenever a collector runs ('benchy infos', 'benchy infos -u N') and
+kept in the network directory, at full resolution for the last 15 minutes and
+averaged per monitoring.resolution (default 1m) up to monitoring.retention
+(default 24h) in the configuration file.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Créer le handler
 		handler, err := handlers.NewCLIHandler()
@@ -28,7 +43,19 @@ var infosCmd = &cobra.Command{
 		// Créer le contexte
 		ctx := context.Background()
 
+		if infosHistory > 0 {
+			if updateInterval > 0 {
+				return fmt.Errorf("--history cannot be combined with --update")
+			}
+			return handler.HandleInfosHistory(ctx, infosHistory)
+		}
+
 		// Exécuter le monitoring
-		return handler.HandleInfos(ctx, updateInterval)
+		return handler.HandleInfos(ctx, updateInterval, infosTokens)
 	},
 }
+
+func init() {
+	infosCmd.Flags().BoolVarP(&infosTokens, "tokens", "t", false, "Show a balance column for every ERC20 token deployed on the network")
+	infosCmd.Flags().DurationVar(&infosHistory, "history", 0, "Show node trends over this window (e.g. 15m, 2h) from the recorded metrics history")
+}
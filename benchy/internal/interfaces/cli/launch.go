@@ -12,11 +12,54 @@ import (
 var launchCmd = &cobra.Command{
 	Use:   "launch-network",
 	Short: "Launch a private Ethereum network",
-	Long: `Launch a private Ethereum network with 5 nodes:
+	Long: `Launch a private Ethereum network using Clique consensus.
+
+The nodes are read from the topology section of .benchy.yaml:
+
+  topology:
+    nodes:
+      - name: alice
+        client: geth            # geth, nethermind, besu or erigon
+        validator: true
+        rpc_port: 8545          # optional, default 8545 + position
+        image: ethereum/client-go:v1.10.26
+        resources: {cpus: 1.5, memory: 2g}
+        extra_flags: ["--verbosity", "4"]
+        client_config:          # merged into the client config file,
+          Node: {P2P: {MaxPeers: 50}}   # see 'benchy config render alice'
+
+Node keys are random unless a mnemonic (or a hex seed) is configured; they
+are then derived along BIP-44, so relaunching gives the same addresses:
+
+  keys:
+    mnemonic: "test test test test test test test test test test test junk"
+    path: m/44'/60'/0'/0            # node i uses <path>/i
+    accounts: 10                    # extra funded test accounts account-0..9
+    accounts_path: m/44'/60'/1'/0
+    account_balance: 100            # ETH
+    nodekeys_path: m/44'/60'/2'/0   # p2p node keys, hence enode URLs; besu and
+                                    # erigon sign blocks with it, so reuse the account key
+
+The genesis defaults to chain ID 1337, 5s Clique blocks, a 30000 epoch, an
+8M gas limit and every fork up to London at block 0:
+
+  genesis:
+    chain_id: 1337
+    period: 5                       # seconds, 0 = seal on demand
+    epoch: 30000
+    gas_limit: 8000000
+    validator_balance: 1000         # ETH
+    node_balance: 10                # ETH
+    alloc_file: predeploys.json     # geth alloc format: balance, code, storage, nonce
+    forks: {arrow_glacier: 100, gray_glacier: 200}
+
+The configuration is checked before anything is launched and every problem
+is reported at once; run 'benchy config validate' to check it alone.
+
+Without a topology, 5 nodes are launched:
 - Alice, Bob, Cassandra (validators)
 - Driss, Elena (normal nodes)
-- Mix of Geth and Nethermind clients
-- Clique consensus algorithm`,
+- Mix of Geth and Nethermind clients`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Créer le handler
 		handler, err := handlers.NewCLIHandler()
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// networksCmd regroupe les commandes sur les réseaux de l'hôte
+var networksCmd = &cobra.Command{
+	Use:   "networks",
+	Short: "Manage the networks running side by side on this host",
+}
+
+// networksLsCmd liste les réseaux connus
+var networksLsCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List the networks of this host",
+	Long: `List the default network and every named network created with --network.
+
+Each named network keeps its state in ~/.benchy/networks/<name> and gets its
+own Docker network (benchy-<name>-network), container names (benchy-<name>-<node>),
+a port offset of 100 per network and, unless genesis.chain_id is set, its own
+chain ID (1337 + offset / 100):
+
+  benchy --network ci-1 launch-network
+  BENCHY_NETWORK=ci-1 benchy infos`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleNetworksList(ctx)
+	},
+}
+
+func init() {
+	networksCmd.AddCommand(networksLsCmd)
+}
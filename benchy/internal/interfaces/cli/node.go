@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"benchy/internal/application/handlers"
+	"benchy/internal/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+// nodeCmd regroupe les commandes qui modifient les nodes d'un réseau lancé
+var nodeCmd = &cobra.Command{
+	Use:   "node",
+	Short: "Add or remove nodes on the running network",
+}
+
+// Options de node add
+var (
+	nodeAddClient    string
+	nodeAddValidator bool
+	nodeAddImage     string
+)
+
+// nodeRemoveForce retire le node même si le vote Clique échoue
+var nodeRemoveForce bool
+
+// nodeAddCmd ajoute un node au réseau lancé
+var nodeAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a node to the running network",
+	Long: `Generate the keys of a new node, start its container from the network genesis
+and peer it with the other nodes. With --validator, the current validators
+vote it in through Clique: it only seals blocks once a majority of them
+have voted, which takes a few block periods.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleNodeAdd(ctx, args[0], entities.ClientType(nodeAddClient), nodeAddValidator, nodeAddImage)
+	},
+}
+
+// nodeRemoveCmd retire un node du réseau lancé
+var nodeRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a node from the running network",
+	Long: `Remove a node and its container from the running network. A validator is
+first voted out by the validators so that the remaining ones keep sealing;
+use --force to remove it even if the vote fails.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleNodeRemove(ctx, args[0], nodeRemoveForce)
+	},
+}
+
+func init() {
+	nodeAddCmd.Flags().StringVar(&nodeAddClient, "client", string(entities.ClientGeth), "Client of the node (geth, nethermind, besu, erigon)")
+	nodeAddCmd.Flags().BoolVar(&nodeAddValidator, "validator", false, "Vote the node in as a Clique validator")
+	nodeAddCmd.Flags().StringVar(&nodeAddImage, "image", "", "Docker image to use instead of the client default")
+	nodeRemoveCmd.Flags().BoolVar(&nodeRemoveForce, "force", false, "Remove the node even if the validators fail to vote it out")
+	nodeCmd.AddCommand(nodeAddCmd)
+	nodeCmd.AddCommand(nodeRemoveCmd)
+}
@@ -11,6 +11,9 @@ import (
 var (
 	// Flag global pour l'option -u (update interval)
 	updateInterval int
+
+	// Flag global --network : réseau sur lequel agit la commande
+	networkName string
 )
 
 // rootCmd représente la commande de base quand appelée sans sous-commandes
@@ -39,11 +42,29 @@ func init() {
 	rootCmd.PersistentFlags().IntVarP(&updateInterval, "update", "u", 0, 
 		"Update interval in seconds for continuous monitoring (0 = no update)")
 
+	// Flag global --network, aussi lu depuis BENCHY_NETWORK
+	rootCmd.PersistentFlags().StringVar(&networkName, "network", "",
+		"Network to act on, to run several networks side by side (default \"benchy\", env BENCHY_NETWORK)")
+	cobra.CheckErr(viper.BindPFlag("network", rootCmd.PersistentFlags().Lookup("network")))
+	cobra.CheckErr(viper.BindEnv("network", "BENCHY_NETWORK"))
+
 	// Ajouter toutes les sous-commandes
 	rootCmd.AddCommand(launchCmd)
 	rootCmd.AddCommand(infosCmd)
 	rootCmd.AddCommand(scenarioCmd)
 	rootCmd.AddCommand(failureCmd)
+	rootCmd.AddCommand(blockCmd)
+	rootCmd.AddCommand(txCmd)
+	rootCmd.AddCommand(consensusCmd)
+	rootCmd.AddCommand(contractCmd)
+	rootCmd.AddCommand(tokenCmd)
+	rootCmd.AddCommand(eventsCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(networksCmd)
+	rootCmd.AddCommand(genesisCmd)
+	rootCmd.AddCommand(nodeCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(dashboardCmd)
 }
 
 // initConfig lit la configuration depuis un fichier config et les variables d'environnement
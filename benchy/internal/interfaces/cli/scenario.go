@@ -15,8 +15,8 @@ var scenarioCmd = &cobra.Command{
 	Short: "Run network test scenarios",
 	Long: `Run predefined scenarios to test network behavior:
 
-Scenario 0 (init):        Initialize network with ETH for validators
-Scenario 1 (transfers):   Alice sends 0.1 ETH to Bob every 10 seconds  
+Scenario 0 (init):        Check that the network answers and Alice holds ETH
+Scenario 1 (transfers):   Alice sends 3 transfers of 0.1 ETH to Bob, 10 seconds apart
 Scenario 2 (erc20):       Deploy ERC20 token and distribute to Driss/Elena
 Scenario 3 (replacement): Test transaction replacement with higher fee`,
 	Args: cobra.ExactArgs(1),
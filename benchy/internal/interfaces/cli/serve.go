@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// Adresses d'écoute de l'exporteur Prometheus et de l'API REST
+var (
+	serveMetricsAddr string
+	serveAPIAddr     string
+)
+
+// serveCmd expose le réseau lancé à d'autres outils
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Expose the network to Prometheus and other tools over HTTP",
+	Long: `Run the metrics collector of the launched network in the foreground and
+serve it over HTTP until interrupted.
+
+With --metrics-addr, samples are exposed on /metrics in the Prometheus text
+format:
+- per node (labels node, client, validator): up, head block, peers, pending
+  and queued txs, CPU, memory, RPC latency, network I/O, probe failures
+- per network: online nodes, head block, block time, missed blocks,
+  consensus status and active alerts by type
+- per validator: sealed blocks, in-turn ratio and missed turns over the
+  last 100 blocks
+
+With --api-addr, a JSON REST API under /api/v1 lets test harnesses and CI
+pipelines drive benchy without scraping console output:
+- GET  /network, /network/metrics, /nodes, /nodes/{name}, /alerts
+- POST /scenarios, GET /scenarios/{id}, POST /scenarios/{id}/stop
+- POST /nodes/{name}/failure, GET /failures
+The OpenAPI schema of the API is served on /api/v1/openapi.yaml.
+
+Samples are taken every monitoring.interval (default 5s) and also recorded
+in the metrics history read by 'benchy infos --history'.`,
+	Example: `  benchy serve --metrics-addr :9100
+  benchy serve --api-addr 127.0.0.1:8081
+  curl -X POST localhost:8081/api/v1/scenarios -d '{"scenario": "transfers"}'
+  curl -X POST localhost:8081/api/v1/nodes/bob/failure -d '{"duration_seconds": 30}'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Créer le handler
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		// Le serveur s'arrête proprement sur Ctrl+C ou à l'arrêt du service
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		return handler.HandleServe(ctx, serveMetricsAddr, serveAPIAddr)
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveMetricsAddr, "metrics-addr", "", "Address to expose Prometheus metrics on (e.g. :9100)")
+	serveCmd.Flags().StringVar(&serveAPIAddr, "api-addr", "", "Address to serve the REST API on (e.g. 127.0.0.1:8081)")
+}
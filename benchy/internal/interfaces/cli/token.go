@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// Options partagées par les sous-commandes token
+var (
+	tokenNode  string
+	tokenFrom  string
+	tokenAlias string
+)
+
+// tokenCmd regroupe les commandes ERC20
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Deploy and use ERC20 tokens",
+	Long: `Deploy the embedded standard ERC20 token and move tokens between nodes.
+Amounts are in base units and accept scientific notation (1e18 = 1 token with 18 decimals).
+Nodes and deployed contracts can be referenced by name instead of address.`,
+}
+
+// tokenDeployCmd déploie le token ERC20 embarqué
+var tokenDeployCmd = &cobra.Command{
+	Use:     "deploy [name] [symbol] [supply]",
+	Short:   "Deploy the embedded ERC20 token",
+	Example: `  benchy token deploy "Benchy Token" BNY 1e24 --from alice`,
+	Args:    cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleTokenDeploy(ctx, args[0], args[1], args[2], tokenAlias, tokenFrom, tokenNode)
+	},
+}
+
+// tokenBalancesCmd affiche les soldes d'un token par node
+var tokenBalancesCmd = &cobra.Command{
+	Use:   "balances [token]",
+	Short: "Show the token balance of every node",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleTokenBalances(ctx, args[0], tokenNode)
+	},
+}
+
+// tokenAllowanceCmd affiche une allowance
+var tokenAllowanceCmd = &cobra.Command{
+	Use:   "allowance [token] [owner] [spender]",
+	Short: "Show how much a spender may transfer on behalf of an owner",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleTokenAllowance(ctx, args[0], args[1], args[2], tokenNode)
+	},
+}
+
+// tokenTransferCmd envoie des tokens
+var tokenTransferCmd = &cobra.Command{
+	Use:     "transfer [token] [to] [amount]",
+	Short:   "Transfer tokens",
+	Example: `  benchy token transfer bny bob 1e18 --from alice`,
+	Args:    cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleTokenTransfer(ctx, args[0], args[1], args[2], tokenFrom, tokenNode)
+	},
+}
+
+// tokenApproveCmd autorise un spender
+var tokenApproveCmd = &cobra.Command{
+	Use:     "approve [token] [spender] [amount]",
+	Short:   "Allow a spender to transfer tokens",
+	Example: `  benchy token approve bny cassandra 5e17 --from alice`,
+	Args:    cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleTokenApprove(ctx, args[0], args[1], args[2], tokenFrom, tokenNode)
+	},
+}
+
+// tokenTransferFromCmd dépense une allowance
+var tokenTransferFromCmd = &cobra.Command{
+	Use:     "transfer-from [token] [owner] [to] [amount]",
+	Short:   "Transfer tokens using an allowance",
+	Example: `  benchy token transfer-from bny alice driss 1e17 --from cassandra`,
+	Args:    cobra.ExactArgs(4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleTokenTransferFrom(ctx, args[0], args[1], args[2], args[3], tokenFrom, tokenNode)
+	},
+}
+
+func init() {
+	tokenCmd.PersistentFlags().StringVarP(&tokenNode, "node", "n", "alice", "Node to send requests to")
+
+	tokenDeployCmd.Flags().StringVar(&tokenAlias, "as", "", "Name to remember the token under (default: lowercase symbol)")
+	for _, cmd := range []*cobra.Command{tokenDeployCmd, tokenTransferCmd, tokenApproveCmd, tokenTransferFromCmd} {
+		cmd.Flags().StringVar(&tokenFrom, "from", "alice", "Node whose key signs the transaction")
+	}
+
+	tokenCmd.AddCommand(tokenDeployCmd)
+	tokenCmd.AddCommand(tokenBalancesCmd)
+	tokenCmd.AddCommand(tokenAllowanceCmd)
+	tokenCmd.AddCommand(tokenTransferCmd)
+	tokenCmd.AddCommand(tokenApproveCmd)
+	tokenCmd.AddCommand(tokenTransferFromCmd)
+}
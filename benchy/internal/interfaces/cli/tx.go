@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"benchy/internal/application/handlers"
+	"github.com/spf13/cobra"
+)
+
+// Node interrogé par la commande tx et ses sous-commandes
+var txNode string
+
+// txCmd représente la commande tx
+var txCmd = &cobra.Command{
+	Use:   "tx [hash]",
+	Short: "Inspect a transaction",
+	Long: `Display a transaction receipt as seen by a node:
+- Receipt status and gas used
+- Sender and recipient shown by node name
+- Signer and seal of the including block`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Créer le handler
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		// Créer le contexte
+		ctx := context.Background()
+
+		// Afficher la transaction
+		return handler.HandleTransaction(ctx, args[0], txNode)
+	},
+}
+
+// txTraceCmd affiche l'arbre d'appels d'une transaction
+var txTraceCmd = &cobra.Command{
+	Use:   "trace [hash]",
+	Short: "Trace a transaction's call tree",
+	Long: `Replay a transaction on a node and show its execution:
+- Call tree with decoded methods and node names
+- Gas used by every call frame
+- Revert reason of failed calls
+
+Uses the callTracer of debug_traceTransaction on geth and Erigon, and
+trace_transaction on Nethermind and Besu.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := handlers.NewCLIHandler()
+		if err != nil {
+			return fmt.Errorf("failed to initialize handler: %w", err)
+		}
+
+		ctx := context.Background()
+		return handler.HandleTransactionTrace(ctx, args[0], txNode)
+	},
+}
+
+func init() {
+	txCmd.PersistentFlags().StringVarP(&txNode, "node", "n", "alice", "Node to query")
+
+	txCmd.AddCommand(txTraceCmd)
+}